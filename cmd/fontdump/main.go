@@ -0,0 +1,62 @@
+// Command fontdump prints the tables of a font file, as parsed
+// by this module, in JSON or XML format.
+//
+// Usage:
+//
+//	fontdump [-format json|xml] [-tables head,GSUB,...] font-file
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/benoitkugler/textlayout/fontdump"
+)
+
+func main() {
+	format := flag.String("format", "json", "output format: json or xml")
+	tables := flag.String("tables", "", "comma separated list of tables to dump (default to all)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [options] font-file\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 || (*format != "json" && *format != "xml") {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	var tags []string
+	if *tables != "" {
+		tags = strings.Split(*tables, ",")
+	}
+
+	f, err := os.Open(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	faces, err := fontdump.DumpFile(f, tags...)
+	if err != nil {
+		log.Fatalf("reading %s: %s", flag.Arg(0), err)
+	}
+
+	root := faces[0]
+	if len(faces) > 1 { // collection
+		root = fontdump.NewList("collection", faces)
+	}
+
+	if *format == "json" {
+		err = root.WriteJSON(os.Stdout)
+	} else {
+		err = root.WriteXML(os.Stdout)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
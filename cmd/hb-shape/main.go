@@ -0,0 +1,374 @@
+// Command hb-shape shapes text with the harfbuzz package, and
+// outputs the result using the format of the hb-shape utility
+// of the C HarfBuzz library, so that both outputs may be compared.
+//
+// Usage:
+//
+//	hb-shape [options] font-file [text]
+//
+// When no text is given (either as argument or with -text-file or -unicodes),
+// it is read from the standard input. Each line is shaped separately.
+// Run hb-shape -help for the list of options.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/benoitkugler/textlayout/fonts"
+	tt "github.com/benoitkugler/textlayout/fonts/truetype"
+	"github.com/benoitkugler/textlayout/harfbuzz"
+	"github.com/benoitkugler/textlayout/language"
+)
+
+type options struct {
+	// font
+	faceIndex  int
+	fontSize   string
+	fontPpem   string
+	fontPtem   float64
+	variations string
+
+	// text
+	text, textFile                string
+	unicodes                      string
+	textBefore, textAfter         string
+	unicodesBefore, unicodesAfter string
+
+	// shaping
+	features     string
+	direction    string
+	script       string
+	lang         string
+	clusterLevel int
+	invisible    uint
+	notFound     uint
+	bufferFlags  harfbuzz.ShappingOptions
+
+	// output
+	format      string
+	serialize   harfbuzz.SerializeFlags
+	showText    bool
+	showUnicode bool
+	trace       bool
+}
+
+// bitFlag is a boolean command line flag, whose
+// value is stored by calling `apply`
+type bitFlag func()
+
+func (bitFlag) String() string   { return "false" }
+func (bitFlag) IsBoolFlag() bool { return true }
+
+func (f bitFlag) Set(s string) error {
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	if v {
+		f()
+	}
+	return nil
+}
+
+func boolFlag(flags *flag.FlagSet, name, usage string, apply func()) {
+	flags.Var(bitFlag(apply), name, usage)
+}
+
+func parseOptions() (opts options, fontFile string) {
+	flags := flag.CommandLine
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s [options] font-file [text]\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+
+	flags.IntVar(&opts.faceIndex, "face-index", 0, "Set face index")
+	flags.StringVar(&opts.fontSize, "font-size", "upem", "Font size, as one or two space-separated numbers, or 'upem'")
+	flags.StringVar(&opts.fontPpem, "font-ppem", "", "Set x,y pixels per EM, as one or two space-separated numbers (default: 0; disabled)")
+	flags.Float64Var(&opts.fontPtem, "font-ptem", 0, "Set font point-size (default: 0; disabled)")
+	flags.StringVar(&opts.variations, "variations", "", "Comma-separated list of font variations, such as 'wght=500,slnt=-7.5'")
+
+	flags.StringVar(&opts.text, "text", "", "Set input text")
+	flags.StringVar(&opts.textFile, "text-file", "", "Set input text file-name")
+	flags.StringVar(&opts.unicodes, "unicodes", "", "Set input Unicode codepoints, such as 'U+0041,U+0042'")
+	flags.StringVar(&opts.textBefore, "text-before", "", "Set text context before each line")
+	flags.StringVar(&opts.textAfter, "text-after", "", "Set text context after each line")
+	flags.StringVar(&opts.unicodesBefore, "unicodes-before", "", "Set Unicode codepoints context before each line")
+	flags.StringVar(&opts.unicodesAfter, "unicodes-after", "", "Set Unicode codepoints context after each line")
+
+	flags.StringVar(&opts.features, "features", "", "Comma-separated list of font features, such as 'kern,-liga,aalt[3:5]=2'")
+	flags.StringVar(&opts.direction, "direction", "", "Set text direction (default: auto)")
+	flags.StringVar(&opts.script, "script", "", "Set text script, as an ISO-15924 tag (default: auto)")
+	flags.StringVar(&opts.lang, "language", "", "Set text language (default: $LANG)")
+	flags.IntVar(&opts.clusterLevel, "cluster-level", 0, "Cluster merging level (0/1/2)")
+	flags.UintVar(&opts.invisible, "invisible-glyph", 0, "Glyph value to replace Default-Ignorables with")
+	flags.UintVar(&opts.notFound, "not-found-glyph", 0, "Glyph value to replace not-found characters with")
+	boolFlag(flags, "bot", "Treat text as beginning-of-paragraph", func() { opts.bufferFlags |= harfbuzz.Bot })
+	boolFlag(flags, "eot", "Treat text as end-of-paragraph", func() { opts.bufferFlags |= harfbuzz.Eot })
+	boolFlag(flags, "preserve-default-ignorables", "Preserve Default-Ignorable characters", func() { opts.bufferFlags |= harfbuzz.PreserveDefaultIgnorables })
+	boolFlag(flags, "remove-default-ignorables", "Remove Default-Ignorable characters", func() { opts.bufferFlags |= harfbuzz.RemoveDefaultIgnorables })
+
+	flags.StringVar(&opts.format, "output-format", "text", "Set output serialization format: text or json")
+	boolFlag(flags, "no-glyph-names", "Output glyph indices instead of names", func() { opts.serialize |= harfbuzz.SerializeNoGlyphNames })
+	boolFlag(flags, "no-positions", "Do not output glyph positions", func() { opts.serialize |= harfbuzz.SerializeNoPositions })
+	boolFlag(flags, "no-advances", "Do not output glyph advances", func() { opts.serialize |= harfbuzz.SerializeNoAdvances })
+	boolFlag(flags, "no-clusters", "Do not output cluster indices", func() { opts.serialize |= harfbuzz.SerializeNoClusters })
+	boolFlag(flags, "show-extents", "Output glyph extents", func() { opts.serialize |= harfbuzz.SerializeGlyphExtents })
+	boolFlag(flags, "show-flags", "Output glyph flags", func() { opts.serialize |= harfbuzz.SerializeGlyphFlags })
+	boolFlag(flags, "ned", "No Extra Data; Do not output clusters or advances", func() {
+		opts.serialize |= harfbuzz.SerializeNoClusters | harfbuzz.SerializeNoAdvances
+	})
+	flags.BoolVar(&opts.showText, "show-text", false, "Prefix each line of output with its corresponding input text")
+	flags.BoolVar(&opts.showUnicode, "show-unicode", false, "Prefix each line of output with its corresponding input codepoint(s)")
+	flags.BoolVar(&opts.trace, "trace", false, "Output interim shaping results")
+
+	flags.Parse(os.Args[1:])
+
+	switch flags.NArg() {
+	case 1:
+	case 2:
+		opts.text = flags.Arg(1)
+	default:
+		flags.Usage()
+		os.Exit(1)
+	}
+	return opts, flags.Arg(0)
+}
+
+func main() {
+	opts, fontFile := parseOptions()
+
+	font, err := opts.loadFont(fontFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	features, err := opts.parseFeatures()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	before, err := opts.context(opts.textBefore, opts.unicodesBefore)
+	if err != nil {
+		log.Fatal(err)
+	}
+	after, err := opts.context(opts.textAfter, opts.unicodesAfter)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	lines, err := opts.lines()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	format := harfbuzz.SerializeText
+	if opts.format == "json" {
+		format = harfbuzz.SerializeJSON
+	} else if opts.format != "text" {
+		log.Fatalf("unsupported output format %s", opts.format)
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+	for _, line := range lines {
+		buffer, err := opts.newBuffer(before, line, after)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if opts.showText {
+			fmt.Fprintf(out, "%s=", string(line))
+		}
+		if opts.showUnicode {
+			fmt.Fprintf(out, "%s=", buffer.SerializeUnicode(harfbuzz.SerializeText, 0))
+		}
+
+		if opts.trace {
+			buffer.Tracer = func(ev harfbuzz.TraceEvent) bool {
+				snapshot := harfbuzz.Buffer{Info: ev.Info, Pos: ev.Pos, Props: buffer.Props}
+				flags := opts.serialize
+				if ev.Step != harfbuzz.TraceGPOS && ev.Step != harfbuzz.TraceKerx {
+					flags |= harfbuzz.SerializeNoPositions
+				}
+				fmt.Fprintf(out, "trace: %s\tbuffer: %s\n", ev, snapshot.Serialize(font, format, flags))
+				return true
+			}
+		}
+
+		buffer.Shape(font, features)
+
+		fmt.Fprintln(out, buffer.Serialize(font, format, opts.serialize))
+	}
+}
+
+func (opts options) loadFont(fontFile string) (*harfbuzz.Font, error) {
+	f, err := os.Open(fontFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	faces, err := tt.Loader.Load(f)
+	if err != nil {
+		return nil, fmt.Errorf("loading %s: %s", fontFile, err)
+	}
+	if opts.faceIndex < 0 || opts.faceIndex >= len(faces) {
+		return nil, fmt.Errorf("invalid face index %d for %d faces", opts.faceIndex, len(faces))
+	}
+	face := faces[opts.faceIndex].(*tt.Font)
+
+	if opts.variations != "" {
+		var variations []tt.Variation
+		for _, s := range strings.Split(opts.variations, ",") {
+			v, err := harfbuzz.ParseVariation(s)
+			if err != nil {
+				return nil, err
+			}
+			variations = append(variations, v)
+		}
+		tt.SetVariations(face, variations)
+	}
+
+	font := harfbuzz.NewFont(face)
+
+	if opts.fontSize != "upem" {
+		var x, y int32
+		n, err := fmt.Sscanf(opts.fontSize, "%d %d", &x, &y)
+		if err != io.EOF && n != 2 {
+			return nil, fmt.Errorf("font-size argument should be one or two space-separated numbers")
+		}
+		if n == 1 {
+			y = x
+		}
+		font.XScale, font.YScale = x, y
+	}
+
+	if opts.fontPpem != "" {
+		n, err := fmt.Sscanf(opts.fontPpem, "%d %d", &font.XPpem, &font.YPpem)
+		if err != io.EOF && n != 2 {
+			return nil, fmt.Errorf("font-ppem argument should be one or two space-separated integers")
+		}
+		if n == 1 {
+			font.YPpem = font.XPpem
+		}
+	}
+	font.Ptem = float32(opts.fontPtem)
+
+	return font, nil
+}
+
+func (opts options) parseFeatures() ([]harfbuzz.Feature, error) {
+	if opts.features == "" {
+		return nil, nil
+	}
+	var out []harfbuzz.Feature
+	for _, s := range strings.Split(opts.features, ",") {
+		f, err := harfbuzz.ParseFeature(s)
+		if err != nil {
+			return nil, fmt.Errorf("parsing features %s: %s", opts.features, err)
+		}
+		out = append(out, f)
+	}
+	return out, nil
+}
+
+// parseUnicodes parses a list of code points such as
+// U+0041,U+0042, also accepting 0x41 or 41
+func parseUnicodes(s string) ([]rune, error) {
+	var out []rune
+	for _, chunk := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' }) {
+		chunk = strings.TrimPrefix(strings.TrimPrefix(chunk, "U+"), "0x")
+		r, err := strconv.ParseUint(chunk, 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid unicode rune: %s", chunk)
+		}
+		out = append(out, rune(r))
+	}
+	return out, nil
+}
+
+func (opts options) context(text, unicodes string) ([]rune, error) {
+	if unicodes != "" {
+		return parseUnicodes(unicodes)
+	}
+	return []rune(text), nil
+}
+
+// lines returns the input text, one item per line
+func (opts options) lines() ([][]rune, error) {
+	if opts.unicodes != "" {
+		text, err := parseUnicodes(opts.unicodes)
+		return [][]rune{text}, err
+	}
+	if opts.text != "" {
+		return [][]rune{[]rune(opts.text)}, nil
+	}
+
+	input := os.Stdin
+	if opts.textFile != "" && opts.textFile != "-" {
+		f, err := os.Open(opts.textFile)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		input = f
+	}
+	var out [][]rune
+	scanner := bufio.NewScanner(input)
+	for scanner.Scan() {
+		out = append(out, []rune(scanner.Text()))
+	}
+	return out, scanner.Err()
+}
+
+func (opts options) newBuffer(before, text, after []rune) (*harfbuzz.Buffer, error) {
+	buffer := harfbuzz.NewBuffer()
+	if len(before) != 0 {
+		buffer.AddRunes(before, len(before), 0)
+	}
+	buffer.AddRunes(text, 0, len(text))
+	if len(after) != 0 {
+		buffer.AddRunes(after, 0, 0)
+	}
+
+	if opts.direction != "" {
+		switch strings.ToLower(opts.direction[:1]) {
+		case "l":
+			buffer.Props.Direction = harfbuzz.LeftToRight
+		case "r":
+			buffer.Props.Direction = harfbuzz.RightToLeft
+		case "t":
+			buffer.Props.Direction = harfbuzz.TopToBottom
+		case "b":
+			buffer.Props.Direction = harfbuzz.BottomToTop
+		default:
+			return nil, fmt.Errorf("invalid direction %s", opts.direction)
+		}
+	}
+	if opts.script != "" {
+		script, err := language.ParseScript(opts.script)
+		if err != nil {
+			return nil, err
+		}
+		buffer.Props.Script = script
+	}
+	if opts.lang != "" {
+		buffer.Props.Language = language.NewLanguage(opts.lang)
+	}
+	if opts.clusterLevel < 0 || opts.clusterLevel > 2 {
+		return nil, fmt.Errorf("invalid cluster-level option: %d", opts.clusterLevel)
+	}
+	buffer.ClusterLevel = harfbuzz.ClusterLevel(opts.clusterLevel)
+	buffer.Flags = opts.bufferFlags
+	buffer.Invisible = fonts.GID(opts.invisible)
+	buffer.NotFound = fonts.GID(opts.notFound)
+	buffer.GuessSegmentProperties()
+	return buffer, nil
+}
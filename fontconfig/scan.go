@@ -130,764 +130,15 @@ func ReadFontFile(file fonts.Resource) (fonts.Faces, bool) {
 	return nil, false
 }
 
-const lang_DONT_CARE = 0xffff
-
-const (
-	macLangID_ENGLISH = iota
-	macLangID_FRENCH
-	macLangID_GERMAN
-	macLangID_ITALIAN
-	macLangID_DUTCH
-	macLangID_SWEDISH
-	macLangID_SPANISH
-	macLangID_DANISH
-	macLangID_PORTUGUESE
-	macLangID_NORWEGIAN
-	macLangID_HEBREW
-	macLangID_JAPANESE
-	macLangID_ARABIC
-	macLangID_FINNISH
-	macLangID_GREEK
-	macLangID_ICELANDIC
-	macLangID_MALTESE
-	macLangID_TURKISH
-	macLangID_CROATIAN
-	macLangID_CHINESE_TRADITIONAL
-	macLangID_URDU
-	macLangID_HINDI
-	macLangID_THAI
-	macLangID_KOREAN
-	macLangID_LITHUANIAN
-	macLangID_POLISH
-	macLangID_HUNGARIAN
-	macLangID_ESTONIAN
-	macLangID_LETTISH
-	macLangID_SAAMISK
-	macLangID_FAEROESE
-	macLangID_FARSI
-	macLangID_RUSSIAN
-	macLangID_CHINESE_SIMPLIFIED
-	macLangID_FLEMISH
-	macLangID_IRISH
-	macLangID_ALBANIAN
-	macLangID_ROMANIAN
-	macLangID_CZECH
-	macLangID_SLOVAK
-	macLangID_SLOVENIAN
-	macLangID_YIDDISH
-	macLangID_SERBIAN
-	macLangID_MACEDONIAN
-	macLangID_BULGARIAN
-	macLangID_UKRAINIAN
-	macLangID_BYELORUSSIAN
-	macLangID_UZBEK
-	macLangID_KAZAKH
-	//  macLangID_AZERBAIJANI
-	macLangID_AZERBAIJANI_CYRILLIC_SCRIPT
-	macLangID_AZERBAIJANI_ARABIC_SCRIPT
-	macLangID_ARMENIAN
-	macLangID_GEORGIAN
-	macLangID_MOLDAVIAN
-	macLangID_KIRGHIZ
-	macLangID_TAJIKI
-	macLangID_TURKMEN
-	macLangID_MONGOLIAN
-	//  macLangID_MONGOLIAN_MONGOLIAN_SCRIPT
-	macLangID_MONGOLIAN_CYRILLIC_SCRIPT
-	macLangID_PASHTO
-	macLangID_KURDISH
-	macLangID_KASHMIRI
-	macLangID_SINDHI
-	macLangID_TIBETAN
-	macLangID_NEPALI
-	macLangID_SANSKRIT
-	macLangID_MARATHI
-	macLangID_BENGALI
-	macLangID_ASSAMESE
-	macLangID_GUJARATI
-	macLangID_PUNJABI
-	macLangID_ORIYA
-	macLangID_MALAYALAM
-	macLangID_KANNADA
-	macLangID_TAMIL
-	macLangID_TELUGU
-	macLangID_SINHALESE
-	macLangID_BURMESE
-	macLangID_KHMER
-	macLangID_LAO
-	macLangID_VIETNAMESE
-	macLangID_INDONESIAN
-	macLangID_TAGALOG
-	macLangID_MALAY_ROMAN_SCRIPT
-	macLangID_MALAY_ARABIC_SCRIPT
-	macLangID_AMHARIC
-	macLangID_TIGRINYA
-	macLangID_GALLA
-	macLangID_SOMALI
-	macLangID_SWAHILI
-	macLangID_RUANDA
-	macLangID_RUNDI
-	macLangID_CHEWA
-	macLangID_MALAGASY
-	macLangID_ESPERANTO
-)
-
+// a few language IDs used by the fixes in nameLanguage and nameTranscode
 const (
-	macLangID_WELSH = 128 + iota
-	macLangID_BASQUE
-	macLangID_CATALAN
-	macLangID_LATIN
-	macLangID_QUECHUA
-	macLangID_GUARANI
-	macLangID_AYMARA
-	macLangID_TATAR
-	macLangID_UIGHUR
-	macLangID_DZONGKHA
-	macLangID_JAVANESE
-	macLangID_SUNDANESE
-
-	/* The following codes are new as of 2000-03-10 */
-	macLangID_GALICIAN
-	macLangID_AFRIKAANS
-	macLangID_BRETON
-	macLangID_INUKTITUT
-	macLangID_SCOTTISH_GAELIC
-	macLangID_MANX_GAELIC
-	macLangID_IRISH_GAELIC
-	macLangID_TONGAN
-	macLangID_GREEK_POLYTONIC
-	macLangID_GREELANDIC
-	macLangID_AZERBAIJANI_ROMAN_SCRIPT
-)
+	macLangID_ENGLISH  = 0
+	macLangID_JAPANESE = 11
 
-const (
-	msLangID_ARABIC_GENERAL                  = 0x0001
-	msLangID_CHINESE_GENERAL                 = 0x0004
-	msLangID_ENGLISH_GENERAL                 = 0x0009
-	msLangID_FRENCH_WEST_INDIES              = 0x1C0C
-	msLangID_FRENCH_REUNION                  = 0x200C
-	msLangID_FRENCH_CONGO                    = 0x240C
-	msLangID_FRENCH_SENEGAL                  = 0x280C
-	msLangID_FRENCH_CAMEROON                 = 0x2C0C
-	msLangID_FRENCH_COTE_D_IVOIRE            = 0x300C
-	msLangID_FRENCH_MALI                     = 0x340C
-	msLangID_ARABIC_SAUDI_ARABIA             = 0x0401
-	msLangID_ARABIC_IRAQ                     = 0x0801
-	msLangID_ARABIC_EGYPT                    = 0x0C01
-	msLangID_ARABIC_LIBYA                    = 0x1001
-	msLangID_ARABIC_ALGERIA                  = 0x1401
-	msLangID_ARABIC_MOROCCO                  = 0x1801
-	msLangID_ARABIC_TUNISIA                  = 0x1C01
-	msLangID_ARABIC_OMAN                     = 0x2001
-	msLangID_ARABIC_YEMEN                    = 0x2401
-	msLangID_ARABIC_SYRIA                    = 0x2801
-	msLangID_ARABIC_JORDAN                   = 0x2C01
-	msLangID_ARABIC_LEBANON                  = 0x3001
-	msLangID_ARABIC_KUWAIT                   = 0x3401
-	msLangID_ARABIC_UAE                      = 0x3801
-	msLangID_ARABIC_BAHRAIN                  = 0x3C01
-	msLangID_ARABIC_QATAR                    = 0x4001
-	msLangID_BULGARIAN_BULGARIA              = 0x0402
-	msLangID_CATALAN_CATALAN                 = 0x0403
-	msLangID_CHINESE_TAIWAN                  = 0x0404
-	msLangID_CHINESE_PRC                     = 0x0804
-	msLangID_CHINESE_HONG_KONG               = 0x0C04
-	msLangID_CHINESE_SINGAPORE               = 0x1004
-	msLangID_CHINESE_MACAO                   = 0x1404
-	msLangID_CZECH_CZECH_REPUBLIC            = 0x0405
-	msLangID_DANISH_DENMARK                  = 0x0406
-	msLangID_GERMAN_GERMANY                  = 0x0407
-	msLangID_GERMAN_SWITZERLAND              = 0x0807
-	msLangID_GERMAN_AUSTRIA                  = 0x0C07
-	msLangID_GERMAN_LUXEMBOURG               = 0x1007
-	msLangID_GERMAN_LIECHTENSTEIN            = 0x1407
-	msLangID_GREEK_GREECE                    = 0x0408
-	msLangID_ENGLISH_UNITED_STATES           = 0x0409
-	msLangID_ENGLISH_UNITED_KINGDOM          = 0x0809
-	msLangID_ENGLISH_AUSTRALIA               = 0x0C09
-	msLangID_ENGLISH_CANADA                  = 0x1009
-	msLangID_ENGLISH_NEW_ZEALAND             = 0x1409
-	msLangID_ENGLISH_IRELAND                 = 0x1809
-	msLangID_ENGLISH_SOUTH_AFRICA            = 0x1C09
-	msLangID_ENGLISH_JAMAICA                 = 0x2009
-	msLangID_ENGLISH_CARIBBEAN               = 0x2409
-	msLangID_ENGLISH_BELIZE                  = 0x2809
-	msLangID_ENGLISH_TRINIDAD                = 0x2C09
-	msLangID_ENGLISH_ZIMBABWE                = 0x3009
-	msLangID_ENGLISH_PHILIPPINES             = 0x3409
-	msLangID_ENGLISH_HONG_KONG               = 0x3C09
-	msLangID_ENGLISH_INDIA                   = 0x4009
-	msLangID_ENGLISH_MALAYSIA                = 0x4409
-	msLangID_ENGLISH_SINGAPORE               = 0x4809
-	msLangID_SPANISH_SPAIN_TRADITIONAL_SORT  = 0x040A
-	msLangID_SPANISH_MEXICO                  = 0x080A
-	msLangID_SPANISH_SPAIN_MODERN_SORT       = 0x0C0A
-	msLangID_SPANISH_GUATEMALA               = 0x100A
-	msLangID_SPANISH_COSTA_RICA              = 0x140A
-	msLangID_SPANISH_PANAMA                  = 0x180A
-	msLangID_SPANISH_DOMINICAN_REPUBLIC      = 0x1C0A
-	msLangID_SPANISH_VENEZUELA               = 0x200A
-	msLangID_SPANISH_COLOMBIA                = 0x240A
-	msLangID_SPANISH_PERU                    = 0x280A
-	msLangID_SPANISH_ARGENTINA               = 0x2C0A
-	msLangID_SPANISH_ECUADOR                 = 0x300A
-	msLangID_SPANISH_CHILE                   = 0x340A
-	msLangID_SPANISH_URUGUAY                 = 0x380A
-	msLangID_SPANISH_PARAGUAY                = 0x3C0A
-	msLangID_SPANISH_BOLIVIA                 = 0x400A
-	msLangID_SPANISH_EL_SALVADOR             = 0x440A
-	msLangID_SPANISH_HONDURAS                = 0x480A
-	msLangID_SPANISH_NICARAGUA               = 0x4C0A
-	msLangID_SPANISH_PUERTO_RICO             = 0x500A
-	msLangID_SPANISH_UNITED_STATES           = 0x540A
-	msLangID_SPANISH_LATIN_AMERICA           = 0xE40A
-	msLangID_FRENCH_NORTH_AFRICA             = 0xE40C
-	msLangID_FRENCH_MOROCCO                  = 0x380C
-	msLangID_FRENCH_HAITI                    = 0x3C0C
-	msLangID_FINNISH_FINLAND                 = 0x040B
-	msLangID_FRENCH_FRANCE                   = 0x040C
-	msLangID_FRENCH_BELGIUM                  = 0x080C
-	msLangID_FRENCH_CANADA                   = 0x0C0C
-	msLangID_FRENCH_SWITZERLAND              = 0x100C
-	msLangID_FRENCH_LUXEMBOURG               = 0x140C
-	msLangID_FRENCH_MONACO                   = 0x180C
-	msLangID_HEBREW_ISRAEL                   = 0x040D
-	msLangID_HUNGARIAN_HUNGARY               = 0x040E
-	msLangID_ICELANDIC_ICELAND               = 0x040F
-	msLangID_ITALIAN_ITALY                   = 0x0410
-	msLangID_ITALIAN_SWITZERLAND             = 0x0810
-	msLangID_JAPANESE_JAPAN                  = 0x0411
-	msLangID_KOREAN_KOREA                    = 0x0412
-	msLangID_KOREAN_JOHAB_KOREA              = 0x0812 // legacy
-	msLangID_DUTCH_NETHERLANDS               = 0x0413
-	msLangID_DUTCH_BELGIUM                   = 0x0813
-	msLangID_NORWEGIAN_NORWAY_BOKMAL         = 0x0414
-	msLangID_NORWEGIAN_NORWAY_NYNORSK        = 0x0814
-	msLangID_POLISH_POLAND                   = 0x0415
-	msLangID_PORTUGUESE_BRAZIL               = 0x0416
-	msLangID_PORTUGUESE_PORTUGAL             = 0x0816
-	msLangID_ROMANSH_SWITZERLAND             = 0x0417
-	msLangID_ROMANIAN_ROMANIA                = 0x0418
-	msLangID_MOLDAVIAN_MOLDAVIA              = 0x0818 // legacy
-	msLangID_RUSSIAN_MOLDAVIA                = 0x0819 // legacy
-	msLangID_RUSSIAN_RUSSIA                  = 0x0419
-	msLangID_CROATIAN_CROATIA                = 0x041A
-	msLangID_SERBIAN_SERBIA_LATIN            = 0x081A
-	msLangID_SERBIAN_SERBIA_CYRILLIC         = 0x0C1A
-	msLangID_CROATIAN_BOSNIA_HERZEGOVINA     = 0x101A
-	msLangID_BOSNIAN_BOSNIA_HERZEGOVINA      = 0x141A
-	msLangID_SERBIAN_BOSNIA_HERZ_LATIN       = 0x181A
-	msLangID_SERBIAN_BOSNIA_HERZ_CYRILLIC    = 0x1C1A
-	msLangID_BOSNIAN_BOSNIA_HERZ_CYRILLIC    = 0x201A
-	msLangID_URDU_INDIA                      = 0x0820
-	msLangID_SLOVAK_SLOVAKIA                 = 0x041B
-	msLangID_ALBANIAN_ALBANIA                = 0x041C
-	msLangID_SWEDISH_SWEDEN                  = 0x041D
-	msLangID_SWEDISH_FINLAND                 = 0x081D
-	msLangID_THAI_THAILAND                   = 0x041E
-	msLangID_TURKISH_TURKEY                  = 0x041F
-	msLangID_URDU_PAKISTAN                   = 0x0420
-	msLangID_INDONESIAN_INDONESIA            = 0x0421
-	msLangID_UKRAINIAN_UKRAINE               = 0x0422
-	msLangID_BELARUSIAN_BELARUS              = 0x0423
-	msLangID_SLOVENIAN_SLOVENIA              = 0x0424
-	msLangID_ESTONIAN_ESTONIA                = 0x0425
-	msLangID_LATVIAN_LATVIA                  = 0x0426
-	msLangID_LITHUANIAN_LITHUANIA            = 0x0427
-	msLangID_CLASSIC_LITHUANIAN_LITHUANIA    = 0x0827 // legacy
-	msLangID_TAJIK_TAJIKISTAN                = 0x0428
-	msLangID_YIDDISH_GERMANY                 = 0x043D
-	msLangID_VIETNAMESE_VIET_NAM             = 0x042A
-	msLangID_ARMENIAN_ARMENIA                = 0x042B
-	msLangID_AZERI_AZERBAIJAN_LATIN          = 0x042C
-	msLangID_AZERI_AZERBAIJAN_CYRILLIC       = 0x082C
-	msLangID_BASQUE_BASQUE                   = 0x042D
-	msLangID_UPPER_SORBIAN_GERMANY           = 0x042E
-	msLangID_LOWER_SORBIAN_GERMANY           = 0x082E
-	msLangID_MACEDONIAN_MACEDONIA            = 0x042F
-	msLangID_SUTU_SOUTH_AFRICA               = 0x0430
-	msLangID_TSONGA_SOUTH_AFRICA             = 0x0431
-	msLangID_SETSWANA_SOUTH_AFRICA           = 0x0432
-	msLangID_VENDA_SOUTH_AFRICA              = 0x0433
-	msLangID_ISIXHOSA_SOUTH_AFRICA           = 0x0434
-	msLangID_ISIZULU_SOUTH_AFRICA            = 0x0435
-	msLangID_AFRIKAANS_SOUTH_AFRICA          = 0x0436
-	msLangID_GEORGIAN_GEORGIA                = 0x0437
-	msLangID_FAEROESE_FAEROE_ISLANDS         = 0x0438
-	msLangID_HINDI_INDIA                     = 0x0439
-	msLangID_MALTESE_MALTA                   = 0x043A
-	msLangID_SAAMI_LAPONIA                   = 0x043B
-	msLangID_SAMI_NORTHERN_NORWAY            = 0x043B
-	msLangID_SAMI_NORTHERN_SWEDEN            = 0x083B
-	msLangID_SAMI_NORTHERN_FINLAND           = 0x0C3B
-	msLangID_SAMI_LULE_NORWAY                = 0x103B
-	msLangID_SAMI_LULE_SWEDEN                = 0x143B
-	msLangID_SAMI_SOUTHERN_NORWAY            = 0x183B
-	msLangID_SAMI_SOUTHERN_SWEDEN            = 0x1C3B
-	msLangID_SAMI_SKOLT_FINLAND              = 0x203B
-	msLangID_SAMI_INARI_FINLAND              = 0x243B
-	msLangID_IRISH_GAELIC_IRELAND            = 0x043C // legacy
-	msLangID_SCOTTISH_GAELIC_UNITED_KINGDOM  = 0x083C // legacy
-	msLangID_IRISH_IRELAND                   = 0x083C
-	msLangID_MALAY_MALAYSIA                  = 0x043E
-	msLangID_MALAY_BRUNEI_DARUSSALAM         = 0x083E
-	msLangID_KAZAKH_KAZAKHSTAN               = 0x043F
-	msLangID_KYRGYZ_KYRGYZSTAN               = /* Cyrillic*/ 0x0440
-	msLangID_KISWAHILI_KENYA                 = 0x0441
-	msLangID_TURKMEN_TURKMENISTAN            = 0x0442
-	msLangID_UZBEK_UZBEKISTAN_LATIN          = 0x0443
-	msLangID_UZBEK_UZBEKISTAN_CYRILLIC       = 0x0843
-	msLangID_TATAR_RUSSIA                    = 0x0444
-	msLangID_BENGALI_INDIA                   = 0x0445
-	msLangID_BENGALI_BANGLADESH              = 0x0845
-	msLangID_PUNJABI_INDIA                   = 0x0446
-	msLangID_PUNJABI_ARABIC_PAKISTAN         = 0x0846
-	msLangID_GUJARATI_INDIA                  = 0x0447
-	msLangID_ODIA_INDIA                      = 0x0448
-	msLangID_TAMIL_INDIA                     = 0x0449
-	msLangID_TELUGU_INDIA                    = 0x044A
-	msLangID_KANNADA_INDIA                   = 0x044B
-	msLangID_MALAYALAM_INDIA                 = 0x044C
-	msLangID_ASSAMESE_INDIA                  = 0x044D
-	msLangID_MARATHI_INDIA                   = 0x044E
-	msLangID_SANSKRIT_INDIA                  = 0x044F
-	msLangID_MONGOLIAN_MONGOLIA              = /* Cyrillic */ 0x0450
-	msLangID_MONGOLIAN_PRC                   = 0x0850
-	msLangID_TIBETAN_PRC                     = 0x0451
-	msLangID_DZONGHKA_BHUTAN                 = 0x0851
-	msLangID_WELSH_UNITED_KINGDOM            = 0x0452
-	msLangID_KHMER_CAMBODIA                  = 0x0453
-	msLangID_LAO_LAOS                        = 0x0454
-	msLangID_BURMESE_MYANMAR                 = 0x0455
-	msLangID_GALICIAN_GALICIAN               = 0x0456
-	msLangID_MANIPURI_INDIA                  = /* Bengali */ 0x0458
-	msLangID_SINDHI_INDIA                    = /* Arabic */ 0x0459
-	msLangID_KONKANI_INDIA                   = 0x0457
-	msLangID_KASHMIRI_PAKISTAN               = /* Arabic */ 0x0460
-	msLangID_KASHMIRI_SASIA                  = 0x0860
-	msLangID_SYRIAC_SYRIA                    = 0x045A
-	msLangID_SINHALA_SRI_LANKA               = 0x045B
-	msLangID_CHEROKEE_UNITED_STATES          = 0x045C
-	msLangID_INUKTITUT_CANADA                = 0x045D
-	msLangID_INUKTITUT_CANADA_LATIN          = 0x085D
-	msLangID_AMHARIC_ETHIOPIA                = 0x045E
-	msLangID_TAMAZIGHT_ALGERIA               = 0x085F
-	msLangID_NEPALI_NEPAL                    = 0x0461
-	msLangID_FRISIAN_NETHERLANDS             = 0x0462
-	msLangID_PASHTO_AFGHANISTAN              = 0x0463
-	msLangID_FILIPINO_PHILIPPINES            = 0x0464
-	msLangID_DHIVEHI_MALDIVES                = 0x0465
-	msLangID_OROMO_ETHIOPIA                  = 0x0472
-	msLangID_TIGRIGNA_ETHIOPIA               = 0x0473
-	msLangID_TIGRIGNA_ERYTHREA               = 0x0873
-	msLangID_HAUSA_NIGERIA                   = 0x0468
-	msLangID_YORUBA_NIGERIA                  = 0x046A
-	msLangID_QUECHUA_BOLIVIA                 = 0x046B
-	msLangID_QUECHUA_ECUADOR                 = 0x086B
-	msLangID_QUECHUA_PERU                    = 0x0C6B
-	msLangID_SESOTHO_SA_LEBOA_SOUTH_AFRICA   = 0x046C
-	msLangID_BASHKIR_RUSSIA                  = 0x046D
-	msLangID_LUXEMBOURGISH_LUXEMBOURG        = 0x046E
-	msLangID_GREENLANDIC_GREENLAND           = 0x046F
-	msLangID_IGBO_NIGERIA                    = 0x0470
-	msLangID_KANURI_NIGERIA                  = 0x0471
-	msLangID_GUARANI_PARAGUAY                = 0x0474
-	msLangID_HAWAIIAN_UNITED_STATES          = 0x0475
-	msLangID_LATIN                           = 0x0476
-	msLangID_SOMALI_SOMALIA                  = 0x0477
-	msLangID_YI_PRC                          = 0x0478
-	msLangID_MAPUDUNGUN_CHILE                = 0x047A
-	msLangID_MOHAWK_MOHAWK                   = 0x047C
-	msLangID_BRETON_FRANCE                   = 0x047E
-	msLangID_UIGHUR_PRC                      = 0x0480
-	msLangID_MAORI_NEW_ZEALAND               = 0x0481
-	msLangID_FARSI_IRAN                      = 0x0429
-	msLangID_OCCITAN_FRANCE                  = 0x0482
-	msLangID_CORSICAN_FRANCE                 = 0x0483
-	msLangID_ALSATIAN_FRANCE                 = 0x0484
-	msLangID_YAKUT_RUSSIA                    = 0x0485
-	msLangID_KICHE_GUATEMALA                 = 0x0486
-	msLangID_KINYARWANDA_RWANDA              = 0x0487
-	msLangID_WOLOF_SENEGAL                   = 0x0488
-	msLangID_DARI_AFGHANISTAN                = 0x048C
-	msLangID_PAPIAMENTU_NETHERLANDS_ANTILLES = 0x0479
+	msLangID_JAPANESE_JAPAN        = 0x0411
+	msLangID_ENGLISH_UNITED_STATES = 0x0409
 )
 
-var fcFtLanguage = [...]struct {
-	lang       string
-	PlatformID truetype.PlatformID
-	LanguageID truetype.PlatformLanguageID
-}{
-	{"", truetype.PlatformUnicode, lang_DONT_CARE},
-	{"en", truetype.PlatformMac, macLangID_ENGLISH},
-	{"fr", truetype.PlatformMac, macLangID_FRENCH},
-	{"de", truetype.PlatformMac, macLangID_GERMAN},
-	{"it", truetype.PlatformMac, macLangID_ITALIAN},
-	{"nl", truetype.PlatformMac, macLangID_DUTCH},
-	{"sv", truetype.PlatformMac, macLangID_SWEDISH},
-	{"es", truetype.PlatformMac, macLangID_SPANISH},
-	{"da", truetype.PlatformMac, macLangID_DANISH},
-	{"pt", truetype.PlatformMac, macLangID_PORTUGUESE},
-	{"no", truetype.PlatformMac, macLangID_NORWEGIAN},
-	{"he", truetype.PlatformMac, macLangID_HEBREW},
-	{"ja", truetype.PlatformMac, macLangID_JAPANESE},
-	{"ar", truetype.PlatformMac, macLangID_ARABIC},
-	{"fi", truetype.PlatformMac, macLangID_FINNISH},
-	{"el", truetype.PlatformMac, macLangID_GREEK},
-	{"is", truetype.PlatformMac, macLangID_ICELANDIC},
-	{"mt", truetype.PlatformMac, macLangID_MALTESE},
-	{"tr", truetype.PlatformMac, macLangID_TURKISH},
-	{"hr", truetype.PlatformMac, macLangID_CROATIAN},
-	{"zh-tw", truetype.PlatformMac, macLangID_CHINESE_TRADITIONAL},
-	{"ur", truetype.PlatformMac, macLangID_URDU},
-	{"hi", truetype.PlatformMac, macLangID_HINDI},
-	{"th", truetype.PlatformMac, macLangID_THAI},
-	{"ko", truetype.PlatformMac, macLangID_KOREAN},
-	{"lt", truetype.PlatformMac, macLangID_LITHUANIAN},
-	{"pl", truetype.PlatformMac, macLangID_POLISH},
-	{"hu", truetype.PlatformMac, macLangID_HUNGARIAN},
-	{"et", truetype.PlatformMac, macLangID_ESTONIAN},
-	{"lv", truetype.PlatformMac, macLangID_LETTISH},
-
-	{"fo", truetype.PlatformMac, macLangID_FAEROESE},
-	{"fa", truetype.PlatformMac, macLangID_FARSI},
-	{"ru", truetype.PlatformMac, macLangID_RUSSIAN},
-	{"zh-cn", truetype.PlatformMac, macLangID_CHINESE_SIMPLIFIED},
-	{"nl", truetype.PlatformMac, macLangID_FLEMISH},
-	{"ga", truetype.PlatformMac, macLangID_IRISH},
-	{"sq", truetype.PlatformMac, macLangID_ALBANIAN},
-	{"ro", truetype.PlatformMac, macLangID_ROMANIAN},
-	{"cs", truetype.PlatformMac, macLangID_CZECH},
-	{"sk", truetype.PlatformMac, macLangID_SLOVAK},
-	{"sl", truetype.PlatformMac, macLangID_SLOVENIAN},
-	{"yi", truetype.PlatformMac, macLangID_YIDDISH},
-	{"sr", truetype.PlatformMac, macLangID_SERBIAN},
-	{"mk", truetype.PlatformMac, macLangID_MACEDONIAN},
-	{"bg", truetype.PlatformMac, macLangID_BULGARIAN},
-	{"uk", truetype.PlatformMac, macLangID_UKRAINIAN},
-	{"be", truetype.PlatformMac, macLangID_BYELORUSSIAN},
-	{"uz", truetype.PlatformMac, macLangID_UZBEK},
-	{"kk", truetype.PlatformMac, macLangID_KAZAKH},
-	{"az", truetype.PlatformMac, macLangID_AZERBAIJANI_CYRILLIC_SCRIPT},
-	{"ar", truetype.PlatformMac, macLangID_AZERBAIJANI_ARABIC_SCRIPT},
-	{"hy", truetype.PlatformMac, macLangID_ARMENIAN},
-	{"ka", truetype.PlatformMac, macLangID_GEORGIAN},
-	{"mo", truetype.PlatformMac, macLangID_MOLDAVIAN},
-	{"ky", truetype.PlatformMac, macLangID_KIRGHIZ},
-	{"tg", truetype.PlatformMac, macLangID_TAJIKI},
-	{"tk", truetype.PlatformMac, macLangID_TURKMEN},
-	{"mn", truetype.PlatformMac, macLangID_MONGOLIAN},
-	{"mn", truetype.PlatformMac, macLangID_MONGOLIAN_CYRILLIC_SCRIPT},
-	{"ps", truetype.PlatformMac, macLangID_PASHTO},
-	{"ku", truetype.PlatformMac, macLangID_KURDISH},
-	{"ks", truetype.PlatformMac, macLangID_KASHMIRI},
-	{"sd", truetype.PlatformMac, macLangID_SINDHI},
-	{"bo", truetype.PlatformMac, macLangID_TIBETAN},
-	{"ne", truetype.PlatformMac, macLangID_NEPALI},
-	{"sa", truetype.PlatformMac, macLangID_SANSKRIT},
-	{"mr", truetype.PlatformMac, macLangID_MARATHI},
-	{"bn", truetype.PlatformMac, macLangID_BENGALI},
-	{"as", truetype.PlatformMac, macLangID_ASSAMESE},
-	{"gu", truetype.PlatformMac, macLangID_GUJARATI},
-	{"pa", truetype.PlatformMac, macLangID_PUNJABI},
-	{"or", truetype.PlatformMac, macLangID_ORIYA},
-	{"ml", truetype.PlatformMac, macLangID_MALAYALAM},
-	{"kn", truetype.PlatformMac, macLangID_KANNADA},
-	{"ta", truetype.PlatformMac, macLangID_TAMIL},
-	{"te", truetype.PlatformMac, macLangID_TELUGU},
-	{"si", truetype.PlatformMac, macLangID_SINHALESE},
-	{"my", truetype.PlatformMac, macLangID_BURMESE},
-	{"km", truetype.PlatformMac, macLangID_KHMER},
-	{"lo", truetype.PlatformMac, macLangID_LAO},
-	{"vi", truetype.PlatformMac, macLangID_VIETNAMESE},
-	{"id", truetype.PlatformMac, macLangID_INDONESIAN},
-	{"tl", truetype.PlatformMac, macLangID_TAGALOG},
-	{"ms", truetype.PlatformMac, macLangID_MALAY_ROMAN_SCRIPT},
-	{"ms", truetype.PlatformMac, macLangID_MALAY_ARABIC_SCRIPT},
-	{"am", truetype.PlatformMac, macLangID_AMHARIC},
-	{"ti", truetype.PlatformMac, macLangID_TIGRINYA},
-	{"om", truetype.PlatformMac, macLangID_GALLA},
-	{"so", truetype.PlatformMac, macLangID_SOMALI},
-	{"sw", truetype.PlatformMac, macLangID_SWAHILI},
-	{"rw", truetype.PlatformMac, macLangID_RUANDA},
-	{"rn", truetype.PlatformMac, macLangID_RUNDI},
-	{"ny", truetype.PlatformMac, macLangID_CHEWA},
-	{"mg", truetype.PlatformMac, macLangID_MALAGASY},
-	{"eo", truetype.PlatformMac, macLangID_ESPERANTO},
-	{"cy", truetype.PlatformMac, macLangID_WELSH},
-	{"eu", truetype.PlatformMac, macLangID_BASQUE},
-	{"ca", truetype.PlatformMac, macLangID_CATALAN},
-	{"la", truetype.PlatformMac, macLangID_LATIN},
-	{"qu", truetype.PlatformMac, macLangID_QUECHUA},
-	{"gn", truetype.PlatformMac, macLangID_GUARANI},
-	{"ay", truetype.PlatformMac, macLangID_AYMARA},
-	{"tt", truetype.PlatformMac, macLangID_TATAR},
-	{"ug", truetype.PlatformMac, macLangID_UIGHUR},
-	{"dz", truetype.PlatformMac, macLangID_DZONGKHA},
-	{"jw", truetype.PlatformMac, macLangID_JAVANESE},
-	{"su", truetype.PlatformMac, macLangID_SUNDANESE},
-
-	/* The following codes are new as of 2000-03-10 */
-	{"gl", truetype.PlatformMac, macLangID_GALICIAN},
-	{"af", truetype.PlatformMac, macLangID_AFRIKAANS},
-	{"br", truetype.PlatformMac, macLangID_BRETON},
-	{"iu", truetype.PlatformMac, macLangID_INUKTITUT},
-	{"gd", truetype.PlatformMac, macLangID_SCOTTISH_GAELIC},
-	{"gv", truetype.PlatformMac, macLangID_MANX_GAELIC},
-	{"ga", truetype.PlatformMac, macLangID_IRISH_GAELIC},
-	{"to", truetype.PlatformMac, macLangID_TONGAN},
-	{"el", truetype.PlatformMac, macLangID_GREEK_POLYTONIC},
-	{"ik", truetype.PlatformMac, macLangID_GREELANDIC},
-	{"az", truetype.PlatformMac, macLangID_AZERBAIJANI_ROMAN_SCRIPT},
-
-	{"ar", truetype.PlatformMicrosoft, msLangID_ARABIC_SAUDI_ARABIA},
-	{"ar", truetype.PlatformMicrosoft, msLangID_ARABIC_IRAQ},
-	{"ar", truetype.PlatformMicrosoft, msLangID_ARABIC_EGYPT},
-	{"ar", truetype.PlatformMicrosoft, msLangID_ARABIC_LIBYA},
-	{"ar", truetype.PlatformMicrosoft, msLangID_ARABIC_ALGERIA},
-	{"ar", truetype.PlatformMicrosoft, msLangID_ARABIC_MOROCCO},
-	{"ar", truetype.PlatformMicrosoft, msLangID_ARABIC_TUNISIA},
-	{"ar", truetype.PlatformMicrosoft, msLangID_ARABIC_OMAN},
-	{"ar", truetype.PlatformMicrosoft, msLangID_ARABIC_YEMEN},
-	{"ar", truetype.PlatformMicrosoft, msLangID_ARABIC_SYRIA},
-	{"ar", truetype.PlatformMicrosoft, msLangID_ARABIC_JORDAN},
-	{"ar", truetype.PlatformMicrosoft, msLangID_ARABIC_LEBANON},
-	{"ar", truetype.PlatformMicrosoft, msLangID_ARABIC_KUWAIT},
-	{"ar", truetype.PlatformMicrosoft, msLangID_ARABIC_UAE},
-	{"ar", truetype.PlatformMicrosoft, msLangID_ARABIC_BAHRAIN},
-	{"ar", truetype.PlatformMicrosoft, msLangID_ARABIC_QATAR},
-	{"bg", truetype.PlatformMicrosoft, msLangID_BULGARIAN_BULGARIA},
-	{"ca", truetype.PlatformMicrosoft, msLangID_CATALAN_CATALAN},
-	{"zh-tw", truetype.PlatformMicrosoft, msLangID_CHINESE_TAIWAN},
-	{"zh-cn", truetype.PlatformMicrosoft, msLangID_CHINESE_PRC},
-	{"zh-hk", truetype.PlatformMicrosoft, msLangID_CHINESE_HONG_KONG},
-	{"zh-sg", truetype.PlatformMicrosoft, msLangID_CHINESE_SINGAPORE},
-
-	{"zh-mo", truetype.PlatformMicrosoft, msLangID_CHINESE_MACAO},
-
-	{"cs", truetype.PlatformMicrosoft, msLangID_CZECH_CZECH_REPUBLIC},
-	{"da", truetype.PlatformMicrosoft, msLangID_DANISH_DENMARK},
-	{"de", truetype.PlatformMicrosoft, msLangID_GERMAN_GERMANY},
-	{"de", truetype.PlatformMicrosoft, msLangID_GERMAN_SWITZERLAND},
-	{"de", truetype.PlatformMicrosoft, msLangID_GERMAN_AUSTRIA},
-	{"de", truetype.PlatformMicrosoft, msLangID_GERMAN_LUXEMBOURG},
-	{"de", truetype.PlatformMicrosoft, msLangID_GERMAN_LIECHTENSTEIN},
-	{"el", truetype.PlatformMicrosoft, msLangID_GREEK_GREECE},
-	{"en", truetype.PlatformMicrosoft, msLangID_ENGLISH_UNITED_STATES},
-	{"en", truetype.PlatformMicrosoft, msLangID_ENGLISH_UNITED_KINGDOM},
-	{"en", truetype.PlatformMicrosoft, msLangID_ENGLISH_AUSTRALIA},
-	{"en", truetype.PlatformMicrosoft, msLangID_ENGLISH_CANADA},
-	{"en", truetype.PlatformMicrosoft, msLangID_ENGLISH_NEW_ZEALAND},
-	{"en", truetype.PlatformMicrosoft, msLangID_ENGLISH_IRELAND},
-	{"en", truetype.PlatformMicrosoft, msLangID_ENGLISH_SOUTH_AFRICA},
-	{"en", truetype.PlatformMicrosoft, msLangID_ENGLISH_JAMAICA},
-	{"en", truetype.PlatformMicrosoft, msLangID_ENGLISH_CARIBBEAN},
-	{"en", truetype.PlatformMicrosoft, msLangID_ENGLISH_BELIZE},
-	{"en", truetype.PlatformMicrosoft, msLangID_ENGLISH_TRINIDAD},
-	{"en", truetype.PlatformMicrosoft, msLangID_ENGLISH_ZIMBABWE},
-	{"en", truetype.PlatformMicrosoft, msLangID_ENGLISH_PHILIPPINES},
-	{"es", truetype.PlatformMicrosoft, msLangID_SPANISH_SPAIN_TRADITIONAL_SORT},
-	{"es", truetype.PlatformMicrosoft, msLangID_SPANISH_MEXICO},
-	{"es", truetype.PlatformMicrosoft, msLangID_SPANISH_SPAIN_MODERN_SORT},
-	{"es", truetype.PlatformMicrosoft, msLangID_SPANISH_GUATEMALA},
-	{"es", truetype.PlatformMicrosoft, msLangID_SPANISH_COSTA_RICA},
-	{"es", truetype.PlatformMicrosoft, msLangID_SPANISH_PANAMA},
-	{"es", truetype.PlatformMicrosoft, msLangID_SPANISH_DOMINICAN_REPUBLIC},
-	{"es", truetype.PlatformMicrosoft, msLangID_SPANISH_VENEZUELA},
-	{"es", truetype.PlatformMicrosoft, msLangID_SPANISH_COLOMBIA},
-	{"es", truetype.PlatformMicrosoft, msLangID_SPANISH_PERU},
-	{"es", truetype.PlatformMicrosoft, msLangID_SPANISH_ARGENTINA},
-	{"es", truetype.PlatformMicrosoft, msLangID_SPANISH_ECUADOR},
-	{"es", truetype.PlatformMicrosoft, msLangID_SPANISH_CHILE},
-	{"es", truetype.PlatformMicrosoft, msLangID_SPANISH_URUGUAY},
-	{"es", truetype.PlatformMicrosoft, msLangID_SPANISH_PARAGUAY},
-	{"es", truetype.PlatformMicrosoft, msLangID_SPANISH_BOLIVIA},
-	{"es", truetype.PlatformMicrosoft, msLangID_SPANISH_EL_SALVADOR},
-	{"es", truetype.PlatformMicrosoft, msLangID_SPANISH_HONDURAS},
-	{"es", truetype.PlatformMicrosoft, msLangID_SPANISH_NICARAGUA},
-	{"es", truetype.PlatformMicrosoft, msLangID_SPANISH_PUERTO_RICO},
-	{"fi", truetype.PlatformMicrosoft, msLangID_FINNISH_FINLAND},
-	{"fr", truetype.PlatformMicrosoft, msLangID_FRENCH_FRANCE},
-	{"fr", truetype.PlatformMicrosoft, msLangID_FRENCH_BELGIUM},
-	{"fr", truetype.PlatformMicrosoft, msLangID_FRENCH_CANADA},
-	{"fr", truetype.PlatformMicrosoft, msLangID_FRENCH_SWITZERLAND},
-	{"fr", truetype.PlatformMicrosoft, msLangID_FRENCH_LUXEMBOURG},
-	{"fr", truetype.PlatformMicrosoft, msLangID_FRENCH_MONACO},
-	{"he", truetype.PlatformMicrosoft, msLangID_HEBREW_ISRAEL},
-	{"hu", truetype.PlatformMicrosoft, msLangID_HUNGARIAN_HUNGARY},
-	{"is", truetype.PlatformMicrosoft, msLangID_ICELANDIC_ICELAND},
-	{"it", truetype.PlatformMicrosoft, msLangID_ITALIAN_ITALY},
-	{"it", truetype.PlatformMicrosoft, msLangID_ITALIAN_SWITZERLAND},
-	{"ja", truetype.PlatformMicrosoft, msLangID_JAPANESE_JAPAN},
-	{"ko", truetype.PlatformMicrosoft, msLangID_KOREAN_KOREA},
-	{"ko", truetype.PlatformMicrosoft, msLangID_KOREAN_JOHAB_KOREA},
-	{"nl", truetype.PlatformMicrosoft, msLangID_DUTCH_NETHERLANDS},
-	{"nl", truetype.PlatformMicrosoft, msLangID_DUTCH_BELGIUM},
-	{"no", truetype.PlatformMicrosoft, msLangID_NORWEGIAN_NORWAY_BOKMAL},
-	{"nn", truetype.PlatformMicrosoft, msLangID_NORWEGIAN_NORWAY_NYNORSK},
-	{"pl", truetype.PlatformMicrosoft, msLangID_POLISH_POLAND},
-	{"pt", truetype.PlatformMicrosoft, msLangID_PORTUGUESE_BRAZIL},
-	{"pt", truetype.PlatformMicrosoft, msLangID_PORTUGUESE_PORTUGAL},
-	{"rm", truetype.PlatformMicrosoft, msLangID_ROMANSH_SWITZERLAND},
-	{"ro", truetype.PlatformMicrosoft, msLangID_ROMANIAN_ROMANIA},
-	{"mo", truetype.PlatformMicrosoft, msLangID_MOLDAVIAN_MOLDAVIA},
-	{"ru", truetype.PlatformMicrosoft, msLangID_RUSSIAN_RUSSIA},
-	{"ru", truetype.PlatformMicrosoft, msLangID_RUSSIAN_MOLDAVIA},
-	{"hr", truetype.PlatformMicrosoft, msLangID_CROATIAN_CROATIA},
-	{"sr", truetype.PlatformMicrosoft, msLangID_SERBIAN_SERBIA_LATIN},
-	{"sr", truetype.PlatformMicrosoft, msLangID_SERBIAN_SERBIA_CYRILLIC},
-	{"sk", truetype.PlatformMicrosoft, msLangID_SLOVAK_SLOVAKIA},
-	{"sq", truetype.PlatformMicrosoft, msLangID_ALBANIAN_ALBANIA},
-	{"sv", truetype.PlatformMicrosoft, msLangID_SWEDISH_SWEDEN},
-	{"sv", truetype.PlatformMicrosoft, msLangID_SWEDISH_FINLAND},
-	{"th", truetype.PlatformMicrosoft, msLangID_THAI_THAILAND},
-	{"tr", truetype.PlatformMicrosoft, msLangID_TURKISH_TURKEY},
-	{"ur", truetype.PlatformMicrosoft, msLangID_URDU_PAKISTAN},
-	{"id", truetype.PlatformMicrosoft, msLangID_INDONESIAN_INDONESIA},
-	{"uk", truetype.PlatformMicrosoft, msLangID_UKRAINIAN_UKRAINE},
-	{"be", truetype.PlatformMicrosoft, msLangID_BELARUSIAN_BELARUS},
-	{"sl", truetype.PlatformMicrosoft, msLangID_SLOVENIAN_SLOVENIA},
-	{"et", truetype.PlatformMicrosoft, msLangID_ESTONIAN_ESTONIA},
-	{"lv", truetype.PlatformMicrosoft, msLangID_LATVIAN_LATVIA},
-	{"lt", truetype.PlatformMicrosoft, msLangID_LITHUANIAN_LITHUANIA},
-	{"lt", truetype.PlatformMicrosoft, msLangID_CLASSIC_LITHUANIAN_LITHUANIA},
-	{"mi", truetype.PlatformMicrosoft, msLangID_MAORI_NEW_ZEALAND},
-	{"fa", truetype.PlatformMicrosoft, msLangID_FARSI_IRAN},
-	{"vi", truetype.PlatformMicrosoft, msLangID_VIETNAMESE_VIET_NAM},
-	{"hy", truetype.PlatformMicrosoft, msLangID_ARMENIAN_ARMENIA},
-	{"az", truetype.PlatformMicrosoft, msLangID_AZERI_AZERBAIJAN_LATIN},
-	{"az", truetype.PlatformMicrosoft, msLangID_AZERI_AZERBAIJAN_CYRILLIC},
-	{"eu", truetype.PlatformMicrosoft, msLangID_BASQUE_BASQUE},
-	{"wen", truetype.PlatformMicrosoft, msLangID_UPPER_SORBIAN_GERMANY},
-	{"mk", truetype.PlatformMicrosoft, msLangID_MACEDONIAN_MACEDONIA},
-	{"st", truetype.PlatformMicrosoft, msLangID_SUTU_SOUTH_AFRICA},
-	{"ts", truetype.PlatformMicrosoft, msLangID_TSONGA_SOUTH_AFRICA},
-	{"tn", truetype.PlatformMicrosoft, msLangID_SETSWANA_SOUTH_AFRICA},
-	{"ven", truetype.PlatformMicrosoft, msLangID_VENDA_SOUTH_AFRICA},
-	{"xh", truetype.PlatformMicrosoft, msLangID_ISIXHOSA_SOUTH_AFRICA},
-	{"zu", truetype.PlatformMicrosoft, msLangID_ISIZULU_SOUTH_AFRICA},
-	{"af", truetype.PlatformMicrosoft, msLangID_AFRIKAANS_SOUTH_AFRICA},
-	{"ka", truetype.PlatformMicrosoft, msLangID_GEORGIAN_GEORGIA},
-	{"fo", truetype.PlatformMicrosoft, msLangID_FAEROESE_FAEROE_ISLANDS},
-	{"hi", truetype.PlatformMicrosoft, msLangID_HINDI_INDIA},
-	{"mt", truetype.PlatformMicrosoft, msLangID_MALTESE_MALTA},
-	{"se", truetype.PlatformMicrosoft, msLangID_SAAMI_LAPONIA},
-
-	{"gd", truetype.PlatformMicrosoft, msLangID_SCOTTISH_GAELIC_UNITED_KINGDOM},
-	{"ga", truetype.PlatformMicrosoft, msLangID_IRISH_GAELIC_IRELAND},
-
-	{"ms", truetype.PlatformMicrosoft, msLangID_MALAY_MALAYSIA},
-	{"ms", truetype.PlatformMicrosoft, msLangID_MALAY_BRUNEI_DARUSSALAM},
-	{"kk", truetype.PlatformMicrosoft, msLangID_KAZAKH_KAZAKHSTAN},
-	{"sw", truetype.PlatformMicrosoft, msLangID_KISWAHILI_KENYA},
-	{"uz", truetype.PlatformMicrosoft, msLangID_UZBEK_UZBEKISTAN_LATIN},
-	{"uz", truetype.PlatformMicrosoft, msLangID_UZBEK_UZBEKISTAN_CYRILLIC},
-	{"tt", truetype.PlatformMicrosoft, msLangID_TATAR_RUSSIA},
-	{"bn", truetype.PlatformMicrosoft, msLangID_BENGALI_INDIA},
-	{"pa", truetype.PlatformMicrosoft, msLangID_PUNJABI_INDIA},
-	{"gu", truetype.PlatformMicrosoft, msLangID_GUJARATI_INDIA},
-	{"or", truetype.PlatformMicrosoft, msLangID_ODIA_INDIA},
-	{"ta", truetype.PlatformMicrosoft, msLangID_TAMIL_INDIA},
-	{"te", truetype.PlatformMicrosoft, msLangID_TELUGU_INDIA},
-	{"kn", truetype.PlatformMicrosoft, msLangID_KANNADA_INDIA},
-	{"ml", truetype.PlatformMicrosoft, msLangID_MALAYALAM_INDIA},
-	{"as", truetype.PlatformMicrosoft, msLangID_ASSAMESE_INDIA},
-	{"mr", truetype.PlatformMicrosoft, msLangID_MARATHI_INDIA},
-	{"sa", truetype.PlatformMicrosoft, msLangID_SANSKRIT_INDIA},
-	{"kok", truetype.PlatformMicrosoft, msLangID_KONKANI_INDIA},
-
-	/* new as of 2001-01-01 */
-	{"ar", truetype.PlatformMicrosoft, msLangID_ARABIC_GENERAL},
-	{"zh", truetype.PlatformMicrosoft, msLangID_CHINESE_GENERAL},
-	{"en", truetype.PlatformMicrosoft, msLangID_ENGLISH_GENERAL},
-	{"fr", truetype.PlatformMicrosoft, msLangID_FRENCH_WEST_INDIES},
-	{"fr", truetype.PlatformMicrosoft, msLangID_FRENCH_REUNION},
-	{"fr", truetype.PlatformMicrosoft, msLangID_FRENCH_CONGO},
-
-	{"fr", truetype.PlatformMicrosoft, msLangID_FRENCH_SENEGAL},
-	{"fr", truetype.PlatformMicrosoft, msLangID_FRENCH_CAMEROON},
-	{"fr", truetype.PlatformMicrosoft, msLangID_FRENCH_COTE_D_IVOIRE},
-	{"fr", truetype.PlatformMicrosoft, msLangID_FRENCH_MALI},
-	{"bs", truetype.PlatformMicrosoft, msLangID_BOSNIAN_BOSNIA_HERZEGOVINA},
-	{"ur", truetype.PlatformMicrosoft, msLangID_URDU_INDIA},
-	{"tg", truetype.PlatformMicrosoft, msLangID_TAJIK_TAJIKISTAN},
-	{"yi", truetype.PlatformMicrosoft, msLangID_YIDDISH_GERMANY},
-	{"ky", truetype.PlatformMicrosoft, msLangID_KYRGYZ_KYRGYZSTAN},
-
-	{"tk", truetype.PlatformMicrosoft, msLangID_TURKMEN_TURKMENISTAN},
-	{"mn", truetype.PlatformMicrosoft, msLangID_MONGOLIAN_MONGOLIA},
-
-	// the following seems to be inconsistent;   here is the current "official" way:
-	{"bo", truetype.PlatformMicrosoft, msLangID_DZONGHKA_BHUTAN},
-	/* and here is what is used by Passport SDK */
-	{"bo", truetype.PlatformMicrosoft, msLangID_TIBETAN_PRC},
-	{"dz", truetype.PlatformMicrosoft, msLangID_DZONGHKA_BHUTAN},
-	/* end of inconsistency */
-
-	{"cy", truetype.PlatformMicrosoft, msLangID_WELSH_UNITED_KINGDOM},
-	{"km", truetype.PlatformMicrosoft, msLangID_KHMER_CAMBODIA},
-	{"lo", truetype.PlatformMicrosoft, msLangID_LAO_LAOS},
-	{"my", truetype.PlatformMicrosoft, msLangID_BURMESE_MYANMAR},
-	{"gl", truetype.PlatformMicrosoft, msLangID_GALICIAN_GALICIAN},
-	{"mni", truetype.PlatformMicrosoft, msLangID_MANIPURI_INDIA},
-	{"sd", truetype.PlatformMicrosoft, msLangID_SINDHI_INDIA},
-	// the following one is only encountered in Microsoft RTF specification
-	{"ks", truetype.PlatformMicrosoft, msLangID_KASHMIRI_PAKISTAN},
-	// the following one is not in the Passport list, looks like an omission
-	{"ks", truetype.PlatformMicrosoft, msLangID_KASHMIRI_SASIA},
-	{"ne", truetype.PlatformMicrosoft, msLangID_NEPALI_NEPAL},
-	// "ne",{truetype.PlatformMicrosoft, msLangID_NEPALI_INDIA,},
-	{"fy", truetype.PlatformMicrosoft, msLangID_FRISIAN_NETHERLANDS},
-
-	// new as of 2001-03-01 (from Office Xp)
-	{"en", truetype.PlatformMicrosoft, msLangID_ENGLISH_HONG_KONG},
-	{"en", truetype.PlatformMicrosoft, msLangID_ENGLISH_INDIA},
-	{"en", truetype.PlatformMicrosoft, msLangID_ENGLISH_MALAYSIA},
-	{"en", truetype.PlatformMicrosoft, msLangID_ENGLISH_SINGAPORE},
-	{"syr", truetype.PlatformMicrosoft, msLangID_SYRIAC_SYRIA},
-	{"si", truetype.PlatformMicrosoft, msLangID_SINHALA_SRI_LANKA},
-	{"chr", truetype.PlatformMicrosoft, msLangID_CHEROKEE_UNITED_STATES},
-	{"iu", truetype.PlatformMicrosoft, msLangID_INUKTITUT_CANADA},
-	{"am", truetype.PlatformMicrosoft, msLangID_AMHARIC_ETHIOPIA},
-
-	{"ps", truetype.PlatformMicrosoft, msLangID_PASHTO_AFGHANISTAN},
-	{"phi", truetype.PlatformMicrosoft, msLangID_FILIPINO_PHILIPPINES},
-	{"div", truetype.PlatformMicrosoft, msLangID_DHIVEHI_MALDIVES},
-
-	{"om", truetype.PlatformMicrosoft, msLangID_OROMO_ETHIOPIA},
-	{"ti", truetype.PlatformMicrosoft, msLangID_TIGRIGNA_ETHIOPIA},
-	{"ti", truetype.PlatformMicrosoft, msLangID_TIGRIGNA_ERYTHREA},
-
-	/* New additions from Windows Xp/Passport SDK 2001-11-10. */
-
-	{"es", truetype.PlatformMicrosoft, msLangID_SPANISH_UNITED_STATES},
-	// The following two IDs blatantly violate MS specs by using a sublanguage >,.                                         */
-	{"es", truetype.PlatformMicrosoft, msLangID_SPANISH_LATIN_AMERICA},
-	{"fr", truetype.PlatformMicrosoft, msLangID_FRENCH_NORTH_AFRICA},
-
-	{"fr", truetype.PlatformMicrosoft, msLangID_FRENCH_MOROCCO},
-	{"fr", truetype.PlatformMicrosoft, msLangID_FRENCH_HAITI},
-	{"bn", truetype.PlatformMicrosoft, msLangID_BENGALI_BANGLADESH},
-	{"ar", truetype.PlatformMicrosoft, msLangID_PUNJABI_ARABIC_PAKISTAN},
-	{"mn", truetype.PlatformMicrosoft, msLangID_MONGOLIAN_PRC},
-	{"ha", truetype.PlatformMicrosoft, msLangID_HAUSA_NIGERIA},
-	{"yo", truetype.PlatformMicrosoft, msLangID_YORUBA_NIGERIA},
-	/* language codes from, to, are (still) unknown. */
-	{"ibo", truetype.PlatformMicrosoft, msLangID_IGBO_NIGERIA},
-	{"kau", truetype.PlatformMicrosoft, msLangID_KANURI_NIGERIA},
-	{"gn", truetype.PlatformMicrosoft, msLangID_GUARANI_PARAGUAY},
-	{"haw", truetype.PlatformMicrosoft, msLangID_HAWAIIAN_UNITED_STATES},
-	{"la", truetype.PlatformMicrosoft, msLangID_LATIN},
-	{"so", truetype.PlatformMicrosoft, msLangID_SOMALI_SOMALIA},
-
-	/* Note: Yi does not have a (proper) ISO 639-2 code, since it is mostly */
-	/*       not written (but OTOH the peculiar writing system is worth     */
-	/*       studying).                                                     */
-	//   {  truetype.PlatformMicrosoft,	msLangID_YI_CHINA },
-
-	{"pap", truetype.PlatformMicrosoft, msLangID_PAPIAMENTU_NETHERLANDS_ANTILLES},
-}
-
 const (
 	encMacRoman      = "MACINTOSH"
 	encodingDontCare = 0xffff
@@ -993,23 +244,13 @@ func nameTranscode(sname truetype.NameEntry) string {
 }
 
 func nameLanguage(sname truetype.NameEntry) string {
-	platformID := sname.PlatformID
-	languageID := sname.LanguageID
-
 	/* Many names encoded for truetype.PlatformMac are broken
 	 * in various ways. Kludge around them. */
-	if platformID == truetype.PlatformMac && sname.EncodingID == truetype.PEMacRoman &&
+	if sname.PlatformID == truetype.PlatformMac && sname.EncodingID == truetype.PEMacRoman &&
 		looksLikeSJIS(sname.Value) {
-		languageID = macLangID_JAPANESE
+		sname.LanguageID = macLangID_JAPANESE
 	}
-
-	for _, langEntry := range fcFtLanguage {
-		if langEntry.PlatformID == platformID &&
-			(langEntry.LanguageID == lang_DONT_CARE || langEntry.LanguageID == languageID) {
-			return langEntry.lang
-		}
-	}
-	return ""
+	return sname.Language()
 }
 
 /* Order is significant.  For example, some B&H fonts are hinted by
@@ -1895,7 +1136,9 @@ func queryFace(face fonts.Face, file string, id uint32, sets *sharedSets) (Patte
 
 	pat.Add(CHARSET, cs, true)
 
-	var ls Langset
+	// csLs only depends on the charset, and is shared between faces,
+	// whereas ls also includes the languages specific to the face
+	var csLs, ls Langset
 	// Symbol fonts don't cover any language, even though they
 	// claim to support Latin1 range.
 	if !symbol {
@@ -1903,9 +1146,16 @@ func queryFace(face fonts.Face, file string, id uint32, sets *sharedSets) (Patte
 			fmt.Printf("\tfont charset: %v \n", cs)
 		}
 		if sets == nil {
-			ls = buildLangSet(cs, exclusiveLang)
+			csLs = buildLangSet(cs, exclusiveLang)
 		} else {
-			ls = sets.ls.Copy()
+			csLs = sets.ls
+		}
+		ls = csLs.Copy()
+		// languages explicitely supported by the font designer
+		if face, ok := face.(*truetype.Font); ok {
+			if meta, err := face.MetaTable(); err == nil {
+				addMetaLangs(&ls, meta.SupportedLanguages)
+			}
 		}
 	}
 
@@ -1926,7 +1176,27 @@ func queryFace(face fonts.Face, file string, id uint32, sets *sharedSets) (Patte
 		pat.AddString(FONTFORMAT, fontFormat)
 	}
 
-	return pat, &sharedSets{cs, nameMappings, ls, enc}
+	return pat, &sharedSets{cs, nameMappings, csLs, enc}
+}
+
+// addMetaLangs adds the languages found in the ScriptLangTags `tags`
+// (from a 'meta' table), ignoring the script only tags.
+func addMetaLangs(ls *Langset, tags []string) {
+	for _, tag := range tags {
+		subtags := strings.Split(strings.ToLower(tag), "-")
+		lang := subtags[0]
+		if len(lang) < 2 || len(lang) > 3 { // script tag like Latn
+			continue
+		}
+		// only keep the territory, if any
+		for _, sub := range subtags[1:] {
+			if len(sub) == 2 {
+				lang += "-" + sub
+				break
+			}
+		}
+		ls.add(lang)
+	}
 }
 
 func weightFromBFD(value int32) float32 {
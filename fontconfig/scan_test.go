@@ -1,7 +1,10 @@
 package fontconfig
 
 import (
+	"bytes"
+	"encoding/binary"
 	"fmt"
+	"io/ioutil"
 	"testing"
 )
 
@@ -90,3 +93,61 @@ func TestScanItalic(t *testing.T) {
 // 	}
 // 	fmt.Println(len(fs))
 // }
+
+func TestScanMetaLanguages(t *testing.T) {
+	c := NewConfig()
+	fs, err := c.ScanFontFile("../fonts/truetype/testdata/Bangla Sangam MN.ttc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fs) == 0 {
+		t.Fatal("no fonts scanned")
+	}
+	for _, font := range fs {
+		v, _ := font.GetAt(LANG, 0)
+		ls, _ := v.(Langset)
+		// declared in the 'meta' table, not in the orthographies
+		if res := ls.hasLang("sbp"); res != langEqual {
+			t.Fatalf("expected sbp in %s", ls)
+		}
+	}
+
+	var ls Langset
+	addMetaLangs(&ls, []string{"Latn", "en-Latn", "pt-Latn-BR", "kok"})
+	for _, lang := range []string{"en", "pt-br", "kok"} {
+		if res := ls.hasLang(lang); res != langEqual {
+			t.Fatalf("expected %s in %s", lang, ls)
+		}
+	}
+	if len(ls.getLangs()) != 3 {
+		t.Fatalf("unexpected langset %s", ls)
+	}
+}
+
+func TestScanMetaLanguagesCollection(t *testing.T) {
+	data, err := ioutil.ReadFile("../fonts/truetype/testdata/Bangla Sangam MN.ttc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// hide the 'meta' table of the second face by renaming its tag
+	offset := binary.BigEndian.Uint32(data[16:])
+	numTables := binary.BigEndian.Uint16(data[offset+4:])
+	for i := 0; i < int(numTables); i++ {
+		record := data[int(offset)+12+16*i:]
+		if string(record[:4]) == "meta" {
+			copy(record, "xeta")
+		}
+	}
+
+	fs := scanOneFontFile(bytes.NewReader(data), "Bangla Sangam MN.ttc", NewConfig())
+	if len(fs) != 2 {
+		t.Fatalf("expected 2 faces, got %d", len(fs))
+	}
+	for i, exp := range []langResult{langEqual, langDifferentLang} {
+		v, _ := fs[i].GetAt(LANG, 0)
+		ls, _ := v.(Langset)
+		if res := ls.hasLang("sbp"); res != exp {
+			t.Fatalf("face %d: unexpected langset %s", i, ls)
+		}
+	}
+}
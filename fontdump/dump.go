@@ -0,0 +1,370 @@
+// Package fontdump provides a textual representation (JSON or XML)
+// of the tables of a font, in the spirit of the ttx tool.
+//
+// Contrary to ttx, the tables are not decoded by an independant parser,
+// but are the structures actually used by this module (see for instance
+// truetype.TableGSUB or truetype.TableMorx), so that the output
+// reflects what the shapers see.
+package fontdump
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/benoitkugler/textlayout/fontconfig"
+	"github.com/benoitkugler/textlayout/fonts"
+	"github.com/benoitkugler/textlayout/fonts/simpleencodings"
+	tt "github.com/benoitkugler/textlayout/fonts/truetype"
+	type1c "github.com/benoitkugler/textlayout/fonts/type1C"
+	"github.com/benoitkugler/textlayout/graphite"
+)
+
+// Node is a generic, ordered tree storing the content of a table.
+// A leaf node has no children and a non empty Value.
+type Node struct {
+	Name     string
+	Value    string
+	Children []*Node
+
+	isList bool // the children are indexed by their position
+	isText bool // Value is a string, not a number, a boolean or null
+}
+
+func (n *Node) add(child *Node) {
+	if child != nil {
+		n.Children = append(n.Children, child)
+	}
+}
+
+// NewList returns a node whose children are `items`, renamed
+// by their index.
+func NewList(name string, items []*Node) *Node {
+	out := &Node{Name: name, isList: true}
+	for i, item := range items {
+		item.Name = strconv.Itoa(i)
+		out.add(item)
+	}
+	return out
+}
+
+func leaf(name, value string, isText bool) *Node {
+	return &Node{Name: name, Value: value, isText: isText}
+}
+
+func errorNode(name string, err error) *Node {
+	return &Node{Name: name, Children: []*Node{leaf("error", err.Error(), true)}}
+}
+
+// DumpFile loads the font faces of `file` (using fontconfig.ReadFontFile)
+// and dumps each of them.
+// See `Dump` for the meaning of `tables`.
+func DumpFile(file fonts.Resource, tables ...string) ([]*Node, error) {
+	faces, ok := fontconfig.ReadFontFile(file)
+	if !ok {
+		return nil, fmt.Errorf("unsupported font format")
+	}
+	out := make([]*Node, len(faces))
+	for i, face := range faces {
+		out[i] = Dump(face, tables...)
+	}
+	return out, nil
+}
+
+// Dump returns the content of the tables of `face`.
+// If `tables` is not empty, only the tables with the given tags are
+// included.
+// For formats other than OpenType, the whole face is dumped.
+func Dump(face fonts.Face, tables ...string) *Node {
+	root := &Node{Name: "font"}
+	font, ok := face.(*tt.Font)
+	if !ok {
+		root.add(leaf("format", fmt.Sprintf("%T", face), true))
+		root.Children = append(root.Children, reflectNode("face", reflect.ValueOf(face), newWalker()).Children...)
+		return root
+	}
+
+	keep := func(tag string) bool {
+		if len(tables) == 0 {
+			return true
+		}
+		for _, t := range tables {
+			if strings.TrimSpace(t) == strings.TrimSpace(tag) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, table := range sfntTables {
+		if !keep(table.tag) || !font.HasTable(tt.MustNewTag(table.tag)) {
+			continue
+		}
+		content, err := table.load(font)
+		if err != nil {
+			root.add(errorNode(table.tag, err))
+			continue
+		}
+		root.add(reflectNode(table.tag, reflect.ValueOf(content), newWalker()))
+	}
+	return root
+}
+
+// sfntTables lists the supported tables, in output order
+var sfntTables = [...]struct {
+	tag  string
+	load func(font *tt.Font) (interface{}, error)
+}{
+	{"head", func(font *tt.Font) (interface{}, error) { return font.Head, nil }},
+	{"hhea", func(font *tt.Font) (interface{}, error) { return font.HheaTable() }},
+	{"vhea", func(font *tt.Font) (interface{}, error) { return font.VheaTable() }},
+	{"OS/2", func(font *tt.Font) (interface{}, error) { return font.OS2Table() }},
+	{"name", func(font *tt.Font) (interface{}, error) { return font.Names, nil }},
+	{"cmap", func(font *tt.Font) (interface{}, error) { return dumpCmap(font) }},
+	{"post", func(font *tt.Font) (interface{}, error) { return font.PostTable() }},
+	{"fvar", func(font *tt.Font) (interface{}, error) { return font.Variations(), nil }},
+	{"avar", func(font *tt.Font) (interface{}, error) { return font.AvarTable(), nil }},
+	{"GDEF", func(font *tt.Font) (interface{}, error) { return font.GDEFTable() }},
+	{"GSUB", func(font *tt.Font) (interface{}, error) { return font.GSUBTable() }},
+	{"GPOS", func(font *tt.Font) (interface{}, error) { return font.GPOSTable() }},
+	{"kern", func(font *tt.Font) (interface{}, error) { return font.KernTable() }},
+	{"kerx", func(font *tt.Font) (interface{}, error) { return font.KerxTable() }},
+	{"morx", func(font *tt.Font) (interface{}, error) { return font.MorxTable() }},
+	{"feat", func(font *tt.Font) (interface{}, error) { return font.FeatTable() }},
+	{"trak", func(font *tt.Font) (interface{}, error) { return font.TrakTable() }},
+	{"ankr", func(font *tt.Font) (interface{}, error) { return font.AnkrTable() }},
+	{"Silf", func(font *tt.Font) (interface{}, error) { return dumpGraphite(font) }},
+	{"CFF ", func(font *tt.Font) (interface{}, error) { return dumpCFF(font) }},
+}
+
+type cmapDump struct {
+	Encoding fonts.CmapEncoding
+	Mapping  map[rune]fonts.GID
+}
+
+// dumpCmap returns the cmap selected by the library
+func dumpCmap(font *tt.Font) (cmapDump, error) {
+	cmap, enc := font.Cmap()
+	out := cmapDump{Encoding: enc, Mapping: make(map[rune]fonts.GID)}
+	if cmap == nil {
+		return out, nil
+	}
+	for iter := cmap.Iter(); iter.Next(); {
+		r, g := iter.Char()
+		out.Mapping[r] = g
+	}
+	return out, nil
+}
+
+// dumpGraphite returns the tables used by the Graphite engine
+func dumpGraphite(font *tt.Font) (map[string]interface{}, error) {
+	face, err := graphite.LoadGraphite(font)
+	if err != nil {
+		return nil, err
+	}
+	return face.Tables(), nil
+}
+
+type cffDump struct {
+	Info          fonts.PSInfo
+	FontName      string
+	CIDFontName   string
+	NumGlyphs     int
+	NumGlobalSubr int
+	NumLocalSubr  []int // one per font dict
+	Encoding      *simpleencodings.Encoding
+	Charset       []uint16
+	FDSelect      []int                // font dict index for each glyph, only for CIDFonts
+	Private       []type1c.PrivateDict // one per font dict
+}
+
+// dumpCFF returns the top level values of the CFF table,
+// and the Private DICTs
+func dumpCFF(font *tt.Font) (cffDump, error) {
+	buf, err := font.GetRawTable(tt.MustNewTag("CFF "))
+	if err != nil {
+		return cffDump{}, err
+	}
+	cff, err := type1c.Parse(bytes.NewReader(buf))
+	if err != nil {
+		return cffDump{}, err
+	}
+	out := cffDump{
+		Info:        cff.PSInfo,
+		FontName:    cff.NameIndex(),
+		CIDFontName: cff.CIDFontName(),
+		NumGlyphs:   int(cff.NumGlyphs()),
+		Encoding:    cff.Encoding,
+		Charset:     cff.Charset(),
+		Private:     cff.PrivateDicts(),
+	}
+	out.NumGlobalSubr, out.NumLocalSubr = cff.NumSubroutines()
+	if cff.IsCIDFont() {
+		out.FDSelect = make([]int, out.NumGlyphs)
+		for gid := range out.FDSelect {
+			out.FDSelect[gid], err = cff.FontDictIndex(fonts.GID(gid))
+			if err != nil {
+				return cffDump{}, err
+			}
+		}
+	}
+	return out, nil
+}
+
+// ------------------------------ reflection ------------------------------
+
+// maximum number of bytes written for []byte values
+const maxBytesDumped = 64
+
+// walker avoids infinite recursion on cyclic pointers
+type walker struct {
+	visited map[uintptr]bool
+}
+
+func newWalker() walker { return walker{visited: make(map[uintptr]bool)} }
+
+// reflectNode builds a tree from an arbitrary value.
+// Unexported fields are included, since most of the tables
+// are not exposed to the user.
+func reflectNode(name string, v reflect.Value, w walker) *Node {
+	if !v.IsValid() {
+		return leaf(name, "null", false)
+	}
+
+	if s, ok := stringer(v); ok {
+		return leaf(name, s, true)
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		return leaf(name, strconv.FormatBool(v.Bool()), false)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return leaf(name, strconv.FormatInt(v.Int(), 10), false)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return leaf(name, strconv.FormatUint(v.Uint(), 10), false)
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		// NaN and infinities are not valid JSON numbers
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return leaf(name, strconv.FormatFloat(f, 'g', -1, 64), true)
+		}
+		return leaf(name, strconv.FormatFloat(f, 'g', -1, v.Type().Bits()), false)
+	case reflect.String:
+		return leaf(name, v.String(), true)
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return leaf(name, "null", false)
+		}
+		if v.Kind() == reflect.Ptr {
+			if w.visited[v.Pointer()] {
+				return leaf(name, "<cycle>", true)
+			}
+			w.visited[v.Pointer()] = true
+			defer delete(w.visited, v.Pointer())
+		}
+		return reflectNode(name, v.Elem(), w)
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return leaf(name, "null", false)
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return leaf(name, bytesString(v), true)
+		}
+		out := &Node{Name: name, isList: true}
+		for i := 0; i < v.Len(); i++ {
+			out.add(reflectNode(strconv.Itoa(i), v.Index(i), w))
+		}
+		return out
+	case reflect.Map:
+		if v.IsNil() {
+			return leaf(name, "null", false)
+		}
+		out := &Node{Name: name}
+		keys := v.MapKeys()
+		names := make([]string, len(keys))
+		for i, key := range keys {
+			names[i] = keyString(key)
+		}
+		sort.Sort(byName{keys, names})
+		for i, key := range keys {
+			out.add(reflectNode(names[i], v.MapIndex(key), w))
+		}
+		return out
+	case reflect.Struct:
+		out := &Node{Name: name}
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).Name == "_" {
+				continue
+			}
+			out.add(reflectNode(t.Field(i).Name, v.Field(i), w))
+		}
+		return out
+	default: // functions, channels...
+		return nil
+	}
+}
+
+var stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+
+// stringer uses the String() method of basic types (like tags), if any.
+// Since `v` may come from an unexported field, a copy is made to call the method.
+func stringer(v reflect.Value) (string, bool) {
+	if !v.Type().Implements(stringerType) {
+		return "", false
+	}
+	cp := reflect.New(v.Type()).Elem()
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		cp.SetInt(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		cp.SetUint(v.Uint())
+	default:
+		return "", false
+	}
+	return cp.Interface().(fmt.Stringer).String(), true
+}
+
+func bytesString(v reflect.Value) string {
+	n := v.Len()
+	var buf bytes.Buffer
+	for i := 0; i < n && i < maxBytesDumped; i++ {
+		fmt.Fprintf(&buf, "%02x", v.Index(i).Uint())
+	}
+	if n > maxBytesDumped {
+		fmt.Fprintf(&buf, "... (%d bytes)", n)
+	}
+	return buf.String()
+}
+
+func keyString(key reflect.Value) string {
+	if n := reflectNode("", key, newWalker()); n != nil && len(n.Children) == 0 {
+		return n.Value
+	}
+	return fmt.Sprintf("%v", key)
+}
+
+// sort map keys, numerically if possible
+type byName struct {
+	keys  []reflect.Value
+	names []string
+}
+
+func (b byName) Len() int { return len(b.keys) }
+func (b byName) Swap(i, j int) {
+	b.keys[i], b.keys[j] = b.keys[j], b.keys[i]
+	b.names[i], b.names[j] = b.names[j], b.names[i]
+}
+
+func (b byName) Less(i, j int) bool {
+	ni, erri := strconv.ParseFloat(b.names[i], 64)
+	nj, errj := strconv.ParseFloat(b.names[j], 64)
+	if erri == nil && errj == nil {
+		return ni < nj
+	}
+	return b.names[i] < b.names[j]
+}
@@ -0,0 +1,153 @@
+package fontdump
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"math"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func dumpFile(t *testing.T, filename string, tables ...string) []*Node {
+	f, err := os.Open(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	out, err := DumpFile(f, tables...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func (n *Node) child(name string) *Node {
+	for _, c := range n.Children {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func checkEncodings(t *testing.T, n *Node) {
+	var buf bytes.Buffer
+	if err := n.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var v interface{}
+	if err := json.Unmarshal(buf.Bytes(), &v); err != nil {
+		t.Fatalf("invalid JSON: %s", err)
+	}
+
+	buf.Reset()
+	if err := n.WriteXML(&buf); err != nil {
+		t.Fatal(err)
+	}
+	dec := xml.NewDecoder(&buf)
+	for {
+		_, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("invalid XML: %s", err)
+		}
+	}
+}
+
+func TestDumpOpenType(t *testing.T) {
+	for _, file := range []string{
+		"../fonts/truetype/testdata/Roboto-BoldItalic.ttf",
+		"../fonts/truetype/testdata/STIX-BoldItalic.otf",
+		"../fonts/truetype/testdata/ToyKern1.ttf",
+		"../harfbuzz/testdata/fonts/aat-morx.ttf",
+		"../harfbuzz/testdata/fonts/Simple-Graphite-Font.ttf",
+		"../harfbuzz/testdata/fonts/SourceSansVariable-Roman.anchor.ttf",
+	} {
+		faces := dumpFile(t, file)
+		if len(faces) != 1 {
+			t.Fatalf("expected one face, got %d", len(faces))
+		}
+		font := faces[0]
+		for _, table := range []string{"head", "hhea", "name", "cmap"} {
+			if font.child(table) == nil {
+				t.Errorf("%s: missing table %s", file, table)
+			}
+		}
+		checkEncodings(t, font)
+	}
+}
+
+func TestDumpTables(t *testing.T) {
+	font := dumpFile(t, "../fonts/truetype/testdata/STIX-BoldItalic.otf", "head", "GSUB", "CFF")[0]
+	if len(font.Children) != 3 || font.child("CFF ") == nil {
+		t.Fatalf("unexpected tables %v", font.Children)
+	}
+	if upem := font.child("head").child("UnitsPerEm"); upem == nil || upem.Value != "1000" {
+		t.Fatalf("unexpected head table %v", upem)
+	}
+	if lookups := font.child("GSUB").child("Lookups"); lookups == nil || len(lookups.Children) == 0 {
+		t.Fatalf("missing GSUB lookups")
+	}
+	private := font.child("CFF ").child("Private")
+	if private == nil || len(private.Children) != 1 {
+		t.Fatalf("unexpected Private DICTs %v", private)
+	}
+	if blues := private.Children[0].child("BlueValues"); blues == nil || len(blues.Children) != 4 || blues.Children[2].Value != "669" {
+		t.Fatalf("unexpected BlueValues %v", blues)
+	}
+	if stdVW := private.Children[0].child("StdVW"); stdVW == nil || stdVW.Value != "53" {
+		t.Fatalf("unexpected StdVW %v", stdVW)
+	}
+
+	font = dumpFile(t, "../harfbuzz/testdata/fonts/aat-morx.ttf", "morx")[0]
+	if len(font.Children) != 1 || len(font.child("morx").Children) == 0 {
+		t.Fatalf("unexpected morx table %v", font.Children)
+	}
+
+	font = dumpFile(t, "../harfbuzz/testdata/fonts/Simple-Graphite-Font.ttf", "Silf")[0]
+	if silf := font.child("Silf"); silf == nil || silf.child("Silf") == nil || silf.child("Feat") == nil {
+		t.Fatalf("unexpected Silf table %v", silf)
+	}
+}
+
+func TestDumpCIDFont(t *testing.T) {
+	font := dumpFile(t, "../harfbuzz/testdata/harfbuzz_reference/in-house/fonts/4cbbc461be066fccc611dcc634af6e8cb2705537.ttf", "CFF ")[0]
+	private := font.child("CFF ").child("Private")
+	if private == nil || len(private.Children) != 4 {
+		t.Fatalf("unexpected Private DICTs %v", private)
+	}
+	for i, exp := range []string{"1000", "1000", "500", "607"} {
+		if width := private.Children[i].child("DefaultWidthX"); width == nil || width.Value != exp {
+			t.Fatalf("font dict %d: unexpected defaultWidthX %v", i, width)
+		}
+	}
+	checkEncodings(t, font)
+}
+
+func TestDumpNonFiniteFloats(t *testing.T) {
+	v := struct {
+		A, B, C float64
+		D       float32
+	}{math.NaN(), math.Inf(1), math.Inf(-1), 0.5}
+	n := reflectNode("floats", reflect.ValueOf(v), newWalker())
+	for i, exp := range []string{"NaN", "+Inf", "-Inf", "0.5"} {
+		if got := n.Children[i].Value; got != exp {
+			t.Fatalf("expected %s, got %s", exp, got)
+		}
+	}
+	checkEncodings(t, n)
+}
+
+func TestDumpType1(t *testing.T) {
+	faces := dumpFile(t, "../fonts/type1/test/CalligrapherRegular.pfb")
+	if len(faces) != 1 || faces[0].child("format").Value != "*type1.Font" {
+		t.Fatalf("unexpected dump %v", faces)
+	}
+	checkEncodings(t, faces[0])
+}
@@ -0,0 +1,98 @@
+package fontdump
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+// WriteJSON writes the tree as an indented JSON value.
+// Leaves are written as JSON numbers, booleans, null or strings,
+// and the other nodes as arrays or objects (whose keys keep the node order).
+func (n *Node) WriteJSON(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	n.writeJSON(bw, 0)
+	bw.WriteByte('\n')
+	return bw.Flush()
+}
+
+func jsonString(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+func (n *Node) writeJSON(w *bufio.Writer, depth int) {
+	if len(n.Children) == 0 {
+		switch {
+		case n.isText:
+			w.WriteString(jsonString(n.Value))
+		case n.Value == "":
+			if n.isList {
+				w.WriteString("[]")
+			} else {
+				w.WriteString("{}")
+			}
+		default:
+			w.WriteString(n.Value)
+		}
+		return
+	}
+
+	open, close := "{", "}"
+	if n.isList {
+		open, close = "[", "]"
+	}
+	indent := strings.Repeat("  ", depth+1)
+	w.WriteString(open)
+	for i, child := range n.Children {
+		if i != 0 {
+			w.WriteByte(',')
+		}
+		w.WriteByte('\n')
+		w.WriteString(indent)
+		if !n.isList {
+			w.WriteString(jsonString(child.Name))
+			w.WriteString(": ")
+		}
+		child.writeJSON(w, depth+1)
+	}
+	w.WriteByte('\n')
+	w.WriteString(indent[2:])
+	w.WriteString(close)
+}
+
+// WriteXML writes the tree as an indented XML document.
+// Each node is written as a <field> element, with a "name" attribute,
+// and a "value" attribute for leaves.
+func (n *Node) WriteXML(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString(xml.Header)
+	n.writeXML(bw, 0)
+	return bw.Flush()
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+func (n *Node) writeXML(w *bufio.Writer, depth int) {
+	indent := strings.Repeat("  ", depth)
+	w.WriteString(indent)
+	w.WriteString(`<field name="` + xmlEscape(n.Name) + `"`)
+	if len(n.Children) == 0 {
+		if n.Value != "" {
+			w.WriteString(` value="` + xmlEscape(n.Value) + `"`)
+		}
+		w.WriteString("/>\n")
+		return
+	}
+	w.WriteString(">\n")
+	for _, child := range n.Children {
+		child.writeXML(w, depth+1)
+	}
+	w.WriteString(indent + "</field>\n")
+}
@@ -106,3 +106,34 @@ func TestAdvances(t *testing.T) {
 		}
 	}
 }
+
+func TestLineMetric(t *testing.T) {
+	for _, file := range files {
+		fi, err := os.Open(file)
+		if err != nil {
+			t.Fatal("can't read test file", err)
+		}
+		font, err := Parse(fi)
+		if err != nil {
+			t.Fatal(file, err)
+		}
+		fi.Close()
+
+		xHeight, ok1 := font.LineMetric(fonts.XHeight)
+		capHeight, ok2 := font.LineMetric(fonts.CapHeight)
+		if ok1 && ok2 && (xHeight <= 0 || capHeight < xHeight) {
+			t.Fatalf("font %s: unexpected x-height and cap-height %f %f", file, xHeight, capHeight)
+		}
+
+		ascent, ok1 := font.LineMetric(fonts.HorizontalClippingAscent)
+		descent, ok2 := font.LineMetric(fonts.HorizontalClippingDescent)
+		if !ok1 || !ok2 || ascent != float32(font.accelerator.fontAscent) || descent != float32(font.accelerator.fontDescent) {
+			t.Fatalf("font %s: unexpected clipping extents %f %f", file, ascent, descent)
+		}
+
+		run, _ := font.LineMetric(fonts.HorizontalCaretRun)
+		if angle, _ := font.GetBDFProperty("ITALIC_ANGLE").(Int); (angle == 0 || angle == 90*64) != (run == 0) {
+			t.Fatalf("font %s: unexpected caret run %f for angle %d", file, run, angle)
+		}
+	}
+}
@@ -1,6 +1,10 @@
 package bitmap
 
-import "github.com/benoitkugler/textlayout/fonts"
+import (
+	"math"
+
+	"github.com/benoitkugler/textlayout/fonts"
+)
 
 var _ fonts.FaceMetrics = (*Font)(nil)
 
@@ -13,10 +17,55 @@ func (f *Font) GlyphName(gid fonts.GID) string {
 	return f.names[gid]
 }
 
-func (Font) LineMetric(fonts.LineMetric) (float32, bool) {
+// LineMetric returns the metrics defined by the XLFD properties of the font,
+// and the clipping extents defined by the font ascent and descent.
+// The values are expressed in pixels.
+func (f *Font) LineMetric(metric fonts.LineMetric) (float32, bool) {
+	switch metric {
+	case fonts.UnderlinePosition:
+		// XLFD positions are positive below the baseline
+		return f.intProperty("UNDERLINE_POSITION", -1)
+	case fonts.UnderlineThickness:
+		return f.intProperty("UNDERLINE_THICKNESS", 1)
+	case fonts.StrikethroughPosition:
+		return f.intProperty("STRIKEOUT_ASCENT", 1)
+	case fonts.XHeight:
+		return f.intProperty("X_HEIGHT", 1)
+	case fonts.CapHeight:
+		return f.intProperty("CAP_HEIGHT", 1)
+	case fonts.HorizontalCaretRise:
+		return 1000, true
+	case fonts.HorizontalCaretRun:
+		// ITALIC_ANGLE is expressed in 1/64 degrees, counterclockwise
+		// from the horizontal (upright fonts use 90 degrees)
+		angle, ok := f.GetBDFProperty("ITALIC_ANGLE").(Int)
+		if !ok {
+			return 0, true
+		}
+		radians := float64(angle) / 64 * math.Pi / 180
+		return float32(math.Round(1000 * math.Cos(radians) / math.Sin(radians))), true
+	case fonts.HorizontalCaretOffset:
+		return 0, true
+	case fonts.HorizontalClippingAscent:
+		if f.accelerator == nil {
+			return 0, false
+		}
+		return float32(f.accelerator.fontAscent), true
+	case fonts.HorizontalClippingDescent:
+		if f.accelerator == nil {
+			return 0, false
+		}
+		return float32(f.accelerator.fontDescent), true
+	}
 	return 0, false
 }
 
+// intProperty returns the integer property `name`, multiplied by `sign`
+func (f *Font) intProperty(name string, sign float32) (float32, bool) {
+	value, ok := f.GetBDFProperty(name).(Int)
+	return sign * float32(value), ok
+}
+
 func (f *Font) FontHExtents() (fonts.FontExtents, bool) {
 	return fonts.FontExtents{}, false
 }
@@ -186,6 +186,43 @@ const (
 	SubscriptEmYSize
 	SubscriptEmYOffset
 	SubscriptEmXOffset
+
+	SuperscriptEmXSize
+	SuperscriptEmYOffset
+	SubscriptEmXSize
+
+	// Height of the lowercase letters (like 'x') above the baseline.
+	XHeight
+
+	// Height of the capital letters above the baseline.
+	CapHeight
+
+	// Slope of the caret for horizontal text, given as a rise/run ratio:
+	// an upright caret has a run of 0.
+	HorizontalCaretRise
+	HorizontalCaretRun
+
+	// Horizontal shift of the caret, which may be needed for slanted fonts.
+	HorizontalCaretOffset
+
+	// Slope of the caret for vertical text, given as a rise/run ratio:
+	// an horizontal caret has a rise of 0.
+	VerticalCaretRise
+	VerticalCaretRun
+
+	// Vertical shift of the caret for vertical text.
+	VerticalCaretOffset
+
+	// Extents of the area where glyphs are drawn, used for clipping.
+	// As in the 'OS/2' table, the descent is a positive distance below the baseline.
+	HorizontalClippingAscent
+	HorizontalClippingDescent
+	VerticalClippingAscent
+	VerticalClippingDescent
+
+	// Typographic line gap, as defined in the 'OS/2' table, which may
+	// differ from the line gap used in `FontExtents`.
+	TypoLineGap
 )
 
 // GlyphExtents exposes extent values, measured in font units.
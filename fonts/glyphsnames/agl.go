@@ -0,0 +1,147 @@
+package glyphsnames
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/benoitkugler/textlayout/fonts"
+)
+
+// GlyphToRunes implements the full Adobe Glyph List specification
+// (see https://github.com/adobe-type-tools/agl-specification),
+// returning the character sequence corresponding to `glyph`, or nil
+// if no characters could be found.
+// More precisely:
+//   - the suffix starting at the first period is dropped (a.sc -> a)
+//   - the remaining name is split into components on underscores (f_i -> f, i)
+//   - each component is looked up in the glyph list, or interpreted as uniXXXX[XXXX...]
+//     or uXXXX[XX] names (uni20AC0308 -> U+20AC, U+0308)
+func GlyphToRunes(glyph string) []rune {
+	if i := strings.IndexByte(glyph, '.'); i != -1 {
+		glyph = glyph[:i]
+	}
+	var out []rune
+	for _, component := range strings.Split(glyph, "_") {
+		out = append(out, componentToRunes(component)...)
+	}
+	return out
+}
+
+// componentToRunes maps one component of a glyph name (see `GlyphToRunes`).
+func componentToRunes(component string) []rune {
+	if component == "" {
+		return nil
+	}
+
+	name := component
+	if alias, ok := glyphAliases[name]; ok {
+		name = alias
+	}
+	if r, ok := glyphlistGlyphToRuneMap[name]; ok {
+		return []rune{r}
+	}
+
+	if hexs := strings.TrimPrefix(component, "uni"); len(hexs) != len(component) {
+		if len(hexs) == 0 || len(hexs)%4 != 0 {
+			return nil
+		}
+		out := make([]rune, 0, len(hexs)/4)
+		for i := 0; i < len(hexs); i += 4 {
+			r, ok := parseUpperHex(hexs[i : i+4])
+			if !ok || isSurrogate(r) {
+				return nil
+			}
+			out = append(out, r)
+		}
+		return out
+	}
+
+	if hexs := strings.TrimPrefix(component, "u"); len(hexs) != len(component) {
+		if len(hexs) < 4 || len(hexs) > 6 {
+			return nil
+		}
+		r, ok := parseUpperHex(hexs)
+		if !ok || isSurrogate(r) || r > 0x10FFFF {
+			return nil
+		}
+		return []rune{r}
+	}
+
+	return nil
+}
+
+// parseUpperHex parses an hexadecimal value, accepting only
+// digits and uppercase letters, as required by the AGL specification.
+func parseUpperHex(s string) (rune, bool) {
+	for _, c := range []byte(s) {
+		if !('0' <= c && c <= '9' || 'A' <= c && c <= 'F') {
+			return 0, false
+		}
+	}
+	v, err := strconv.ParseUint(s, 16, 32)
+	return rune(v), err == nil
+}
+
+func isSurrogate(r rune) bool { return 0xD800 <= r && r <= 0xDFFF }
+
+// GlyphNamer is implemented by fonts whose glyphs are identified by names,
+// like type1.Font and type1C.Font.
+type GlyphNamer interface {
+	// NumGlyphs returns the number of glyphs in the font.
+	NumGlyphs() int
+	// GlyphName returns the name of the given glyph, or an empty
+	// string if the glyph is invalid or has no name.
+	GlyphName(gid fonts.GID) string
+}
+
+// privateUseStart is the start of the Private Use Area
+// used for symbol fonts (see the Microsoft Symbol cmap encoding)
+const privateUseStart = 0xF000
+
+// SynthesizeCmap builds a Unicode cmap for `font`, using `GlyphToRunes` on its glyph names.
+// It should be used for fonts which do not use the standard glyph names, for instance because
+// their builtin encoding is custom or symbolic.
+//
+// Glyphs whose name can't be interpreted are mapped to U+F000 + code (in the Private Use Area),
+// where code is their byte code in `encoding` (the builtin encoding of the font), if any.
+// Glyphs mapping to several runes (like ligatures) are not included.
+// When several glyphs map to the same rune, glyphs without suffix (like a vs a.sc) and then the
+// lowest glyph indices are preferred.
+func SynthesizeCmap(font GlyphNamer, encoding *[256]string) fonts.CmapSimple {
+	var codes map[string]byte
+	if encoding != nil {
+		codes = make(map[string]byte)
+		for code, name := range encoding {
+			if _, has := codes[name]; name != "" && !has {
+				codes[name] = byte(code)
+			}
+		}
+	}
+
+	out := make(fonts.CmapSimple)
+	hasSuffix := make(map[rune]bool) // for each mapped rune, true if the glyph name is suffixed
+	for gid := 0; gid < font.NumGlyphs(); gid++ {
+		name := font.GlyphName(fonts.GID(gid))
+		if name == "" || name == ".notdef" {
+			continue
+		}
+		var r rune
+		if runes := GlyphToRunes(name); len(runes) == 1 {
+			r = runes[0]
+		} else if len(runes) > 1 { // ligature
+			continue
+		} else if code, ok := codes[name]; ok {
+			r = privateUseStart + rune(code)
+		} else {
+			continue
+		}
+
+		suffixed := strings.IndexByte(name, '.') != -1
+		if previousSuffixed, has := hasSuffix[r]; has && (suffixed || !previousSuffixed) {
+			continue // keep the previous glyph
+		}
+		out[r] = fonts.GID(gid)
+		hasSuffix[r] = suffixed
+	}
+	return out
+}
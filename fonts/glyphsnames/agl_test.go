@@ -0,0 +1,87 @@
+package glyphsnames
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/benoitkugler/textlayout/fonts"
+)
+
+func TestGlyphToRunes(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		exp  []rune
+	}{
+		{"a", []rune{'a'}},
+		{"a.sc", []rune{'a'}},
+		{"f_i", []rune{'f', 'i'}},
+		{"f_i.liga", []rune{'f', 'i'}},
+		{"f_f_l", []rune{'f', 'f', 'l'}},
+		{"uni20AC", []rune{0x20AC}},
+		{"uni20AC0308", []rune{0x20AC, 0x0308}},
+		{"uni20ac", nil},  // lowercase hex
+		{"uniD801", nil},  // surrogate
+		{"uni20AC0", nil}, // invalid length
+		{"u1040C", []rune{0x1040C}},
+		{"u20AC", []rune{0x20AC}},
+		{"u110000", nil}, // out of range
+		{"u12", nil},     // too short
+		{"Lcommaaccent_uni20AC0308_u1040C.alternate", []rune{0x013B, 0x20AC, 0x0308, 0x1040C}},
+		{"a_unknown_b", []rune{'a', 'b'}},
+		{".notdef", nil},
+		{"", nil},
+	} {
+		if got := GlyphToRunes(test.name); !reflect.DeepEqual(got, test.exp) {
+			t.Errorf("for %s, expected %v, got %v", test.name, test.exp, got)
+		}
+	}
+}
+
+func TestGlyphToRune(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		exp  rune
+	}{
+		{"eight.lf", '8'},
+		{"uni20AC", 0x20AC},
+		{"u1F600", 0x1F600},
+		{"one.sc.alt", '1'},
+		{"C211", 211},
+	} {
+		if got, ok := GlyphToRune(test.name); !ok || got != test.exp {
+			t.Errorf("for %s, expected %v, got %v", test.name, test.exp, got)
+		}
+	}
+
+	if _, ok := GlyphToRune("g33" + "xyz"); ok {
+		t.Error("expected no rune")
+	}
+}
+
+type namedGlyphs []string
+
+func (ng namedGlyphs) NumGlyphs() int { return len(ng) }
+
+func (ng namedGlyphs) GlyphName(gid fonts.GID) string { return ng[gid] }
+
+func TestSynthesizeCmap(t *testing.T) {
+	font := namedGlyphs{".notdef", "a.sc", "a", "f_i", "g33", "b", "b.alt", "uni20AC"}
+	var encoding [256]string
+	encoding[0x41] = "g33"
+
+	cmap := SynthesizeCmap(font, &encoding)
+	exp := fonts.CmapSimple{
+		'a':    2, // unsuffixed glyph
+		'b':    5,
+		0x20AC: 7,
+		0xF041: 4, // private use area
+	}
+	if !reflect.DeepEqual(cmap, exp) {
+		t.Fatalf("expected %v, got %v", exp, cmap)
+	}
+
+	cmap = SynthesizeCmap(font, nil)
+	if _, ok := cmap[0xF041]; ok {
+		t.Fatal("unexpected private use mapping without encoding")
+	}
+}
@@ -8,6 +8,8 @@ import (
 )
 
 // GlyphToRune returns the rune corresponding to glyph `glyph` if there is one.
+// Names corresponding to several runes (see `GlyphToRunes`) are only supported
+// for the most common ligatures, which are mapped to the Private Use Area.
 func GlyphToRune(glyph string) (rune, bool) {
 	// We treat glyph "eight.lf" the same as glyph "eight".
 	if strings.Contains(string(glyph), ".") {
@@ -42,6 +44,11 @@ func GlyphToRune(glyph string) (rune, bool) {
 		}
 	}
 
+	// Finally, use the full Adobe Glyph List specification
+	if runes := GlyphToRunes(glyph); len(runes) == 1 {
+		return runes[0], true
+	}
+
 	return 0, false
 }
 
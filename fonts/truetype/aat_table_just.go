@@ -0,0 +1,240 @@
+package truetype
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// TableJust is the AAT 'just' table, which stores
+// the justification information of the font.
+type TableJust struct {
+	Horizontal, Vertical JustificationHeader // may be empty
+}
+
+// JustificationHeader stores the justification information
+// for one direction.
+type JustificationHeader struct {
+	// ClassTable is the (optional) state table
+	// used to assign justification categories to glyphs.
+	// Its entries have no additional data, and the categories are
+	// stored in the flags (see `JustCategoryFlags`).
+	ClassTable AATStateTable
+
+	wdcLookup Class                           // glyph -> offset into wdcClusters
+	clusters  map[uint32][]JustWidthDeltaPair // key is the offset
+
+	pcLookup Class // glyph -> offset into pcActions, may be nil
+	actions  map[uint32][]JustPostcompensationAction
+}
+
+// IsEmpty returns true if the header has no width delta clusters.
+func (jh JustificationHeader) IsEmpty() bool { return jh.wdcLookup == nil }
+
+// JustCategoryFlags decodes the flags of an entry of `ClassTable`.
+func JustCategoryFlags(flags uint16) (setMark, dontAdvance bool, markCategory, currentCategory uint8) {
+	return flags&0x8000 != 0, flags&0x4000 != 0, uint8((flags & 0x3F80) >> 7), uint8(flags & 0x007F)
+}
+
+// WidthDeltaCluster returns the width delta pairs for `glyph`, or nil if not found.
+func (jh JustificationHeader) WidthDeltaCluster(glyph GID) []JustWidthDeltaPair {
+	if jh.wdcLookup == nil {
+		return nil
+	}
+	offset, ok := jh.wdcLookup.ClassID(glyph)
+	if !ok {
+		return nil
+	}
+	return jh.clusters[offset]
+}
+
+// PostcompensationActions returns the actions to apply to `glyph`
+// after justification, or nil if not found.
+func (jh JustificationHeader) PostcompensationActions(glyph GID) []JustPostcompensationAction {
+	if jh.pcLookup == nil {
+		return nil
+	}
+	offset, ok := jh.pcLookup.ClassID(glyph)
+	if !ok {
+		return nil
+	}
+	return jh.actions[offset]
+}
+
+// JustWidthDeltaPair defines how glyphs of the
+// justification class `JustClass` may grow or shrink.
+type JustWidthDeltaPair struct {
+	JustClass uint32
+	// Limits, in points (that is, as a fraction of the em),
+	// on each side of the glyph
+	BeforeGrowLimit, BeforeShrinkLimit float32
+	AfterGrowLimit, AfterShrinkLimit   float32
+	GrowFlags, ShrinkFlags             uint16
+}
+
+// Priority returns the priority of the pair, for the given flags
+// (`GrowFlags` or `ShrinkFlags`): 0 is the highest (kashida), 3 the lowest (null).
+func (JustWidthDeltaPair) Priority(flags uint16) uint8 { return uint8(flags & 0x000F) }
+
+// IsUnlimitedGap returns true if the gap may be extended without limit,
+// for the given flags (`GrowFlags` or `ShrinkFlags`).
+func (JustWidthDeltaPair) IsUnlimitedGap(flags uint16) bool { return flags&0x1000 != 0 }
+
+// JustPostcompensationAction is a glyph specific action
+// (like ligature decomposition or glyph addition), to be
+// applied after justification.
+type JustPostcompensationAction struct {
+	// Data is the action specific content, whose
+	// interpretation depends on `Type` (0 to 5)
+	Data  []byte
+	Class uint16 // justification class the action applies to
+	Type  uint16
+}
+
+func parseTableJust(data []byte, numGlyphs int) (out TableJust, err error) {
+	if len(data) < 10 {
+		return out, errors.New("invalid 'just' table (EOF)")
+	}
+	// ignoring version and format
+	horizOffset := binary.BigEndian.Uint16(data[6:])
+	vertOffset := binary.BigEndian.Uint16(data[8:])
+
+	if horizOffset != 0 {
+		out.Horizontal, err = parseJustificationHeader(data, int(horizOffset), numGlyphs)
+		if err != nil {
+			return out, err
+		}
+	}
+	if vertOffset != 0 {
+		out.Vertical, err = parseJustificationHeader(data, int(vertOffset), numGlyphs)
+		if err != nil {
+			return out, err
+		}
+	}
+	return out, nil
+}
+
+// data is the whole table, offset the start of the header
+func parseJustificationHeader(data []byte, offset int, numGlyphs int) (out JustificationHeader, err error) {
+	if len(data) < offset+8 {
+		return out, errors.New("invalid 'just' table header (EOF)")
+	}
+	classOffset := int(binary.BigEndian.Uint16(data[offset:]))
+	// wdcOffset is not needed, since the lookup values are
+	// offsets from the start of the table
+	pcOffset := int(binary.BigEndian.Uint16(data[offset+4:]))
+
+	if classOffset != 0 {
+		// the state table is preceded by a morph subtable header (length, coverage, sub-feature flags)
+		const morphHeaderSize = 8
+		if len(data) < classOffset+morphHeaderSize {
+			return out, errors.New("invalid 'just' justification category table (EOF)")
+		}
+		out.ClassTable, err = parseStateTable(data[classOffset+morphHeaderSize:], 0, false, numGlyphs)
+		if err != nil {
+			return out, fmt.Errorf("invalid 'just' justification category table: %s", err)
+		}
+	}
+
+	out.wdcLookup, err = parseAATLookupTable(data, uint32(offset+6), numGlyphs, false)
+	if err != nil {
+		return out, fmt.Errorf("invalid 'just' table: %s", err)
+	}
+	out.clusters = make(map[uint32][]JustWidthDeltaPair)
+	err = visitAATLookupValues(out.wdcLookup, numGlyphs, func(clusterOffset uint32) error {
+		if _, has := out.clusters[clusterOffset]; has {
+			return nil
+		}
+		out.clusters[clusterOffset], err = parseJustWidthDeltaCluster(data, int(clusterOffset))
+		return err
+	})
+	if err != nil {
+		return out, err
+	}
+
+	if pcOffset != 0 {
+		out.pcLookup, err = parseAATLookupTable(data, uint32(pcOffset), numGlyphs, false)
+		if err != nil {
+			return out, fmt.Errorf("invalid 'just' postcompensation table: %s", err)
+		}
+		out.actions = make(map[uint32][]JustPostcompensationAction)
+		err = visitAATLookupValues(out.pcLookup, numGlyphs, func(actionsOffset uint32) error {
+			if _, has := out.actions[actionsOffset]; has {
+				return nil
+			}
+			// offsets are relative to the postcompensation subtable
+			out.actions[actionsOffset], err = parseJustPostcompensationActions(data, pcOffset+int(actionsOffset))
+			return err
+		})
+		if err != nil {
+			return out, err
+		}
+	}
+
+	return out, nil
+}
+
+// visitAATLookupValues calls `fn` for each value of the glyphs covered by `lookup`
+func visitAATLookupValues(lookup Class, numGlyphs int, fn func(value uint32) error) error {
+	for gid := 0; gid < numGlyphs; gid++ {
+		value, ok := lookup.ClassID(GID(gid))
+		if !ok {
+			continue
+		}
+		if err := fn(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseJustWidthDeltaCluster(data []byte, offset int) ([]JustWidthDeltaPair, error) {
+	const pairSize = 24
+	if len(data) < offset+4 {
+		return nil, errors.New("invalid 'just' width delta cluster (EOF)")
+	}
+	count := int(binary.BigEndian.Uint32(data[offset:]))
+	if len(data) < offset+4+count*pairSize {
+		return nil, errors.New("invalid 'just' width delta cluster (EOF)")
+	}
+	out := make([]JustWidthDeltaPair, count)
+	for i := range out {
+		pair := data[offset+4+i*pairSize:]
+		out[i].JustClass = binary.BigEndian.Uint32(pair)
+		out[i].BeforeGrowLimit = fixed1616ToFloat(binary.BigEndian.Uint32(pair[4:]))
+		out[i].BeforeShrinkLimit = fixed1616ToFloat(binary.BigEndian.Uint32(pair[8:]))
+		out[i].AfterGrowLimit = fixed1616ToFloat(binary.BigEndian.Uint32(pair[12:]))
+		out[i].AfterShrinkLimit = fixed1616ToFloat(binary.BigEndian.Uint32(pair[16:]))
+		out[i].GrowFlags = binary.BigEndian.Uint16(pair[20:])
+		out[i].ShrinkFlags = binary.BigEndian.Uint16(pair[22:])
+	}
+	return out, nil
+}
+
+func parseJustPostcompensationActions(data []byte, offset int) ([]JustPostcompensationAction, error) {
+	const actionHeaderSize = 8
+	if len(data) < offset+4 {
+		return nil, errors.New("invalid 'just' postcompensation actions (EOF)")
+	}
+	count := int(binary.BigEndian.Uint32(data[offset:]))
+	// "sanitize" before allocating
+	if len(data) < offset+4+count*actionHeaderSize {
+		return nil, errors.New("invalid 'just' postcompensation actions (EOF)")
+	}
+	out := make([]JustPostcompensationAction, count)
+	pos := offset + 4
+	for i := range out {
+		if len(data) < pos+actionHeaderSize {
+			return nil, errors.New("invalid 'just' postcompensation action (EOF)")
+		}
+		out[i].Class = binary.BigEndian.Uint16(data[pos:])
+		out[i].Type = binary.BigEndian.Uint16(data[pos+2:])
+		length := int(binary.BigEndian.Uint32(data[pos+4:])) // including the header
+		if length < actionHeaderSize || len(data) < pos+length {
+			return nil, fmt.Errorf("invalid 'just' postcompensation action length: %d", length)
+		}
+		out[i].Data = data[pos+actionHeaderSize : pos+length]
+		pos += length
+	}
+	return out, nil
+}
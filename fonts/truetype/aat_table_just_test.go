@@ -0,0 +1,72 @@
+package truetype
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseJust(t *testing.T) {
+	data := []byte{
+		0, 1, 0, 0, // version
+		0, 0, // format
+		0, 10, // horizontal header
+		0, 0, // no vertical header
+		0, 0, // no category state table
+		0, 32, // width delta clusters
+		0, 60, // postcompensation subtable
+	}
+	data = append(data, aatLookup6(3, 32)...)
+	data = append(data,
+		0, 0, 0, 1, // one pair
+		0, 0, 0, 2, // class
+		0, 1, 0x80, 0, // 1.5
+		0, 0, 0, 0,
+		0, 1, 0x80, 0, // 1.5
+		0, 0, 0, 0,
+		0x10, 0x01, 0, 3, // grow and shrink flags
+	)
+	data = append(data, aatLookup6(3, 16)...) // relative to the postcompensation subtable
+	data = append(data,
+		0, 0, 0, 1, // one action
+		0, 1, 0, 2, 0, 0, 0, 10, // class, type, length
+		0, 5,
+	)
+
+	just, err := parseTableJust(data, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !just.Vertical.IsEmpty() || just.Horizontal.IsEmpty() {
+		t.Fatal("unexpected empty headers")
+	}
+
+	exp := []JustWidthDeltaPair{{
+		JustClass:       2,
+		BeforeGrowLimit: 1.5, AfterGrowLimit: 1.5,
+		GrowFlags: 0x1001, ShrinkFlags: 3,
+	}}
+	cluster := just.Horizontal.WidthDeltaCluster(3)
+	if !reflect.DeepEqual(cluster, exp) {
+		t.Fatalf("expected %v, got %v", exp, cluster)
+	}
+	if p := cluster[0]; p.Priority(p.GrowFlags) != 1 || !p.IsUnlimitedGap(p.GrowFlags) || p.Priority(p.ShrinkFlags) != 3 {
+		t.Fatalf("unexpected flags for %v", p)
+	}
+	if got := just.Horizontal.WidthDeltaCluster(4); got != nil {
+		t.Fatalf("unexpected cluster %v", got)
+	}
+
+	expActions := []JustPostcompensationAction{{Class: 1, Type: 2, Data: []byte{0, 5}}}
+	if got := just.Horizontal.PostcompensationActions(3); !reflect.DeepEqual(got, expActions) {
+		t.Fatalf("expected %v, got %v", expActions, got)
+	}
+
+	if _, err = parseTableJust(data[:80], 10); err == nil {
+		t.Fatal("expected error for invalid action length")
+	}
+
+	setMark, dontAdvance, mark, current := JustCategoryFlags(0x8000 | 3<<7 | 5)
+	if !setMark || dontAdvance || mark != 3 || current != 5 {
+		t.Fatal("invalid category flags")
+	}
+}
@@ -0,0 +1,57 @@
+package truetype
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// TableLcar is the AAT 'lcar' table, which stores
+// the caret positions of ligature glyphs.
+type TableLcar struct {
+	class Class
+	data  []byte // the whole table
+
+	// IsControlPoint is true if the caret positions
+	// are given as control point indices instead of distances.
+	IsControlPoint bool
+}
+
+// LigatureCarets returns the caret values (distances in font units
+// or control points, see `IsControlPoint`) for `glyph`, or nil if not found.
+func (t TableLcar) LigatureCarets(glyph GID) []int16 {
+	if t.class == nil { // empty table
+		return nil
+	}
+	offset, ok := t.class.ClassID(glyph)
+	if !ok {
+		return nil
+	}
+	// offset sanitized during parsing
+	count := int(binary.BigEndian.Uint16(t.data[offset:]))
+	if len(t.data) < int(offset)+2+2*count {
+		return nil // invalid table
+	}
+	out := make([]int16, count)
+	for i := range out {
+		out[i] = int16(binary.BigEndian.Uint16(t.data[int(offset)+2+2*i:]))
+	}
+	return out
+}
+
+func parseTableLcar(data []byte, numGlyphs int) (out TableLcar, err error) {
+	if len(data) < 8 {
+		return out, errors.New("invalid 'lcar' table (EOF)")
+	}
+	// ignoring version
+	out.IsControlPoint = binary.BigEndian.Uint16(data[4:]) == 1
+	out.class, err = parseAATLookupTable(data, 6, numGlyphs, false)
+	if err != nil {
+		return out, fmt.Errorf("invalid 'lcar' table: %s", err)
+	}
+	out.data = data
+	if e := out.class.Extent(); e+1 > len(data) {
+		return out, errors.New("invalid 'lcar' table (EOF)")
+	}
+	return out, nil
+}
@@ -0,0 +1,49 @@
+package truetype
+
+import (
+	"reflect"
+	"testing"
+)
+
+// aatLookup6 returns an AAT lookup table in format 6,
+// with 16-bit values, for the given sorted pairs (glyph, value).
+func aatLookup6(pairs ...uint16) []byte {
+	n := uint16(len(pairs) / 2)
+	out := []byte{0, 6, 0, 4, byte(n >> 8), byte(n), 0, 0, 0, 0, 0, 0}
+	for _, v := range pairs {
+		out = append(out, byte(v>>8), byte(v))
+	}
+	return out
+}
+
+func TestParseLcar(t *testing.T) {
+	data := []byte{0, 1, 0, 0, 0, 0} // version, format
+	data = append(data, aatLookup6(4, 26, 5, 32)...)
+	data = append(data, 0, 2, 0, 100, 0, 200) // glyph 4, at offset 26
+	data = append(data, 0, 1, 0xFF, 0xF6)     // glyph 5, at offset 32
+
+	lcar, err := parseTableLcar(data, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lcar.IsControlPoint {
+		t.Fatal("unexpected control point format")
+	}
+	if got := lcar.LigatureCarets(4); !reflect.DeepEqual(got, []int16{100, 200}) {
+		t.Fatalf("unexpected carets %v", got)
+	}
+	if got := lcar.LigatureCarets(5); !reflect.DeepEqual(got, []int16{-10}) {
+		t.Fatalf("unexpected carets %v", got)
+	}
+	if got := lcar.LigatureCarets(6); got != nil {
+		t.Fatalf("unexpected carets %v", got)
+	}
+
+	if got := (TableLcar{}).LigatureCarets(4); got != nil {
+		t.Fatalf("unexpected carets for empty table %v", got)
+	}
+
+	if _, err = parseTableLcar(data[:30], 10); err == nil {
+		t.Fatal("expected error for invalid offset")
+	}
+}
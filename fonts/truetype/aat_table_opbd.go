@@ -0,0 +1,65 @@
+package truetype
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// TableOpbd is the AAT 'opbd' table, which stores
+// the optical bounds of glyphs, used for instance
+// to implement hanging punctuation.
+type TableOpbd struct {
+	class Class
+	data  []byte // the whole table
+
+	// IsControlPoint is true if the bounds are given as
+	// control point indices instead of distances.
+	IsControlPoint bool
+}
+
+// AATOpticalBounds stores, for each edge of a glyph, the distance
+// (in font units, positive inwards) the optical edge is
+// from the edge defined by the metrics, or the index of the
+// control point defining the optical edge.
+// For control points, 0xFFFF means that the edge is not adjusted.
+type AATOpticalBounds struct {
+	Left, Top, Right, Bottom int16
+}
+
+// OpticalBounds returns the bounds of `glyph`, or false if not found.
+func (t TableOpbd) OpticalBounds(glyph GID) (AATOpticalBounds, bool) {
+	if t.class == nil { // empty table
+		return AATOpticalBounds{}, false
+	}
+	offset, ok := t.class.ClassID(glyph)
+	if !ok {
+		return AATOpticalBounds{}, false
+	}
+	// offset sanitized during parsing
+	data := t.data[offset:]
+	return AATOpticalBounds{
+		Left:   int16(binary.BigEndian.Uint16(data)),
+		Top:    int16(binary.BigEndian.Uint16(data[2:])),
+		Right:  int16(binary.BigEndian.Uint16(data[4:])),
+		Bottom: int16(binary.BigEndian.Uint16(data[6:])),
+	}, true
+}
+
+func parseTableOpbd(data []byte, numGlyphs int) (out TableOpbd, err error) {
+	if len(data) < 8 {
+		return out, errors.New("invalid 'opbd' table (EOF)")
+	}
+	// ignoring version
+	out.IsControlPoint = binary.BigEndian.Uint16(data[4:]) == 1
+	out.class, err = parseAATLookupTable(data, 6, numGlyphs, false)
+	if err != nil {
+		return out, fmt.Errorf("invalid 'opbd' table: %s", err)
+	}
+	out.data = data
+	// the maximum offset is Extent - 1
+	if e := out.class.Extent(); e+7 > len(data) {
+		return out, errors.New("invalid 'opbd' table (EOF)")
+	}
+	return out, nil
+}
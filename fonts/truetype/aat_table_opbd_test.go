@@ -0,0 +1,33 @@
+package truetype
+
+import (
+	"testing"
+)
+
+func TestParseOpbd(t *testing.T) {
+	data := []byte{0, 1, 0, 0, 0, 0} // version, format
+	data = append(data, aatLookup6(7, 22)...)
+	data = append(data, 0, 50, 0, 0, 0xFF, 0xEC, 0, 0) // glyph 7, at offset 22
+
+	opbd, err := parseTableOpbd(data, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opbd.IsControlPoint {
+		t.Fatal("unexpected control point format")
+	}
+	if got, ok := opbd.OpticalBounds(7); !ok || got != (AATOpticalBounds{Left: 50, Right: -20}) {
+		t.Fatalf("unexpected bounds %v", got)
+	}
+	if _, ok := opbd.OpticalBounds(8); ok {
+		t.Fatal("unexpected bounds for glyph 8")
+	}
+
+	if _, ok := (TableOpbd{}).OpticalBounds(7); ok {
+		t.Fatal("unexpected bounds for empty table")
+	}
+
+	if _, err = parseTableOpbd(data[:28], 10); err == nil {
+		t.Fatal("expected error for invalid offset")
+	}
+}
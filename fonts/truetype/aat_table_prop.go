@@ -0,0 +1,86 @@
+package truetype
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// AATGlyphProperties is a bit field describing
+// the properties of a glyph, as found in the 'prop' table.
+type AATGlyphProperties uint16
+
+const (
+	// AATPropFloater is set for glyphs which float (like diacritics)
+	AATPropFloater AATGlyphProperties = 0x8000
+	// AATPropHangLeft is set for glyphs which may hang off the left (or top) edge
+	AATPropHangLeft AATGlyphProperties = 0x4000
+	// AATPropHangRight is set for glyphs which may hang off the right (or bottom) edge
+	AATPropHangRight AATGlyphProperties = 0x2000
+	// AATPropMirrored is set for glyphs which have a complementary bracket
+	// (see `PairOffset`)
+	AATPropMirrored AATGlyphProperties = 0x1000
+	// AATPropAttachRight is set for glyphs which attach to the
+	// glyph on their right (only supported in version 2.0 and later)
+	AATPropAttachRight AATGlyphProperties = 0x0080
+
+	aatPropPairOffset     AATGlyphProperties = 0x0F00
+	aatPropDirectionClass AATGlyphProperties = 0x001F
+)
+
+// DirectionClass returns the Unicode bidirectional class of the glyph,
+// using the encoding of the 'prop' table (0 for L, 1 for R, etc...).
+func (p AATGlyphProperties) DirectionClass() uint8 {
+	return uint8(p & aatPropDirectionClass)
+}
+
+// PairOffset returns the signed offset from this glyph to its
+// complementary bracket, only meaningful if `AATPropMirrored` is set.
+func (p AATGlyphProperties) PairOffset() int8 {
+	offset := int8((p & aatPropPairOffset) >> 8)
+	if offset >= 8 { // sign extension of a 4 bit value
+		offset -= 16
+	}
+	return offset
+}
+
+// TableProp is the AAT 'prop' table, which stores glyph properties
+// like directionality or hanging punctuation.
+type TableProp struct {
+	class    Class // may be nil
+	Default  AATGlyphProperties
+	isLegacy bool // version 1.0 only defines a subset of the properties
+}
+
+// Properties returns the properties of `glyph`,
+// falling back to the default value of the table.
+func (t TableProp) Properties(glyph GID) AATGlyphProperties {
+	if t.class == nil {
+		return t.Default
+	}
+	props, ok := t.class.ClassID(glyph)
+	if !ok {
+		return t.Default
+	}
+	if t.isLegacy {
+		props &^= uint32(AATPropAttachRight)
+	}
+	return AATGlyphProperties(props)
+}
+
+func parseTableProp(data []byte, numGlyphs int) (out TableProp, err error) {
+	if len(data) < 8 {
+		return out, errors.New("invalid 'prop' table (EOF)")
+	}
+	out.isLegacy = binary.BigEndian.Uint16(data) == 1
+	format := binary.BigEndian.Uint16(data[4:])
+	out.Default = AATGlyphProperties(binary.BigEndian.Uint16(data[6:]))
+	if format == 0 { // no lookup table
+		return out, nil
+	}
+	out.class, err = parseAATLookupTable(data, 8, numGlyphs, false)
+	if err != nil {
+		return out, fmt.Errorf("invalid 'prop' table: %s", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,47 @@
+package truetype
+
+import (
+	"os"
+	"testing"
+)
+
+func TestProp(t *testing.T) {
+	f, err := os.Open("testdata/ToyKern1.ttf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	font, err := Parse(f, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prop, err := font.PropTable()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, exp := range []struct {
+		glyph GID
+		props AATGlyphProperties
+	}{
+		{2, 0}, // default value
+		{3, 0x000a},
+		{11, 0x110b},
+		{0xbd, 0x800b},
+	} {
+		if got := prop.Properties(exp.glyph); got != exp.props {
+			t.Fatalf("invalid properties for %d: expected %x, got %x", exp.glyph, exp.props, got)
+		}
+	}
+
+	if p := prop.Properties(11); p&AATPropMirrored == 0 || p.PairOffset() != 1 || p.DirectionClass() != 11 {
+		t.Fatalf("unexpected properties %x", p)
+	}
+	if p := prop.Properties(12); p&AATPropMirrored == 0 || p.PairOffset() != -1 {
+		t.Fatalf("unexpected properties %x", p)
+	}
+	if p := prop.Properties(0xbc); p&AATPropFloater == 0 {
+		t.Fatalf("unexpected properties %x", p)
+	}
+}
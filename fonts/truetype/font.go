@@ -58,7 +58,7 @@ type Font struct {
 	tables map[Tag]*tableSection // header only, contents is processed on demand
 
 	// Optionnal, only present in variable fonts
-	avar tableAvar
+	avar TableAvar
 
 	// cmaps is not empty after successful parsing
 	cmaps TableCmap
@@ -249,6 +249,37 @@ func (font *Font) OS2Table() (*TableOS2, error) {
 	return parseTableOS2(buf)
 }
 
+// GaspTable returns the grid-fitting and scan-conversion procedure table
+// identified with the 'gasp' tag.
+func (font *Font) GaspTable() (TableGasp, error) {
+	buf, err := font.GetRawTable(tagGasp)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseTableGasp(buf)
+}
+
+// MetaTable returns the metadata table identified with the 'meta' tag.
+func (font *Font) MetaTable() (TableMeta, error) {
+	buf, err := font.GetRawTable(tagMeta)
+	if err != nil {
+		return TableMeta{}, err
+	}
+
+	return parseTableMeta(buf)
+}
+
+// JSTFTable returns the justification table identified with the 'JSTF' tag.
+func (font *Font) JSTFTable() (TableJstf, error) {
+	buf, err := font.GetRawTable(tagJstf)
+	if err != nil {
+		return TableJstf{}, err
+	}
+
+	return parseTableJstf(buf)
+}
+
 // GPOSTable returns the Glyph Positioning table identified with the 'GPOS' tag.
 func (font *Font) GPOSTable() (TableGPOS, error) {
 	buf, err := font.GetRawTable(TagGpos)
@@ -354,11 +385,16 @@ type LayoutTables struct {
 	Trak TableTrak
 	Ankr TableAnkr
 	Feat TableFeat
+	Lcar TableLcar
+	Opbd TableOpbd
+	Prop TableProp
+	Just TableJust
 	Morx TableMorx
 	Kern TableKernx
 	Kerx TableKernx
 	GSUB TableGSUB // An absent table has a nil slice of lookups
 	GPOS TableGPOS // An absent table has a nil slice of lookups
+	JSTF TableJstf // An absent table has a nil slice of scripts
 }
 
 // LayoutTables try and parse all the advanced layout tables.
@@ -375,6 +411,9 @@ func (font *Font) LayoutTables() LayoutTables {
 	if tb, err := font.GPOSTable(); err == nil {
 		out.GPOS = tb
 	}
+	if tb, err := font.JSTFTable(); err == nil {
+		out.JSTF = tb
+	}
 
 	if tb, err := font.MorxTable(); err == nil {
 		out.Morx = tb
@@ -394,6 +433,18 @@ func (font *Font) LayoutTables() LayoutTables {
 	if tb, err := font.FeatTable(); err == nil {
 		out.Feat = tb
 	}
+	if tb, err := font.LcarTable(); err == nil {
+		out.Lcar = tb
+	}
+	if tb, err := font.OpbdTable(); err == nil {
+		out.Opbd = tb
+	}
+	if tb, err := font.PropTable(); err == nil {
+		out.Prop = tb
+	}
+	if tb, err := font.JustTable(); err == nil {
+		out.Just = tb
+	}
 
 	return out
 }
@@ -458,6 +509,56 @@ func (font *Font) FeatTable() (TableFeat, error) {
 	return parseTableFeat(buf)
 }
 
+// LcarTable parse the AAT 'lcar' table.
+func (font *Font) LcarTable() (TableLcar, error) {
+	buf, err := font.GetRawTable(tagLcar)
+	if err != nil {
+		return TableLcar{}, err
+	}
+
+	return parseTableLcar(buf, int(font.NumGlyphs))
+}
+
+// OpbdTable parse the AAT 'opbd' table.
+func (font *Font) OpbdTable() (TableOpbd, error) {
+	buf, err := font.GetRawTable(tagOpbd)
+	if err != nil {
+		return TableOpbd{}, err
+	}
+
+	return parseTableOpbd(buf, int(font.NumGlyphs))
+}
+
+// PropTable parse the AAT 'prop' table.
+func (font *Font) PropTable() (TableProp, error) {
+	buf, err := font.GetRawTable(tagProp)
+	if err != nil {
+		return TableProp{}, err
+	}
+
+	return parseTableProp(buf, int(font.NumGlyphs))
+}
+
+// JustTable parse the AAT 'just' table.
+func (font *Font) JustTable() (TableJust, error) {
+	buf, err := font.GetRawTable(tagJust)
+	if err != nil {
+		return TableJust{}, err
+	}
+
+	return parseTableJust(buf, int(font.NumGlyphs))
+}
+
+// LtagTable parse the AAT 'ltag' table.
+func (font *Font) LtagTable() (TableLtag, error) {
+	buf, err := font.GetRawTable(tagLtag)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseTableLtag(buf)
+}
+
 // error only if the table is present and invalid
 func (font *Font) tryAndLoadFvarTable() error {
 	s, found := font.tables[tagFvar]
@@ -678,6 +779,9 @@ func (font *Font) findTableBuffer(s *tableSection) ([]byte, error) {
 	return buf, nil
 }
 
+// NameTable returns the 'name' table of the font.
+func (font *Font) NameTable() TableName { return font.Names }
+
 // HasTable returns `true` is the font has the given table.
 func (font *Font) HasTable(tag Tag) bool {
 	_, has := font.tables[tag]
@@ -193,6 +193,17 @@ var (
 	tagSubscriptYSize     = MustNewTag("sbys")
 	tagSubscriptYOffset   = MustNewTag("sbyo")
 	tagSubscriptXOffset   = MustNewTag("sbxo")
+	tagSubscriptXSize     = MustNewTag("sbxs")
+	tagXHeight            = MustNewTag("xhgt")
+	tagCapHeight          = MustNewTag("cpht")
+	tagHCaretRise         = MustNewTag("hcrs")
+	tagHCaretRun          = MustNewTag("hcrn")
+	tagHCaretOffset       = MustNewTag("hcof")
+	tagVCaretRise         = MustNewTag("vcrs")
+	tagVCaretRun          = MustNewTag("vcrn")
+	tagVCaretOffset       = MustNewTag("vcof")
+	tagHClippingAscent    = MustNewTag("hcla")
+	tagHClippingDescent   = MustNewTag("hcld")
 )
 
 func (f *metrics) LineMetric(metric fonts.LineMetric) (float32, bool) {
@@ -215,6 +226,63 @@ func (f *metrics) LineMetric(metric fonts.LineMetric) (float32, bool) {
 		return float32(f.os2.YSubscriptYOffset) + f.mvar.getVar(tagSubscriptYOffset, f.varCoords), true
 	case fonts.SubscriptEmXOffset:
 		return float32(f.os2.YSubscriptXOffset) + f.mvar.getVar(tagSubscriptXOffset, f.varCoords), true
+	case fonts.SuperscriptEmXSize:
+		return float32(f.os2.YSuperscriptXSize) + f.mvar.getVar(tagSuperscriptXSize, f.varCoords), true
+	case fonts.SuperscriptEmYOffset:
+		return float32(f.os2.YSuperscriptYOffset) + f.mvar.getVar(tagSuperscriptYOffset, f.varCoords), true
+	case fonts.SubscriptEmXSize:
+		return float32(f.os2.YSubscriptXSize) + f.mvar.getVar(tagSubscriptXSize, f.varCoords), true
+	case fonts.XHeight:
+		// only defined in version 2 and later
+		if f.os2.Version >= 2 {
+			return float32(f.os2.SxHeigh) + f.mvar.getVar(tagXHeight, f.varCoords), true
+		}
+	case fonts.CapHeight:
+		if f.os2.Version >= 2 {
+			return float32(f.os2.SCapHeight) + f.mvar.getVar(tagCapHeight, f.varCoords), true
+		}
+	case fonts.HorizontalCaretRise:
+		if f.hhea != nil {
+			return float32(f.hhea.CaretSlopeRise) + f.mvar.getVar(tagHCaretRise, f.varCoords), true
+		}
+	case fonts.HorizontalCaretRun:
+		if f.hhea != nil {
+			return float32(f.hhea.CaretSlopeRun) + f.mvar.getVar(tagHCaretRun, f.varCoords), true
+		}
+	case fonts.HorizontalCaretOffset:
+		if f.hhea != nil {
+			return float32(f.hhea.CaretOffset) + f.mvar.getVar(tagHCaretOffset, f.varCoords), true
+		}
+	case fonts.VerticalCaretRise:
+		if f.vhea != nil {
+			return float32(f.vhea.CaretSlopeRise) + f.mvar.getVar(tagVCaretRise, f.varCoords), true
+		}
+	case fonts.VerticalCaretRun:
+		if f.vhea != nil {
+			return float32(f.vhea.CaretSlopeRun) + f.mvar.getVar(tagVCaretRun, f.varCoords), true
+		}
+	case fonts.VerticalCaretOffset:
+		if f.vhea != nil {
+			return float32(f.vhea.CaretOffset) + f.mvar.getVar(tagVCaretOffset, f.varCoords), true
+		}
+	case fonts.HorizontalClippingAscent:
+		if f.os2.hasData() {
+			return float32(f.os2.UsWinAscent) + f.mvar.getVar(tagHClippingAscent, f.varCoords), true
+		}
+	case fonts.HorizontalClippingDescent:
+		if f.os2.hasData() {
+			return float32(f.os2.UsWinDescent) + f.mvar.getVar(tagHClippingDescent, f.varCoords), true
+		}
+	case fonts.VerticalClippingAscent:
+		// there is no dedicated field: use the 'vhea' extents
+		return f.getPositionCommon(metricsTagVerticalAscender)
+	case fonts.VerticalClippingDescent:
+		descent, ok := f.getPositionCommon(metricsTagVerticalDescender)
+		return -descent, ok
+	case fonts.TypoLineGap:
+		if f.os2.hasData() {
+			return float32(f.os2.STypoLineGap) + f.mvar.getVar(metricsTagHorizontalLineGap, f.varCoords), true
+		}
 	}
 	return 0, false
 }
@@ -0,0 +1,75 @@
+package truetype
+
+import (
+	"os"
+	"testing"
+
+	"github.com/benoitkugler/textlayout/fonts"
+)
+
+func loadFont(t *testing.T, file string) *Font {
+	f, err := os.Open(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	font, err := Parse(f, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return font
+}
+
+func TestLineMetric(t *testing.T) {
+	font := loadFont(t, "testdata/Castoro-Italic.ttf")
+	os2, hhea := font.metrics.os2, font.metrics.hhea
+	for metric, expected := range map[fonts.LineMetric]float32{
+		fonts.XHeight:                   475,
+		fonts.CapHeight:                 float32(os2.SCapHeight),
+		fonts.HorizontalCaretRise:       float32(hhea.CaretSlopeRise),
+		fonts.HorizontalCaretRun:        194,
+		fonts.HorizontalCaretOffset:     float32(hhea.CaretOffset),
+		fonts.HorizontalClippingAscent:  float32(os2.UsWinAscent),
+		fonts.HorizontalClippingDescent: float32(os2.UsWinDescent),
+		fonts.TypoLineGap:               float32(os2.STypoLineGap),
+		fonts.SuperscriptEmYOffset:      float32(os2.YSuperscriptYOffset),
+		fonts.SubscriptEmXSize:          float32(os2.YSubscriptXSize),
+	} {
+		got, ok := font.LineMetric(metric)
+		if !ok || got != expected {
+			t.Fatalf("metric %d: expected %f, got %f (%v)", metric, expected, got, ok)
+		}
+	}
+	if _, ok := font.LineMetric(fonts.VerticalCaretRise); ok {
+		t.Fatal("unexpected vertical metric without 'vhea' table")
+	}
+
+	// OS/2 version 2 also defines x-height
+	font = loadFont(t, "testdata/STIX-BoldItalic.otf")
+	if x, ok := font.LineMetric(fonts.XHeight); !ok || x != 449 {
+		t.Fatalf("unexpected x-height %f", x)
+	}
+
+	// no x-height before version 2
+	font = loadFont(t, "testdata/DejaVuSerif.ttf")
+	if _, ok := font.LineMetric(fonts.XHeight); ok {
+		t.Fatal("unexpected x-height")
+	}
+}
+
+func TestLineMetricMvar(t *testing.T) {
+	font := loadFont(t, "testdata/Mada-VF.ttf")
+
+	xHeight, _ := font.LineMetric(fonts.XHeight)
+	ascent, _ := font.LineMetric(fonts.HorizontalClippingAscent)
+	if xHeight != float32(font.metrics.os2.SxHeigh) || ascent != float32(font.metrics.os2.UsWinAscent) {
+		t.Fatalf("unexpected default metrics %f %f", xHeight, ascent)
+	}
+
+	font.SetVarCoordinates([]float32{1})
+	xHeightVar, _ := font.LineMetric(fonts.XHeight)
+	ascentVar, _ := font.LineMetric(fonts.HorizontalClippingAscent)
+	if xHeightVar == xHeight || ascentVar == ascent {
+		t.Fatalf("expected variations, got %f %f", xHeightVar, ascentVar)
+	}
+}
@@ -49,6 +49,9 @@ var (
 	tagMvar = MustNewTag("MVAR")
 	tagHvar = MustNewTag("HVAR")
 	tagVvar = MustNewTag("VVAR")
+	tagGasp = MustNewTag("gasp")
+	tagMeta = MustNewTag("meta")
+	tagJstf = MustNewTag("JSTF")
 
 	tagFeat = MustNewTag("feat")
 	tagMort = MustNewTag("mort")
@@ -56,6 +59,11 @@ var (
 	tagKerx = MustNewTag("kerx")
 	tagAnkr = MustNewTag("ankr")
 	tagTrak = MustNewTag("trak")
+	tagLtag = MustNewTag("ltag")
+	tagLcar = MustNewTag("lcar")
+	tagOpbd = MustNewTag("opbd")
+	tagProp = MustNewTag("prop")
+	tagJust = MustNewTag("just")
 
 	// TypeTrueType is the first four bytes of an OpenType file containing a TrueType font
 	TypeTrueType = Tag(0x00010000)
@@ -0,0 +1,68 @@
+package truetype
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// GaspBehavior is a bit field describing the rasterization
+// behavior to use for a range of ppem sizes.
+type GaspBehavior uint16
+
+const (
+	// GaspGridfit uses gridfitting (hinting)
+	GaspGridfit GaspBehavior = 1 << iota
+	// GaspDoGray uses grayscale rendering
+	GaspDoGray
+	// GaspSymmetricGridfit uses gridfitting with ClearType symmetric smoothing
+	// (only supported in version 1)
+	GaspSymmetricGridfit
+	// GaspSymmetricSmoothing uses smoothing along multiple axes with ClearType
+	// (only supported in version 1)
+	GaspSymmetricSmoothing
+)
+
+// GaspRange defines the behavior for sizes up to (and including) `MaxPPEM`.
+type GaspRange struct {
+	MaxPPEM  uint16
+	Behavior GaspBehavior
+}
+
+// TableGasp is the 'gasp' table, which describes
+// the preferred rasterization techniques for the font.
+// The ranges are sorted by increasing `MaxPPEM`.
+type TableGasp []GaspRange
+
+// Behavior returns the behavior for the given size,
+// or 0 if the table does not specify it.
+func (t TableGasp) Behavior(ppem uint16) GaspBehavior {
+	for _, r := range t {
+		if ppem <= r.MaxPPEM {
+			return r.Behavior
+		}
+	}
+	return 0
+}
+
+func parseTableGasp(data []byte) (TableGasp, error) {
+	if len(data) < 4 {
+		return nil, errors.New("invalid 'gasp' table (EOF)")
+	}
+	version := binary.BigEndian.Uint16(data)
+	count := int(binary.BigEndian.Uint16(data[2:]))
+	if len(data) < 4+4*count {
+		return nil, errors.New("invalid 'gasp' table (EOF)")
+	}
+	out := make(TableGasp, count)
+	for i := range out {
+		out[i].MaxPPEM = binary.BigEndian.Uint16(data[4+4*i:])
+		out[i].Behavior = GaspBehavior(binary.BigEndian.Uint16(data[4+4*i+2:]))
+		if version == 0 { // ClearType flags are only valid in version 1
+			out[i].Behavior &= GaspGridfit | GaspDoGray
+		}
+		if i > 0 && out[i].MaxPPEM <= out[i-1].MaxPPEM {
+			return nil, errors.New("invalid 'gasp' table (unsorted ranges)")
+		}
+	}
+	return out, nil
+}
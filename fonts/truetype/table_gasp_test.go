@@ -0,0 +1,68 @@
+package truetype
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestGasp(t *testing.T) {
+	f, err := os.Open("testdata/DejaVuSerif.ttf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	font, err := Parse(f, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gasp, err := font.GaspTable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gasp) == 0 {
+		t.Fatal("empty gasp table")
+	}
+	if last := gasp[len(gasp)-1]; last.MaxPPEM != 0xFFFF {
+		t.Fatalf("unexpected last range %v", last)
+	}
+}
+
+func TestParseGasp(t *testing.T) {
+	data := []byte{
+		0, 1, 0, 2, // version, count
+		0, 8, 0, 2, // up to 8 ppem: gray
+		0xFF, 0xFF, 0, 15, // otherwise: all
+	}
+	gasp, err := parseTableGasp(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := TableGasp{
+		{MaxPPEM: 8, Behavior: GaspDoGray},
+		{MaxPPEM: 0xFFFF, Behavior: GaspGridfit | GaspDoGray | GaspSymmetricGridfit | GaspSymmetricSmoothing},
+	}
+	if !reflect.DeepEqual(gasp, exp) {
+		t.Fatalf("expected %v, got %v", exp, gasp)
+	}
+	if b := gasp.Behavior(8); b != GaspDoGray {
+		t.Fatalf("unexpected behavior %d", b)
+	}
+	if b := gasp.Behavior(9); b != exp[1].Behavior {
+		t.Fatalf("unexpected behavior %d", b)
+	}
+
+	data[1] = 0 // version 0 only supports the first two bits
+	gasp, err = parseTableGasp(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := gasp.Behavior(20); b != GaspGridfit|GaspDoGray {
+		t.Fatalf("unexpected behavior %d", b)
+	}
+
+	if _, err = parseTableGasp(data[:10]); err == nil {
+		t.Fatal("expected error on truncated table")
+	}
+}
@@ -0,0 +1,247 @@
+package truetype
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// TableJstf is the OpenType 'JSTF' table, which provides
+// justification alternatives, by script and language system.
+// See https://docs.microsoft.com/en-us/typography/opentype/spec/jstf
+type TableJstf struct {
+	Scripts []JstfScript // sorted by tag
+}
+
+// FindScript looks for `script` and return its index into the Scripts slice,
+// or -1 if the tag is not found.
+func (t TableJstf) FindScript(script Tag) int {
+	// Scripts is sorted: binary search
+	low, high := 0, len(t.Scripts)
+	for low < high {
+		mid := low + (high-low)/2 // avoid overflow when computing mid
+		p := t.Scripts[mid].Tag
+		if script < p {
+			high = mid
+		} else if script > p {
+			low = mid + 1
+		} else {
+			return mid
+		}
+	}
+	return -1
+}
+
+// JstfScript stores the justification data for a script.
+type JstfScript struct {
+	// ExtenderGlyphs are the glyphs (like the Arabic kashida)
+	// which may be inserted to extend the text.
+	ExtenderGlyphs  []GID
+	DefaultLanguage *JstfLangSys
+	Languages       []JstfLangSys // sorted by tag
+	Tag             Tag
+}
+
+// FindLanguage looks for `language` and return its index into the Languages slice,
+// or -1 if the tag is not found.
+func (t JstfScript) FindLanguage(language Tag) int {
+	// Languages is sorted: binary search
+	low, high := 0, len(t.Languages)
+	for low < high {
+		mid := low + (high-low)/2 // avoid overflow when computing mid
+		p := t.Languages[mid].Tag
+		if language < p {
+			high = mid
+		} else if language > p {
+			low = mid + 1
+		} else {
+			return mid
+		}
+	}
+	return -1
+}
+
+// JstfLangSys stores the justification suggestions for a language system.
+type JstfLangSys struct {
+	// Priorities are sorted from the most to the least preferred suggestion.
+	Priorities []JstfPriority
+	Tag        Tag
+}
+
+// JstfPriority stores one level of justification suggestions,
+// used to shrink or extend the text.
+type JstfPriority struct {
+	Shrinkage, Extension JstfModifications
+}
+
+// JstfModifications lists the changes in the
+// GSUB and GPOS lookups to apply, and the maximum adjustment allowed.
+type JstfModifications struct {
+	// Lookups to enable or disable, given as indices into the GSUB and GPOS lookup lists.
+	EnableGSUB, DisableGSUB, EnableGPOS, DisableGPOS []uint16
+
+	// Max stores GPOS lookups defining the maximum adjustment
+	// of the glyph positions.
+	Max []LookupGPOS
+}
+
+func parseTableJstf(data []byte) (out TableJstf, err error) {
+	if len(data) < 6 {
+		return out, errors.New("invalid 'JSTF' table (EOF)")
+	}
+	// version is ignored
+	count := int(binary.BigEndian.Uint16(data[4:]))
+	if len(data) < 6+6*count {
+		return out, errors.New("invalid 'JSTF' table (EOF)")
+	}
+	out.Scripts = make([]JstfScript, count)
+	for i := range out.Scripts {
+		out.Scripts[i].Tag = Tag(binary.BigEndian.Uint32(data[6+6*i:]))
+		offset := int(binary.BigEndian.Uint16(data[6+6*i+4:]))
+		if offset >= len(data) {
+			return out, errors.New("invalid 'JSTF' script offset")
+		}
+		err = out.Scripts[i].parse(data[offset:])
+		if err != nil {
+			return out, err
+		}
+	}
+	return out, nil
+}
+
+// data starts at the JstfScript table
+func (s *JstfScript) parse(data []byte) error {
+	if len(data) < 6 {
+		return errors.New("invalid 'JSTF' script table (EOF)")
+	}
+	extenderOffset := int(binary.BigEndian.Uint16(data))
+	defaultOffset := int(binary.BigEndian.Uint16(data[2:]))
+	count := int(binary.BigEndian.Uint16(data[4:]))
+
+	if extenderOffset != 0 {
+		if len(data) < extenderOffset+2 {
+			return errors.New("invalid 'JSTF' extender glyphs (EOF)")
+		}
+		glyphCount := int(binary.BigEndian.Uint16(data[extenderOffset:]))
+		glyphs, err := parseUint16s(data[extenderOffset+2:], glyphCount)
+		if err != nil {
+			return fmt.Errorf("invalid 'JSTF' extender glyphs: %s", err)
+		}
+		s.ExtenderGlyphs = make([]GID, glyphCount)
+		for i, g := range glyphs {
+			s.ExtenderGlyphs[i] = GID(g)
+		}
+	}
+
+	if defaultOffset != 0 {
+		lang, err := parseJstfLangSys(data, defaultOffset)
+		if err != nil {
+			return err
+		}
+		s.DefaultLanguage = &lang
+	}
+
+	if len(data) < 6+6*count {
+		return errors.New("invalid 'JSTF' script table (EOF)")
+	}
+	s.Languages = make([]JstfLangSys, count)
+	for i := range s.Languages {
+		offset := int(binary.BigEndian.Uint16(data[6+6*i+4:]))
+		lang, err := parseJstfLangSys(data, offset)
+		if err != nil {
+			return err
+		}
+		lang.Tag = Tag(binary.BigEndian.Uint32(data[6+6*i:]))
+		s.Languages[i] = lang
+	}
+	return nil
+}
+
+func parseJstfLangSys(data []byte, offset int) (out JstfLangSys, err error) {
+	if len(data) < offset+2 {
+		return out, errors.New("invalid 'JSTF' language system (EOF)")
+	}
+	data = data[offset:]
+	count := int(binary.BigEndian.Uint16(data))
+	offsets, err := parseUint16s(data[2:], count)
+	if err != nil {
+		return out, fmt.Errorf("invalid 'JSTF' language system: %s", err)
+	}
+	out.Priorities = make([]JstfPriority, count)
+	for i, offset := range offsets {
+		out.Priorities[i], err = parseJstfPriority(data, int(offset))
+		if err != nil {
+			return out, err
+		}
+	}
+	return out, nil
+}
+
+func parseJstfPriority(data []byte, offset int) (out JstfPriority, err error) {
+	if len(data) < offset+20 {
+		return out, errors.New("invalid 'JSTF' priority (EOF)")
+	}
+	data = data[offset:]
+	offsets, _ := parseUint16s(data, 10)
+	for i, mods := range [2]*JstfModifications{&out.Shrinkage, &out.Extension} {
+		offsets := offsets[5*i : 5*i+5]
+		lists := [4]*[]uint16{&mods.EnableGSUB, &mods.DisableGSUB, &mods.EnableGPOS, &mods.DisableGPOS}
+		for j, list := range lists {
+			*list, err = parseJstfModList(data, int(offsets[j]))
+			if err != nil {
+				return out, err
+			}
+		}
+		mods.Max, err = parseJstfMax(data, int(offsets[4]))
+		if err != nil {
+			return out, err
+		}
+	}
+	return out, nil
+}
+
+// parseJstfModList parses a JstfGSUBModList or JstfGPOSModList,
+// returning nil for a null offset.
+func parseJstfModList(data []byte, offset int) ([]uint16, error) {
+	if offset == 0 {
+		return nil, nil
+	}
+	if len(data) < offset+2 {
+		return nil, errors.New("invalid 'JSTF' lookup list (EOF)")
+	}
+	count := int(binary.BigEndian.Uint16(data[offset:]))
+	out, err := parseUint16s(data[offset+2:], count)
+	if err != nil {
+		return nil, fmt.Errorf("invalid 'JSTF' lookup list: %s", err)
+	}
+	return out, nil
+}
+
+// parseJstfMax returns nil for a null offset
+func parseJstfMax(data []byte, offset int) ([]LookupGPOS, error) {
+	if offset == 0 {
+		return nil, nil
+	}
+	if len(data) < offset+2 {
+		return nil, errors.New("invalid 'JSTF' max table (EOF)")
+	}
+	data = data[offset:]
+	count := int(binary.BigEndian.Uint16(data))
+	offsets, err := parseUint16s(data[2:], count)
+	if err != nil {
+		return nil, fmt.Errorf("invalid 'JSTF' max table: %s", err)
+	}
+	var t TableLayout // only used as receiver
+	out := make([]LookupGPOS, count)
+	for i, offset := range offsets {
+		l, err := t.parseLookup(data, offset)
+		if err != nil {
+			return nil, fmt.Errorf("invalid 'JSTF' max table: %s", err)
+		}
+		out[i], err = l.parseGPOS(uint16(count))
+		if err != nil {
+			return nil, fmt.Errorf("invalid 'JSTF' max table: %s", err)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,72 @@
+package truetype
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestJSTF(t *testing.T) {
+	filename := "testdata/Roboto-JSTF.ttf"
+	file, err := os.Open(filename)
+	if err != nil {
+		t.Fatalf("Failed to open %q: %s\n", filename, err)
+	}
+	defer file.Close()
+
+	font, err := Parse(file, false)
+	if err != nil {
+		t.Fatalf("Parse(%q) err = %q, want nil", filename, err)
+	}
+
+	jstf, err := font.JSTFTable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jstf.Scripts) != 1 || jstf.FindScript(MustNewTag("latn")) != 0 || jstf.FindScript(MustNewTag("arab")) != -1 {
+		t.Fatalf("unexpected scripts %v", jstf.Scripts)
+	}
+	script := jstf.Scripts[0]
+	if !reflect.DeepEqual(script.ExtenderGlyphs, []GID{3}) {
+		t.Fatalf("unexpected extender glyphs %v", script.ExtenderGlyphs)
+	}
+	if script.DefaultLanguage == nil || len(script.DefaultLanguage.Priorities) != 2 {
+		t.Fatalf("unexpected default language %v", script.DefaultLanguage)
+	}
+
+	p0 := script.DefaultLanguage.Priorities[0]
+	if !reflect.DeepEqual(p0.Shrinkage, JstfModifications{}) {
+		t.Fatalf("unexpected shrinkage %v", p0.Shrinkage)
+	}
+	if !reflect.DeepEqual(p0.Extension.EnableGPOS, []uint16{0}) || len(p0.Extension.Max) != 1 {
+		t.Fatalf("unexpected extension %v", p0.Extension)
+	}
+	max := p0.Extension.Max[0]
+	if max.Type != GPOSSingle || len(max.Subtables) != 1 {
+		t.Fatalf("unexpected max lookup %v", max)
+	}
+	if _, ok := max.Subtables[0].Coverage.Index(3); !ok {
+		t.Fatalf("unexpected max coverage %v", max.Subtables[0].Coverage)
+	}
+	single, ok := max.Subtables[0].Data.(GPOSSingle1)
+	if !ok || single.Value.XAdvance != 200 {
+		t.Fatalf("unexpected max subtable %v", max.Subtables[0].Data)
+	}
+
+	p1 := script.DefaultLanguage.Priorities[1]
+	if !reflect.DeepEqual(p1.Shrinkage.DisableGPOS, []uint16{1}) || !reflect.DeepEqual(p1.Extension.DisableGPOS, []uint16{1}) {
+		t.Fatalf("unexpected priority %v", p1)
+	}
+
+	if index := script.FindLanguage(MustNewTag("TRK ")); index != 0 {
+		t.Fatalf("unexpected language index %d", index)
+	}
+	trk := script.Languages[0]
+	if len(trk.Priorities) != 1 || !reflect.DeepEqual(trk.Priorities[0].Extension.DisableGSUB, []uint16{16, 17}) {
+		t.Fatalf("unexpected language %v", trk)
+	}
+
+	if tables := font.LayoutTables(); !reflect.DeepEqual(tables.JSTF, jstf) {
+		t.Fatal("JSTF table not loaded by LayoutTables")
+	}
+}
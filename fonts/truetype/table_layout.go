@@ -122,7 +122,123 @@ type FeatureRecord struct {
 // Feature represents a glyph substitution or glyph positioning features.
 type Feature struct {
 	LookupIndices []uint16
-	paramsOffet   uint16
+	// Params is the optional, feature specific data, or nil.
+	Params FeatureParams
+}
+
+// FeatureParams provides additional information about a feature.
+// The concrete type depends on the feature tag :
+// FeatureParamsSize for 'size', FeatureParamsStylisticSet for 'ss01' to 'ss20' and
+// FeatureParamsCharacterVariants for 'cv01' to 'cv99'.
+type FeatureParams interface {
+	isFeatureParams()
+}
+
+func (FeatureParamsSize) isFeatureParams()              {}
+func (FeatureParamsStylisticSet) isFeatureParams()      {}
+func (FeatureParamsCharacterVariants) isFeatureParams() {}
+
+// FeatureParamsSize is used by the 'size' feature.
+// See https://docs.microsoft.com/en-us/typography/opentype/spec/features_pt#size
+type FeatureParamsSize struct {
+	// The design size, in 720/inch units (decipoints).
+	DesignSize uint16
+	// Identifies the font within a family sharing the same
+	// subfamily name, or 0.
+	SubfamilyID uint16
+	// Name ID of the subfamily name, or 0.
+	SubfamilyNameID NameID
+	// The range of sizes (in decipoints) for which the font
+	// is recommended : RangeStart is exclusive, RangeEnd is inclusive.
+	RangeStart, RangeEnd uint16
+}
+
+// FeatureParamsStylisticSet is used by the 'ss01' to 'ss20' features.
+// See https://docs.microsoft.com/en-us/typography/opentype/spec/features_pt#ssxx
+type FeatureParamsStylisticSet struct {
+	// Name ID of the feature UI label, or 0
+	UINameID NameID
+}
+
+// FeatureParamsCharacterVariants is used by the 'cv01' to 'cv99' features.
+// See https://docs.microsoft.com/en-us/typography/opentype/spec/features_ae#cv01-cv99
+type FeatureParamsCharacterVariants struct {
+	// Name ID of the feature UI label, or 0
+	FeatUILabelNameID NameID
+	// Name ID of a tooltip text, or 0
+	FeatUITooltipTextNameID NameID
+	// Name ID of a sample text, or 0
+	SampleTextNameID NameID
+	// Number of named parameters (may be zero)
+	NumNamedParameters uint16
+	// Name ID of the first parameter UI label, or 0
+	// (the other labels use the following IDs)
+	FirstParamUILabelNameID NameID
+	// The characters for which this feature provides glyph variants
+	Characters []rune
+}
+
+// isCharacterVariantsTag returns true for 'cv01' to 'cv99'
+func isCharacterVariantsTag(tag Tag) bool {
+	d1, d2 := byte(tag>>8), byte(tag)
+	return byte(tag>>24) == 'c' && byte(tag>>16) == 'v' &&
+		'0' <= d1 && d1 <= '9' && '0' <= d2 && d2 <= '9' && tag != MustNewTag("cv00")
+}
+
+// isStylisticSetTag returns true for 'ss01' to 'ss20'
+func isStylisticSetTag(tag Tag) bool {
+	d1, d2 := byte(tag>>8), byte(tag)
+	return byte(tag>>24) == 's' && byte(tag>>16) == 's' &&
+		'0' <= d1 && d1 <= '2' && '0' <= d2 && d2 <= '9' &&
+		tag != MustNewTag("ss00") && tag <= MustNewTag("ss20")
+}
+
+// parseFeatureParams parses the params of the feature with `tag`,
+// starting at `b`. Unsupported or invalid params are ignored.
+func parseFeatureParams(b []byte, tag Tag) FeatureParams {
+	switch {
+	case tag == MustNewTag("size"):
+		if len(b) < 10 {
+			return nil
+		}
+		params := FeatureParamsSize{
+			DesignSize:      binary.BigEndian.Uint16(b),
+			SubfamilyID:     binary.BigEndian.Uint16(b[2:]),
+			SubfamilyNameID: NameID(binary.BigEndian.Uint16(b[4:])),
+			RangeStart:      binary.BigEndian.Uint16(b[6:]),
+			RangeEnd:        binary.BigEndian.Uint16(b[8:]),
+		}
+		if !params.isValid() {
+			return nil
+		}
+		return params
+	case isStylisticSetTag(tag):
+		if len(b) < 4 || binary.BigEndian.Uint16(b) != 0 { // version must be 0
+			return nil
+		}
+		return FeatureParamsStylisticSet{UINameID: NameID(binary.BigEndian.Uint16(b[2:]))}
+	case isCharacterVariantsTag(tag):
+		if len(b) < 14 || binary.BigEndian.Uint16(b) != 0 { // format must be 0
+			return nil
+		}
+		params := FeatureParamsCharacterVariants{
+			FeatUILabelNameID:       NameID(binary.BigEndian.Uint16(b[2:])),
+			FeatUITooltipTextNameID: NameID(binary.BigEndian.Uint16(b[4:])),
+			SampleTextNameID:        NameID(binary.BigEndian.Uint16(b[6:])),
+			NumNamedParameters:      binary.BigEndian.Uint16(b[8:]),
+			FirstParamUILabelNameID: NameID(binary.BigEndian.Uint16(b[10:])),
+		}
+		count := int(binary.BigEndian.Uint16(b[12:]))
+		if len(b) < 14+3*count {
+			return nil
+		}
+		params.Characters = make([]rune, count)
+		for i := range params.Characters {
+			params.Characters[i] = parseUint24(b[14+3*i:])
+		}
+		return params
+	}
+	return nil
 }
 
 type LookupOptions struct {
@@ -323,9 +439,23 @@ func (t *TableLayout) parseScriptList(buf []byte) error {
 	return nil
 }
 
+// isValid applies the sanity checks used by Harfbuzz, which are
+// required to detect the old, incorrect offsets to the params.
+func (params FeatureParamsSize) isValid() bool {
+	if params.DesignSize == 0 {
+		return false
+	}
+	if params.SubfamilyID == 0 && params.SubfamilyNameID == 0 && params.RangeStart == 0 && params.RangeEnd == 0 {
+		return true
+	}
+	return params.RangeStart <= params.DesignSize && params.DesignSize <= params.RangeEnd &&
+		256 <= params.SubfamilyNameID && params.SubfamilyNameID <= 32767
+}
+
 // parseFeature parses a single Feature table. b expected to be the beginning of the feature
+// `tag` is used to interpret the feature params.
 // See https://www.microsoft.com/typography/otspec/chapter2.htm#featTbl
-func parseFeature(b []byte) (Feature, error) {
+func parseFeature(b []byte, tag Tag) (Feature, error) {
 	r := bytes.NewReader(b)
 
 	var feature struct {
@@ -341,7 +471,11 @@ func parseFeature(b []byte) (Feature, error) {
 		return Feature{}, fmt.Errorf("reading featureTable: %s", err)
 	}
 
-	return Feature{paramsOffet: feature.FeatureParams, LookupIndices: lookupIndices}, nil
+	out := Feature{LookupIndices: lookupIndices}
+	if feature.FeatureParams != 0 && int(feature.FeatureParams) < len(b) {
+		out.Params = parseFeatureParams(b[feature.FeatureParams:], tag)
+	}
+	return out, nil
 }
 
 // parseFeatureList parses the FeatureList.
@@ -370,10 +504,16 @@ func (t *TableLayout) parseFeatureList(buf []byte) error {
 		if len(b) < int(record.Offset) {
 			return io.ErrUnexpectedEOF
 		}
-		feature, err := parseFeature(b[record.Offset:])
+		feature, err := parseFeature(b[record.Offset:], record.Tag)
 		if err != nil {
 			return err
 		}
+		if record.Tag == MustNewTag("size") && feature.Params == nil {
+			// some old fonts use an offset relative to the FeatureList
+			if offset := binary.BigEndian.Uint16(b[record.Offset:]); offset != 0 && int(offset) < len(b) {
+				feature.Params = parseFeatureParams(b[offset:], record.Tag)
+			}
+		}
 
 		t.Features[i] = FeatureRecord{Tag: record.Tag, Feature: feature}
 	}
@@ -610,7 +750,8 @@ func parseFeatureSubstitution(buf []byte) ([]FeatureSubstitution, error) {
 			return nil, io.ErrUnexpectedEOF
 		}
 		var err error
-		out[i].AlternateFeature, err = parseFeature(buf[alternateFeatureOffset:])
+		// the params of the alternate features are not used
+		out[i].AlternateFeature, err = parseFeature(buf[alternateFeatureOffset:], 0)
 		if err != nil {
 			return nil, err
 		}
@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"reflect"
 	"sort"
 	"testing"
 )
@@ -111,3 +112,40 @@ func TestFeatureVariations(t *testing.T) {
 	}
 	fmt.Println(gdef.Class)
 }
+
+func TestFeatureParamsCharacterVariants(t *testing.T) {
+	filename := "testdata/cv01.otf"
+	file, err := os.Open(filename)
+	if err != nil {
+		t.Fatalf("Failed to open %q: %s\n", filename, err)
+	}
+	defer file.Close()
+
+	font, err := Parse(file, false)
+	if err != nil {
+		t.Fatalf("Parse(%q) err = %q, want nil", filename, err)
+	}
+
+	gsub, err := font.GSUBTable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gsub.Features) == 0 || gsub.Features[0].Tag != MustNewTag("cv01") {
+		t.Fatalf("unexpected features %v", gsub.Features)
+	}
+	params, ok := gsub.Features[0].Params.(FeatureParamsCharacterVariants)
+	if !ok {
+		t.Fatalf("unexpected params %T", gsub.Features[0].Params)
+	}
+	expected := FeatureParamsCharacterVariants{
+		FeatUILabelNameID:       256,
+		FeatUITooltipTextNameID: 257,
+		SampleTextNameID:        258,
+		NumNamedParameters:      2,
+		FirstParamUILabelNameID: 259,
+		Characters:              []rune{10, 24030},
+	}
+	if !reflect.DeepEqual(params, expected) {
+		t.Fatalf("expected %v, got %v", expected, params)
+	}
+}
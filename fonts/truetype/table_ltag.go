@@ -0,0 +1,42 @@
+package truetype
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// TableLtag is the AAT 'ltag' table, which maps
+// numeric language codes to IETF language tags (BCP 47).
+// It is used for instance by the 'name' table, when
+// the platform is Unicode and the language ID is not 0xFFFF.
+type TableLtag []string
+
+// Language returns the tag for the given index, or an empty string
+// if it is out of range.
+func (t TableLtag) Language(index uint16) string {
+	if int(index) >= len(t) {
+		return ""
+	}
+	return t[index]
+}
+
+func parseTableLtag(data []byte) (TableLtag, error) {
+	if len(data) < 12 {
+		return nil, errors.New("invalid 'ltag' table (EOF)")
+	}
+	// ignoring version and flags
+	count := int(binary.BigEndian.Uint32(data[8:]))
+	if len(data) < 12+4*count {
+		return nil, errors.New("invalid 'ltag' table (EOF)")
+	}
+	out := make(TableLtag, count)
+	for i := range out {
+		offset := int(binary.BigEndian.Uint16(data[12+4*i:]))
+		length := int(binary.BigEndian.Uint16(data[12+4*i+2:]))
+		if len(data) < offset+length {
+			return nil, errors.New("invalid 'ltag' table string (EOF)")
+		}
+		out[i] = string(data[offset : offset+length])
+	}
+	return out, nil
+}
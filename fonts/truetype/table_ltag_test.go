@@ -0,0 +1,36 @@
+package truetype
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseLtag(t *testing.T) {
+	data := []byte{
+		0, 0, 0, 1, // version
+		0, 0, 0, 0, // flags
+		0, 0, 0, 3, // count
+		0, 24, 0, 2,
+		0, 26, 0, 7,
+		0, 24, 0, 2, // strings may be shared
+	}
+	data = append(data, "enzh-Hant"...)
+
+	ltag, err := parseTableLtag(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := (TableLtag{"en", "zh-Hant", "en"}); !reflect.DeepEqual(ltag, exp) {
+		t.Fatalf("expected %v, got %v", exp, ltag)
+	}
+	if l := ltag.Language(1); l != "zh-Hant" {
+		t.Fatalf("unexpected language %s", l)
+	}
+	if l := ltag.Language(3); l != "" {
+		t.Fatalf("unexpected language %s", l)
+	}
+
+	if _, err = parseTableLtag(data[:20]); err == nil {
+		t.Fatal("expected error on truncated table")
+	}
+}
@@ -0,0 +1,63 @@
+package truetype
+
+import (
+	"encoding/binary"
+	"errors"
+	"strings"
+)
+
+var (
+	metaDesignLanguages    = MustNewTag("dlng")
+	metaSupportedLanguages = MustNewTag("slng")
+)
+
+// TableMeta is the 'meta' table, storing metadata about the font.
+// The raw data for each tag is available in `Data`, and the
+// 'dlng' and 'slng' entries are decoded.
+type TableMeta struct {
+	Data map[Tag][]byte
+
+	// DesignLanguages lists the languages or scripts the font
+	// was primarily designed for, as ScriptLangTags (like "en-Latn" or "Cyrl").
+	DesignLanguages []string
+
+	// SupportedLanguages lists the languages or scripts that
+	// the font is declared to be capable of supporting, as ScriptLangTags.
+	SupportedLanguages []string
+}
+
+func parseTableMeta(data []byte) (out TableMeta, err error) {
+	if len(data) < 16 {
+		return out, errors.New("invalid 'meta' table (EOF)")
+	}
+	count := int(binary.BigEndian.Uint32(data[12:]))
+	if len(data) < 16+12*count {
+		return out, errors.New("invalid 'meta' table (EOF)")
+	}
+	out.Data = make(map[Tag][]byte, count)
+	for i := 0; i < count; i++ {
+		tag := Tag(binary.BigEndian.Uint32(data[16+12*i:]))
+		offset := binary.BigEndian.Uint32(data[16+12*i+4:])
+		length := binary.BigEndian.Uint32(data[16+12*i+8:])
+		if uint64(offset)+uint64(length) > uint64(len(data)) {
+			return out, errors.New("invalid 'meta' table data map (EOF)")
+		}
+		out.Data[tag] = data[offset : offset+length]
+	}
+
+	out.DesignLanguages = parseScriptLangTags(out.Data[metaDesignLanguages])
+	out.SupportedLanguages = parseScriptLangTags(out.Data[metaSupportedLanguages])
+	return out, nil
+}
+
+// parseScriptLangTags splits a comma-separated list of ScriptLangTags,
+// returning nil for empty data.
+func parseScriptLangTags(data []byte) []string {
+	var out []string
+	for _, tag := range strings.Split(string(data), ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			out = append(out, tag)
+		}
+	}
+	return out
+}
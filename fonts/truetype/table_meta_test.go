@@ -0,0 +1,49 @@
+package truetype
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestMeta(t *testing.T) {
+	filename := "testdata/Bangla Sangam MN.ttc"
+	file, err := os.Open(filename)
+	if err != nil {
+		t.Fatalf("Failed to open %q: %s\n", filename, err)
+	}
+	defer file.Close()
+
+	fonts, err := Loader.Load(file)
+	if err != nil {
+		t.Fatalf("Parse(%q) err = %q, want nil", filename, err)
+	}
+
+	for _, font := range fonts {
+		font := font.(*Font)
+
+		meta, err := font.MetaTable()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if exp := []string{"bn"}; !reflect.DeepEqual(meta.DesignLanguages, exp) {
+			t.Fatalf("expected %v, got %v", exp, meta.DesignLanguages)
+		}
+		if len(meta.SupportedLanguages) != 36 || meta.SupportedLanguages[4] != "bn" {
+			t.Fatalf("unexpected supported languages %v", meta.SupportedLanguages)
+		}
+		if len(meta.Data) != 4 {
+			t.Fatalf("unexpected data map %v", meta.Data)
+		}
+	}
+}
+
+func TestParseScriptLangTags(t *testing.T) {
+	if got := parseScriptLangTags(nil); got != nil {
+		t.Fatalf("expected nil, got %v", got)
+	}
+	exp := []string{"en-Latn", "Cyrl", "ja"}
+	if got := parseScriptLangTags([]byte("en-Latn, Cyrl,ja,")); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("expected %v, got %v", exp, got)
+	}
+}
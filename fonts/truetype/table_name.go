@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 	"io"
 	"strconv"
+	"strings"
 
 	"golang.org/x/text/encoding/charmap"
 	"golang.org/x/text/encoding/unicode"
@@ -108,6 +109,34 @@ func (names TableName) SelectEntry(name NameID) *NameEntry {
 	return nil
 }
 
+// SelectEntryLanguage returns the entry for `name` in the language `lang`,
+// given as a lowercase RFC 3066 tag (see `NameEntry.Language`).
+// If not found, entries with the same primary language are tried, then
+// `SelectEntry` is used. It returns nil if `name` is not found at all.
+func (names TableName) SelectEntryLanguage(name NameID, lang string) *NameEntry {
+	primary := lang
+	if i := strings.IndexByte(lang, '-'); i != -1 {
+		primary = lang[:i]
+	}
+	partial := -1
+	for i, rec := range names {
+		if rec.NameID != name || len(rec.Value) == 0 {
+			continue
+		}
+		entryLang := rec.Language()
+		if entryLang == lang {
+			return &names[i]
+		}
+		if partial == -1 && (entryLang == primary || strings.HasPrefix(entryLang, primary+"-")) {
+			partial = i
+		}
+	}
+	if partial != -1 {
+		return &names[partial]
+	}
+	return names.SelectEntry(name)
+}
+
 type NameEntry struct {
 	Value      []byte // raw value of the name
 	PlatformID PlatformID
@@ -0,0 +1,774 @@
+package truetype
+
+// ported from fontconfig/src/fcfreetype.c   2000 Keith Packard
+
+const lang_DONT_CARE = 0xffff
+
+const (
+	macLangID_ENGLISH = iota
+	macLangID_FRENCH
+	macLangID_GERMAN
+	macLangID_ITALIAN
+	macLangID_DUTCH
+	macLangID_SWEDISH
+	macLangID_SPANISH
+	macLangID_DANISH
+	macLangID_PORTUGUESE
+	macLangID_NORWEGIAN
+	macLangID_HEBREW
+	macLangID_JAPANESE
+	macLangID_ARABIC
+	macLangID_FINNISH
+	macLangID_GREEK
+	macLangID_ICELANDIC
+	macLangID_MALTESE
+	macLangID_TURKISH
+	macLangID_CROATIAN
+	macLangID_CHINESE_TRADITIONAL
+	macLangID_URDU
+	macLangID_HINDI
+	macLangID_THAI
+	macLangID_KOREAN
+	macLangID_LITHUANIAN
+	macLangID_POLISH
+	macLangID_HUNGARIAN
+	macLangID_ESTONIAN
+	macLangID_LETTISH
+	macLangID_SAAMISK
+	macLangID_FAEROESE
+	macLangID_FARSI
+	macLangID_RUSSIAN
+	macLangID_CHINESE_SIMPLIFIED
+	macLangID_FLEMISH
+	macLangID_IRISH
+	macLangID_ALBANIAN
+	macLangID_ROMANIAN
+	macLangID_CZECH
+	macLangID_SLOVAK
+	macLangID_SLOVENIAN
+	macLangID_YIDDISH
+	macLangID_SERBIAN
+	macLangID_MACEDONIAN
+	macLangID_BULGARIAN
+	macLangID_UKRAINIAN
+	macLangID_BYELORUSSIAN
+	macLangID_UZBEK
+	macLangID_KAZAKH
+	//  macLangID_AZERBAIJANI
+	macLangID_AZERBAIJANI_CYRILLIC_SCRIPT
+	macLangID_AZERBAIJANI_ARABIC_SCRIPT
+	macLangID_ARMENIAN
+	macLangID_GEORGIAN
+	macLangID_MOLDAVIAN
+	macLangID_KIRGHIZ
+	macLangID_TAJIKI
+	macLangID_TURKMEN
+	macLangID_MONGOLIAN
+	//  macLangID_MONGOLIAN_MONGOLIAN_SCRIPT
+	macLangID_MONGOLIAN_CYRILLIC_SCRIPT
+	macLangID_PASHTO
+	macLangID_KURDISH
+	macLangID_KASHMIRI
+	macLangID_SINDHI
+	macLangID_TIBETAN
+	macLangID_NEPALI
+	macLangID_SANSKRIT
+	macLangID_MARATHI
+	macLangID_BENGALI
+	macLangID_ASSAMESE
+	macLangID_GUJARATI
+	macLangID_PUNJABI
+	macLangID_ORIYA
+	macLangID_MALAYALAM
+	macLangID_KANNADA
+	macLangID_TAMIL
+	macLangID_TELUGU
+	macLangID_SINHALESE
+	macLangID_BURMESE
+	macLangID_KHMER
+	macLangID_LAO
+	macLangID_VIETNAMESE
+	macLangID_INDONESIAN
+	macLangID_TAGALOG
+	macLangID_MALAY_ROMAN_SCRIPT
+	macLangID_MALAY_ARABIC_SCRIPT
+	macLangID_AMHARIC
+	macLangID_TIGRINYA
+	macLangID_GALLA
+	macLangID_SOMALI
+	macLangID_SWAHILI
+	macLangID_RUANDA
+	macLangID_RUNDI
+	macLangID_CHEWA
+	macLangID_MALAGASY
+	macLangID_ESPERANTO
+)
+
+const (
+	macLangID_WELSH = 128 + iota
+	macLangID_BASQUE
+	macLangID_CATALAN
+	macLangID_LATIN
+	macLangID_QUECHUA
+	macLangID_GUARANI
+	macLangID_AYMARA
+	macLangID_TATAR
+	macLangID_UIGHUR
+	macLangID_DZONGKHA
+	macLangID_JAVANESE
+	macLangID_SUNDANESE
+
+	/* The following codes are new as of 2000-03-10 */
+	macLangID_GALICIAN
+	macLangID_AFRIKAANS
+	macLangID_BRETON
+	macLangID_INUKTITUT
+	macLangID_SCOTTISH_GAELIC
+	macLangID_MANX_GAELIC
+	macLangID_IRISH_GAELIC
+	macLangID_TONGAN
+	macLangID_GREEK_POLYTONIC
+	macLangID_GREELANDIC
+	macLangID_AZERBAIJANI_ROMAN_SCRIPT
+)
+
+const (
+	msLangID_ARABIC_GENERAL                  = 0x0001
+	msLangID_CHINESE_GENERAL                 = 0x0004
+	msLangID_ENGLISH_GENERAL                 = 0x0009
+	msLangID_FRENCH_WEST_INDIES              = 0x1C0C
+	msLangID_FRENCH_REUNION                  = 0x200C
+	msLangID_FRENCH_CONGO                    = 0x240C
+	msLangID_FRENCH_SENEGAL                  = 0x280C
+	msLangID_FRENCH_CAMEROON                 = 0x2C0C
+	msLangID_FRENCH_COTE_D_IVOIRE            = 0x300C
+	msLangID_FRENCH_MALI                     = 0x340C
+	msLangID_ARABIC_SAUDI_ARABIA             = 0x0401
+	msLangID_ARABIC_IRAQ                     = 0x0801
+	msLangID_ARABIC_EGYPT                    = 0x0C01
+	msLangID_ARABIC_LIBYA                    = 0x1001
+	msLangID_ARABIC_ALGERIA                  = 0x1401
+	msLangID_ARABIC_MOROCCO                  = 0x1801
+	msLangID_ARABIC_TUNISIA                  = 0x1C01
+	msLangID_ARABIC_OMAN                     = 0x2001
+	msLangID_ARABIC_YEMEN                    = 0x2401
+	msLangID_ARABIC_SYRIA                    = 0x2801
+	msLangID_ARABIC_JORDAN                   = 0x2C01
+	msLangID_ARABIC_LEBANON                  = 0x3001
+	msLangID_ARABIC_KUWAIT                   = 0x3401
+	msLangID_ARABIC_UAE                      = 0x3801
+	msLangID_ARABIC_BAHRAIN                  = 0x3C01
+	msLangID_ARABIC_QATAR                    = 0x4001
+	msLangID_BULGARIAN_BULGARIA              = 0x0402
+	msLangID_CATALAN_CATALAN                 = 0x0403
+	msLangID_CHINESE_TAIWAN                  = 0x0404
+	msLangID_CHINESE_PRC                     = 0x0804
+	msLangID_CHINESE_HONG_KONG               = 0x0C04
+	msLangID_CHINESE_SINGAPORE               = 0x1004
+	msLangID_CHINESE_MACAO                   = 0x1404
+	msLangID_CZECH_CZECH_REPUBLIC            = 0x0405
+	msLangID_DANISH_DENMARK                  = 0x0406
+	msLangID_GERMAN_GERMANY                  = 0x0407
+	msLangID_GERMAN_SWITZERLAND              = 0x0807
+	msLangID_GERMAN_AUSTRIA                  = 0x0C07
+	msLangID_GERMAN_LUXEMBOURG               = 0x1007
+	msLangID_GERMAN_LIECHTENSTEIN            = 0x1407
+	msLangID_GREEK_GREECE                    = 0x0408
+	msLangID_ENGLISH_UNITED_STATES           = 0x0409
+	msLangID_ENGLISH_UNITED_KINGDOM          = 0x0809
+	msLangID_ENGLISH_AUSTRALIA               = 0x0C09
+	msLangID_ENGLISH_CANADA                  = 0x1009
+	msLangID_ENGLISH_NEW_ZEALAND             = 0x1409
+	msLangID_ENGLISH_IRELAND                 = 0x1809
+	msLangID_ENGLISH_SOUTH_AFRICA            = 0x1C09
+	msLangID_ENGLISH_JAMAICA                 = 0x2009
+	msLangID_ENGLISH_CARIBBEAN               = 0x2409
+	msLangID_ENGLISH_BELIZE                  = 0x2809
+	msLangID_ENGLISH_TRINIDAD                = 0x2C09
+	msLangID_ENGLISH_ZIMBABWE                = 0x3009
+	msLangID_ENGLISH_PHILIPPINES             = 0x3409
+	msLangID_ENGLISH_HONG_KONG               = 0x3C09
+	msLangID_ENGLISH_INDIA                   = 0x4009
+	msLangID_ENGLISH_MALAYSIA                = 0x4409
+	msLangID_ENGLISH_SINGAPORE               = 0x4809
+	msLangID_SPANISH_SPAIN_TRADITIONAL_SORT  = 0x040A
+	msLangID_SPANISH_MEXICO                  = 0x080A
+	msLangID_SPANISH_SPAIN_MODERN_SORT       = 0x0C0A
+	msLangID_SPANISH_GUATEMALA               = 0x100A
+	msLangID_SPANISH_COSTA_RICA              = 0x140A
+	msLangID_SPANISH_PANAMA                  = 0x180A
+	msLangID_SPANISH_DOMINICAN_REPUBLIC      = 0x1C0A
+	msLangID_SPANISH_VENEZUELA               = 0x200A
+	msLangID_SPANISH_COLOMBIA                = 0x240A
+	msLangID_SPANISH_PERU                    = 0x280A
+	msLangID_SPANISH_ARGENTINA               = 0x2C0A
+	msLangID_SPANISH_ECUADOR                 = 0x300A
+	msLangID_SPANISH_CHILE                   = 0x340A
+	msLangID_SPANISH_URUGUAY                 = 0x380A
+	msLangID_SPANISH_PARAGUAY                = 0x3C0A
+	msLangID_SPANISH_BOLIVIA                 = 0x400A
+	msLangID_SPANISH_EL_SALVADOR             = 0x440A
+	msLangID_SPANISH_HONDURAS                = 0x480A
+	msLangID_SPANISH_NICARAGUA               = 0x4C0A
+	msLangID_SPANISH_PUERTO_RICO             = 0x500A
+	msLangID_SPANISH_UNITED_STATES           = 0x540A
+	msLangID_SPANISH_LATIN_AMERICA           = 0xE40A
+	msLangID_FRENCH_NORTH_AFRICA             = 0xE40C
+	msLangID_FRENCH_MOROCCO                  = 0x380C
+	msLangID_FRENCH_HAITI                    = 0x3C0C
+	msLangID_FINNISH_FINLAND                 = 0x040B
+	msLangID_FRENCH_FRANCE                   = 0x040C
+	msLangID_FRENCH_BELGIUM                  = 0x080C
+	msLangID_FRENCH_CANADA                   = 0x0C0C
+	msLangID_FRENCH_SWITZERLAND              = 0x100C
+	msLangID_FRENCH_LUXEMBOURG               = 0x140C
+	msLangID_FRENCH_MONACO                   = 0x180C
+	msLangID_HEBREW_ISRAEL                   = 0x040D
+	msLangID_HUNGARIAN_HUNGARY               = 0x040E
+	msLangID_ICELANDIC_ICELAND               = 0x040F
+	msLangID_ITALIAN_ITALY                   = 0x0410
+	msLangID_ITALIAN_SWITZERLAND             = 0x0810
+	msLangID_JAPANESE_JAPAN                  = 0x0411
+	msLangID_KOREAN_KOREA                    = 0x0412
+	msLangID_KOREAN_JOHAB_KOREA              = 0x0812 // legacy
+	msLangID_DUTCH_NETHERLANDS               = 0x0413
+	msLangID_DUTCH_BELGIUM                   = 0x0813
+	msLangID_NORWEGIAN_NORWAY_BOKMAL         = 0x0414
+	msLangID_NORWEGIAN_NORWAY_NYNORSK        = 0x0814
+	msLangID_POLISH_POLAND                   = 0x0415
+	msLangID_PORTUGUESE_BRAZIL               = 0x0416
+	msLangID_PORTUGUESE_PORTUGAL             = 0x0816
+	msLangID_ROMANSH_SWITZERLAND             = 0x0417
+	msLangID_ROMANIAN_ROMANIA                = 0x0418
+	msLangID_MOLDAVIAN_MOLDAVIA              = 0x0818 // legacy
+	msLangID_RUSSIAN_MOLDAVIA                = 0x0819 // legacy
+	msLangID_RUSSIAN_RUSSIA                  = 0x0419
+	msLangID_CROATIAN_CROATIA                = 0x041A
+	msLangID_SERBIAN_SERBIA_LATIN            = 0x081A
+	msLangID_SERBIAN_SERBIA_CYRILLIC         = 0x0C1A
+	msLangID_CROATIAN_BOSNIA_HERZEGOVINA     = 0x101A
+	msLangID_BOSNIAN_BOSNIA_HERZEGOVINA      = 0x141A
+	msLangID_SERBIAN_BOSNIA_HERZ_LATIN       = 0x181A
+	msLangID_SERBIAN_BOSNIA_HERZ_CYRILLIC    = 0x1C1A
+	msLangID_BOSNIAN_BOSNIA_HERZ_CYRILLIC    = 0x201A
+	msLangID_URDU_INDIA                      = 0x0820
+	msLangID_SLOVAK_SLOVAKIA                 = 0x041B
+	msLangID_ALBANIAN_ALBANIA                = 0x041C
+	msLangID_SWEDISH_SWEDEN                  = 0x041D
+	msLangID_SWEDISH_FINLAND                 = 0x081D
+	msLangID_THAI_THAILAND                   = 0x041E
+	msLangID_TURKISH_TURKEY                  = 0x041F
+	msLangID_URDU_PAKISTAN                   = 0x0420
+	msLangID_INDONESIAN_INDONESIA            = 0x0421
+	msLangID_UKRAINIAN_UKRAINE               = 0x0422
+	msLangID_BELARUSIAN_BELARUS              = 0x0423
+	msLangID_SLOVENIAN_SLOVENIA              = 0x0424
+	msLangID_ESTONIAN_ESTONIA                = 0x0425
+	msLangID_LATVIAN_LATVIA                  = 0x0426
+	msLangID_LITHUANIAN_LITHUANIA            = 0x0427
+	msLangID_CLASSIC_LITHUANIAN_LITHUANIA    = 0x0827 // legacy
+	msLangID_TAJIK_TAJIKISTAN                = 0x0428
+	msLangID_YIDDISH_GERMANY                 = 0x043D
+	msLangID_VIETNAMESE_VIET_NAM             = 0x042A
+	msLangID_ARMENIAN_ARMENIA                = 0x042B
+	msLangID_AZERI_AZERBAIJAN_LATIN          = 0x042C
+	msLangID_AZERI_AZERBAIJAN_CYRILLIC       = 0x082C
+	msLangID_BASQUE_BASQUE                   = 0x042D
+	msLangID_UPPER_SORBIAN_GERMANY           = 0x042E
+	msLangID_LOWER_SORBIAN_GERMANY           = 0x082E
+	msLangID_MACEDONIAN_MACEDONIA            = 0x042F
+	msLangID_SUTU_SOUTH_AFRICA               = 0x0430
+	msLangID_TSONGA_SOUTH_AFRICA             = 0x0431
+	msLangID_SETSWANA_SOUTH_AFRICA           = 0x0432
+	msLangID_VENDA_SOUTH_AFRICA              = 0x0433
+	msLangID_ISIXHOSA_SOUTH_AFRICA           = 0x0434
+	msLangID_ISIZULU_SOUTH_AFRICA            = 0x0435
+	msLangID_AFRIKAANS_SOUTH_AFRICA          = 0x0436
+	msLangID_GEORGIAN_GEORGIA                = 0x0437
+	msLangID_FAEROESE_FAEROE_ISLANDS         = 0x0438
+	msLangID_HINDI_INDIA                     = 0x0439
+	msLangID_MALTESE_MALTA                   = 0x043A
+	msLangID_SAAMI_LAPONIA                   = 0x043B
+	msLangID_SAMI_NORTHERN_NORWAY            = 0x043B
+	msLangID_SAMI_NORTHERN_SWEDEN            = 0x083B
+	msLangID_SAMI_NORTHERN_FINLAND           = 0x0C3B
+	msLangID_SAMI_LULE_NORWAY                = 0x103B
+	msLangID_SAMI_LULE_SWEDEN                = 0x143B
+	msLangID_SAMI_SOUTHERN_NORWAY            = 0x183B
+	msLangID_SAMI_SOUTHERN_SWEDEN            = 0x1C3B
+	msLangID_SAMI_SKOLT_FINLAND              = 0x203B
+	msLangID_SAMI_INARI_FINLAND              = 0x243B
+	msLangID_IRISH_GAELIC_IRELAND            = 0x043C // legacy
+	msLangID_SCOTTISH_GAELIC_UNITED_KINGDOM  = 0x083C // legacy
+	msLangID_IRISH_IRELAND                   = 0x083C
+	msLangID_MALAY_MALAYSIA                  = 0x043E
+	msLangID_MALAY_BRUNEI_DARUSSALAM         = 0x083E
+	msLangID_KAZAKH_KAZAKHSTAN               = 0x043F
+	msLangID_KYRGYZ_KYRGYZSTAN               = /* Cyrillic*/ 0x0440
+	msLangID_KISWAHILI_KENYA                 = 0x0441
+	msLangID_TURKMEN_TURKMENISTAN            = 0x0442
+	msLangID_UZBEK_UZBEKISTAN_LATIN          = 0x0443
+	msLangID_UZBEK_UZBEKISTAN_CYRILLIC       = 0x0843
+	msLangID_TATAR_RUSSIA                    = 0x0444
+	msLangID_BENGALI_INDIA                   = 0x0445
+	msLangID_BENGALI_BANGLADESH              = 0x0845
+	msLangID_PUNJABI_INDIA                   = 0x0446
+	msLangID_PUNJABI_ARABIC_PAKISTAN         = 0x0846
+	msLangID_GUJARATI_INDIA                  = 0x0447
+	msLangID_ODIA_INDIA                      = 0x0448
+	msLangID_TAMIL_INDIA                     = 0x0449
+	msLangID_TELUGU_INDIA                    = 0x044A
+	msLangID_KANNADA_INDIA                   = 0x044B
+	msLangID_MALAYALAM_INDIA                 = 0x044C
+	msLangID_ASSAMESE_INDIA                  = 0x044D
+	msLangID_MARATHI_INDIA                   = 0x044E
+	msLangID_SANSKRIT_INDIA                  = 0x044F
+	msLangID_MONGOLIAN_MONGOLIA              = /* Cyrillic */ 0x0450
+	msLangID_MONGOLIAN_PRC                   = 0x0850
+	msLangID_TIBETAN_PRC                     = 0x0451
+	msLangID_DZONGHKA_BHUTAN                 = 0x0851
+	msLangID_WELSH_UNITED_KINGDOM            = 0x0452
+	msLangID_KHMER_CAMBODIA                  = 0x0453
+	msLangID_LAO_LAOS                        = 0x0454
+	msLangID_BURMESE_MYANMAR                 = 0x0455
+	msLangID_GALICIAN_GALICIAN               = 0x0456
+	msLangID_MANIPURI_INDIA                  = /* Bengali */ 0x0458
+	msLangID_SINDHI_INDIA                    = /* Arabic */ 0x0459
+	msLangID_KONKANI_INDIA                   = 0x0457
+	msLangID_KASHMIRI_PAKISTAN               = /* Arabic */ 0x0460
+	msLangID_KASHMIRI_SASIA                  = 0x0860
+	msLangID_SYRIAC_SYRIA                    = 0x045A
+	msLangID_SINHALA_SRI_LANKA               = 0x045B
+	msLangID_CHEROKEE_UNITED_STATES          = 0x045C
+	msLangID_INUKTITUT_CANADA                = 0x045D
+	msLangID_INUKTITUT_CANADA_LATIN          = 0x085D
+	msLangID_AMHARIC_ETHIOPIA                = 0x045E
+	msLangID_TAMAZIGHT_ALGERIA               = 0x085F
+	msLangID_NEPALI_NEPAL                    = 0x0461
+	msLangID_FRISIAN_NETHERLANDS             = 0x0462
+	msLangID_PASHTO_AFGHANISTAN              = 0x0463
+	msLangID_FILIPINO_PHILIPPINES            = 0x0464
+	msLangID_DHIVEHI_MALDIVES                = 0x0465
+	msLangID_OROMO_ETHIOPIA                  = 0x0472
+	msLangID_TIGRIGNA_ETHIOPIA               = 0x0473
+	msLangID_TIGRIGNA_ERYTHREA               = 0x0873
+	msLangID_HAUSA_NIGERIA                   = 0x0468
+	msLangID_YORUBA_NIGERIA                  = 0x046A
+	msLangID_QUECHUA_BOLIVIA                 = 0x046B
+	msLangID_QUECHUA_ECUADOR                 = 0x086B
+	msLangID_QUECHUA_PERU                    = 0x0C6B
+	msLangID_SESOTHO_SA_LEBOA_SOUTH_AFRICA   = 0x046C
+	msLangID_BASHKIR_RUSSIA                  = 0x046D
+	msLangID_LUXEMBOURGISH_LUXEMBOURG        = 0x046E
+	msLangID_GREENLANDIC_GREENLAND           = 0x046F
+	msLangID_IGBO_NIGERIA                    = 0x0470
+	msLangID_KANURI_NIGERIA                  = 0x0471
+	msLangID_GUARANI_PARAGUAY                = 0x0474
+	msLangID_HAWAIIAN_UNITED_STATES          = 0x0475
+	msLangID_LATIN                           = 0x0476
+	msLangID_SOMALI_SOMALIA                  = 0x0477
+	msLangID_YI_PRC                          = 0x0478
+	msLangID_MAPUDUNGUN_CHILE                = 0x047A
+	msLangID_MOHAWK_MOHAWK                   = 0x047C
+	msLangID_BRETON_FRANCE                   = 0x047E
+	msLangID_UIGHUR_PRC                      = 0x0480
+	msLangID_MAORI_NEW_ZEALAND               = 0x0481
+	msLangID_FARSI_IRAN                      = 0x0429
+	msLangID_OCCITAN_FRANCE                  = 0x0482
+	msLangID_CORSICAN_FRANCE                 = 0x0483
+	msLangID_ALSATIAN_FRANCE                 = 0x0484
+	msLangID_YAKUT_RUSSIA                    = 0x0485
+	msLangID_KICHE_GUATEMALA                 = 0x0486
+	msLangID_KINYARWANDA_RWANDA              = 0x0487
+	msLangID_WOLOF_SENEGAL                   = 0x0488
+	msLangID_DARI_AFGHANISTAN                = 0x048C
+	msLangID_PAPIAMENTU_NETHERLANDS_ANTILLES = 0x0479
+)
+
+var nameLanguages = [...]struct {
+	lang       string
+	PlatformID PlatformID
+	LanguageID PlatformLanguageID
+}{
+	{"", PlatformUnicode, lang_DONT_CARE},
+	{"en", PlatformMac, macLangID_ENGLISH},
+	{"fr", PlatformMac, macLangID_FRENCH},
+	{"de", PlatformMac, macLangID_GERMAN},
+	{"it", PlatformMac, macLangID_ITALIAN},
+	{"nl", PlatformMac, macLangID_DUTCH},
+	{"sv", PlatformMac, macLangID_SWEDISH},
+	{"es", PlatformMac, macLangID_SPANISH},
+	{"da", PlatformMac, macLangID_DANISH},
+	{"pt", PlatformMac, macLangID_PORTUGUESE},
+	{"no", PlatformMac, macLangID_NORWEGIAN},
+	{"he", PlatformMac, macLangID_HEBREW},
+	{"ja", PlatformMac, macLangID_JAPANESE},
+	{"ar", PlatformMac, macLangID_ARABIC},
+	{"fi", PlatformMac, macLangID_FINNISH},
+	{"el", PlatformMac, macLangID_GREEK},
+	{"is", PlatformMac, macLangID_ICELANDIC},
+	{"mt", PlatformMac, macLangID_MALTESE},
+	{"tr", PlatformMac, macLangID_TURKISH},
+	{"hr", PlatformMac, macLangID_CROATIAN},
+	{"zh-tw", PlatformMac, macLangID_CHINESE_TRADITIONAL},
+	{"ur", PlatformMac, macLangID_URDU},
+	{"hi", PlatformMac, macLangID_HINDI},
+	{"th", PlatformMac, macLangID_THAI},
+	{"ko", PlatformMac, macLangID_KOREAN},
+	{"lt", PlatformMac, macLangID_LITHUANIAN},
+	{"pl", PlatformMac, macLangID_POLISH},
+	{"hu", PlatformMac, macLangID_HUNGARIAN},
+	{"et", PlatformMac, macLangID_ESTONIAN},
+	{"lv", PlatformMac, macLangID_LETTISH},
+
+	{"fo", PlatformMac, macLangID_FAEROESE},
+	{"fa", PlatformMac, macLangID_FARSI},
+	{"ru", PlatformMac, macLangID_RUSSIAN},
+	{"zh-cn", PlatformMac, macLangID_CHINESE_SIMPLIFIED},
+	{"nl", PlatformMac, macLangID_FLEMISH},
+	{"ga", PlatformMac, macLangID_IRISH},
+	{"sq", PlatformMac, macLangID_ALBANIAN},
+	{"ro", PlatformMac, macLangID_ROMANIAN},
+	{"cs", PlatformMac, macLangID_CZECH},
+	{"sk", PlatformMac, macLangID_SLOVAK},
+	{"sl", PlatformMac, macLangID_SLOVENIAN},
+	{"yi", PlatformMac, macLangID_YIDDISH},
+	{"sr", PlatformMac, macLangID_SERBIAN},
+	{"mk", PlatformMac, macLangID_MACEDONIAN},
+	{"bg", PlatformMac, macLangID_BULGARIAN},
+	{"uk", PlatformMac, macLangID_UKRAINIAN},
+	{"be", PlatformMac, macLangID_BYELORUSSIAN},
+	{"uz", PlatformMac, macLangID_UZBEK},
+	{"kk", PlatformMac, macLangID_KAZAKH},
+	{"az", PlatformMac, macLangID_AZERBAIJANI_CYRILLIC_SCRIPT},
+	{"ar", PlatformMac, macLangID_AZERBAIJANI_ARABIC_SCRIPT},
+	{"hy", PlatformMac, macLangID_ARMENIAN},
+	{"ka", PlatformMac, macLangID_GEORGIAN},
+	{"mo", PlatformMac, macLangID_MOLDAVIAN},
+	{"ky", PlatformMac, macLangID_KIRGHIZ},
+	{"tg", PlatformMac, macLangID_TAJIKI},
+	{"tk", PlatformMac, macLangID_TURKMEN},
+	{"mn", PlatformMac, macLangID_MONGOLIAN},
+	{"mn", PlatformMac, macLangID_MONGOLIAN_CYRILLIC_SCRIPT},
+	{"ps", PlatformMac, macLangID_PASHTO},
+	{"ku", PlatformMac, macLangID_KURDISH},
+	{"ks", PlatformMac, macLangID_KASHMIRI},
+	{"sd", PlatformMac, macLangID_SINDHI},
+	{"bo", PlatformMac, macLangID_TIBETAN},
+	{"ne", PlatformMac, macLangID_NEPALI},
+	{"sa", PlatformMac, macLangID_SANSKRIT},
+	{"mr", PlatformMac, macLangID_MARATHI},
+	{"bn", PlatformMac, macLangID_BENGALI},
+	{"as", PlatformMac, macLangID_ASSAMESE},
+	{"gu", PlatformMac, macLangID_GUJARATI},
+	{"pa", PlatformMac, macLangID_PUNJABI},
+	{"or", PlatformMac, macLangID_ORIYA},
+	{"ml", PlatformMac, macLangID_MALAYALAM},
+	{"kn", PlatformMac, macLangID_KANNADA},
+	{"ta", PlatformMac, macLangID_TAMIL},
+	{"te", PlatformMac, macLangID_TELUGU},
+	{"si", PlatformMac, macLangID_SINHALESE},
+	{"my", PlatformMac, macLangID_BURMESE},
+	{"km", PlatformMac, macLangID_KHMER},
+	{"lo", PlatformMac, macLangID_LAO},
+	{"vi", PlatformMac, macLangID_VIETNAMESE},
+	{"id", PlatformMac, macLangID_INDONESIAN},
+	{"tl", PlatformMac, macLangID_TAGALOG},
+	{"ms", PlatformMac, macLangID_MALAY_ROMAN_SCRIPT},
+	{"ms", PlatformMac, macLangID_MALAY_ARABIC_SCRIPT},
+	{"am", PlatformMac, macLangID_AMHARIC},
+	{"ti", PlatformMac, macLangID_TIGRINYA},
+	{"om", PlatformMac, macLangID_GALLA},
+	{"so", PlatformMac, macLangID_SOMALI},
+	{"sw", PlatformMac, macLangID_SWAHILI},
+	{"rw", PlatformMac, macLangID_RUANDA},
+	{"rn", PlatformMac, macLangID_RUNDI},
+	{"ny", PlatformMac, macLangID_CHEWA},
+	{"mg", PlatformMac, macLangID_MALAGASY},
+	{"eo", PlatformMac, macLangID_ESPERANTO},
+	{"cy", PlatformMac, macLangID_WELSH},
+	{"eu", PlatformMac, macLangID_BASQUE},
+	{"ca", PlatformMac, macLangID_CATALAN},
+	{"la", PlatformMac, macLangID_LATIN},
+	{"qu", PlatformMac, macLangID_QUECHUA},
+	{"gn", PlatformMac, macLangID_GUARANI},
+	{"ay", PlatformMac, macLangID_AYMARA},
+	{"tt", PlatformMac, macLangID_TATAR},
+	{"ug", PlatformMac, macLangID_UIGHUR},
+	{"dz", PlatformMac, macLangID_DZONGKHA},
+	{"jw", PlatformMac, macLangID_JAVANESE},
+	{"su", PlatformMac, macLangID_SUNDANESE},
+
+	/* The following codes are new as of 2000-03-10 */
+	{"gl", PlatformMac, macLangID_GALICIAN},
+	{"af", PlatformMac, macLangID_AFRIKAANS},
+	{"br", PlatformMac, macLangID_BRETON},
+	{"iu", PlatformMac, macLangID_INUKTITUT},
+	{"gd", PlatformMac, macLangID_SCOTTISH_GAELIC},
+	{"gv", PlatformMac, macLangID_MANX_GAELIC},
+	{"ga", PlatformMac, macLangID_IRISH_GAELIC},
+	{"to", PlatformMac, macLangID_TONGAN},
+	{"el", PlatformMac, macLangID_GREEK_POLYTONIC},
+	{"ik", PlatformMac, macLangID_GREELANDIC},
+	{"az", PlatformMac, macLangID_AZERBAIJANI_ROMAN_SCRIPT},
+
+	{"ar", PlatformMicrosoft, msLangID_ARABIC_SAUDI_ARABIA},
+	{"ar", PlatformMicrosoft, msLangID_ARABIC_IRAQ},
+	{"ar", PlatformMicrosoft, msLangID_ARABIC_EGYPT},
+	{"ar", PlatformMicrosoft, msLangID_ARABIC_LIBYA},
+	{"ar", PlatformMicrosoft, msLangID_ARABIC_ALGERIA},
+	{"ar", PlatformMicrosoft, msLangID_ARABIC_MOROCCO},
+	{"ar", PlatformMicrosoft, msLangID_ARABIC_TUNISIA},
+	{"ar", PlatformMicrosoft, msLangID_ARABIC_OMAN},
+	{"ar", PlatformMicrosoft, msLangID_ARABIC_YEMEN},
+	{"ar", PlatformMicrosoft, msLangID_ARABIC_SYRIA},
+	{"ar", PlatformMicrosoft, msLangID_ARABIC_JORDAN},
+	{"ar", PlatformMicrosoft, msLangID_ARABIC_LEBANON},
+	{"ar", PlatformMicrosoft, msLangID_ARABIC_KUWAIT},
+	{"ar", PlatformMicrosoft, msLangID_ARABIC_UAE},
+	{"ar", PlatformMicrosoft, msLangID_ARABIC_BAHRAIN},
+	{"ar", PlatformMicrosoft, msLangID_ARABIC_QATAR},
+	{"bg", PlatformMicrosoft, msLangID_BULGARIAN_BULGARIA},
+	{"ca", PlatformMicrosoft, msLangID_CATALAN_CATALAN},
+	{"zh-tw", PlatformMicrosoft, msLangID_CHINESE_TAIWAN},
+	{"zh-cn", PlatformMicrosoft, msLangID_CHINESE_PRC},
+	{"zh-hk", PlatformMicrosoft, msLangID_CHINESE_HONG_KONG},
+	{"zh-sg", PlatformMicrosoft, msLangID_CHINESE_SINGAPORE},
+
+	{"zh-mo", PlatformMicrosoft, msLangID_CHINESE_MACAO},
+
+	{"cs", PlatformMicrosoft, msLangID_CZECH_CZECH_REPUBLIC},
+	{"da", PlatformMicrosoft, msLangID_DANISH_DENMARK},
+	{"de", PlatformMicrosoft, msLangID_GERMAN_GERMANY},
+	{"de", PlatformMicrosoft, msLangID_GERMAN_SWITZERLAND},
+	{"de", PlatformMicrosoft, msLangID_GERMAN_AUSTRIA},
+	{"de", PlatformMicrosoft, msLangID_GERMAN_LUXEMBOURG},
+	{"de", PlatformMicrosoft, msLangID_GERMAN_LIECHTENSTEIN},
+	{"el", PlatformMicrosoft, msLangID_GREEK_GREECE},
+	{"en", PlatformMicrosoft, msLangID_ENGLISH_UNITED_STATES},
+	{"en", PlatformMicrosoft, msLangID_ENGLISH_UNITED_KINGDOM},
+	{"en", PlatformMicrosoft, msLangID_ENGLISH_AUSTRALIA},
+	{"en", PlatformMicrosoft, msLangID_ENGLISH_CANADA},
+	{"en", PlatformMicrosoft, msLangID_ENGLISH_NEW_ZEALAND},
+	{"en", PlatformMicrosoft, msLangID_ENGLISH_IRELAND},
+	{"en", PlatformMicrosoft, msLangID_ENGLISH_SOUTH_AFRICA},
+	{"en", PlatformMicrosoft, msLangID_ENGLISH_JAMAICA},
+	{"en", PlatformMicrosoft, msLangID_ENGLISH_CARIBBEAN},
+	{"en", PlatformMicrosoft, msLangID_ENGLISH_BELIZE},
+	{"en", PlatformMicrosoft, msLangID_ENGLISH_TRINIDAD},
+	{"en", PlatformMicrosoft, msLangID_ENGLISH_ZIMBABWE},
+	{"en", PlatformMicrosoft, msLangID_ENGLISH_PHILIPPINES},
+	{"es", PlatformMicrosoft, msLangID_SPANISH_SPAIN_TRADITIONAL_SORT},
+	{"es", PlatformMicrosoft, msLangID_SPANISH_MEXICO},
+	{"es", PlatformMicrosoft, msLangID_SPANISH_SPAIN_MODERN_SORT},
+	{"es", PlatformMicrosoft, msLangID_SPANISH_GUATEMALA},
+	{"es", PlatformMicrosoft, msLangID_SPANISH_COSTA_RICA},
+	{"es", PlatformMicrosoft, msLangID_SPANISH_PANAMA},
+	{"es", PlatformMicrosoft, msLangID_SPANISH_DOMINICAN_REPUBLIC},
+	{"es", PlatformMicrosoft, msLangID_SPANISH_VENEZUELA},
+	{"es", PlatformMicrosoft, msLangID_SPANISH_COLOMBIA},
+	{"es", PlatformMicrosoft, msLangID_SPANISH_PERU},
+	{"es", PlatformMicrosoft, msLangID_SPANISH_ARGENTINA},
+	{"es", PlatformMicrosoft, msLangID_SPANISH_ECUADOR},
+	{"es", PlatformMicrosoft, msLangID_SPANISH_CHILE},
+	{"es", PlatformMicrosoft, msLangID_SPANISH_URUGUAY},
+	{"es", PlatformMicrosoft, msLangID_SPANISH_PARAGUAY},
+	{"es", PlatformMicrosoft, msLangID_SPANISH_BOLIVIA},
+	{"es", PlatformMicrosoft, msLangID_SPANISH_EL_SALVADOR},
+	{"es", PlatformMicrosoft, msLangID_SPANISH_HONDURAS},
+	{"es", PlatformMicrosoft, msLangID_SPANISH_NICARAGUA},
+	{"es", PlatformMicrosoft, msLangID_SPANISH_PUERTO_RICO},
+	{"fi", PlatformMicrosoft, msLangID_FINNISH_FINLAND},
+	{"fr", PlatformMicrosoft, msLangID_FRENCH_FRANCE},
+	{"fr", PlatformMicrosoft, msLangID_FRENCH_BELGIUM},
+	{"fr", PlatformMicrosoft, msLangID_FRENCH_CANADA},
+	{"fr", PlatformMicrosoft, msLangID_FRENCH_SWITZERLAND},
+	{"fr", PlatformMicrosoft, msLangID_FRENCH_LUXEMBOURG},
+	{"fr", PlatformMicrosoft, msLangID_FRENCH_MONACO},
+	{"he", PlatformMicrosoft, msLangID_HEBREW_ISRAEL},
+	{"hu", PlatformMicrosoft, msLangID_HUNGARIAN_HUNGARY},
+	{"is", PlatformMicrosoft, msLangID_ICELANDIC_ICELAND},
+	{"it", PlatformMicrosoft, msLangID_ITALIAN_ITALY},
+	{"it", PlatformMicrosoft, msLangID_ITALIAN_SWITZERLAND},
+	{"ja", PlatformMicrosoft, msLangID_JAPANESE_JAPAN},
+	{"ko", PlatformMicrosoft, msLangID_KOREAN_KOREA},
+	{"ko", PlatformMicrosoft, msLangID_KOREAN_JOHAB_KOREA},
+	{"nl", PlatformMicrosoft, msLangID_DUTCH_NETHERLANDS},
+	{"nl", PlatformMicrosoft, msLangID_DUTCH_BELGIUM},
+	{"no", PlatformMicrosoft, msLangID_NORWEGIAN_NORWAY_BOKMAL},
+	{"nn", PlatformMicrosoft, msLangID_NORWEGIAN_NORWAY_NYNORSK},
+	{"pl", PlatformMicrosoft, msLangID_POLISH_POLAND},
+	{"pt", PlatformMicrosoft, msLangID_PORTUGUESE_BRAZIL},
+	{"pt", PlatformMicrosoft, msLangID_PORTUGUESE_PORTUGAL},
+	{"rm", PlatformMicrosoft, msLangID_ROMANSH_SWITZERLAND},
+	{"ro", PlatformMicrosoft, msLangID_ROMANIAN_ROMANIA},
+	{"mo", PlatformMicrosoft, msLangID_MOLDAVIAN_MOLDAVIA},
+	{"ru", PlatformMicrosoft, msLangID_RUSSIAN_RUSSIA},
+	{"ru", PlatformMicrosoft, msLangID_RUSSIAN_MOLDAVIA},
+	{"hr", PlatformMicrosoft, msLangID_CROATIAN_CROATIA},
+	{"sr", PlatformMicrosoft, msLangID_SERBIAN_SERBIA_LATIN},
+	{"sr", PlatformMicrosoft, msLangID_SERBIAN_SERBIA_CYRILLIC},
+	{"sk", PlatformMicrosoft, msLangID_SLOVAK_SLOVAKIA},
+	{"sq", PlatformMicrosoft, msLangID_ALBANIAN_ALBANIA},
+	{"sv", PlatformMicrosoft, msLangID_SWEDISH_SWEDEN},
+	{"sv", PlatformMicrosoft, msLangID_SWEDISH_FINLAND},
+	{"th", PlatformMicrosoft, msLangID_THAI_THAILAND},
+	{"tr", PlatformMicrosoft, msLangID_TURKISH_TURKEY},
+	{"ur", PlatformMicrosoft, msLangID_URDU_PAKISTAN},
+	{"id", PlatformMicrosoft, msLangID_INDONESIAN_INDONESIA},
+	{"uk", PlatformMicrosoft, msLangID_UKRAINIAN_UKRAINE},
+	{"be", PlatformMicrosoft, msLangID_BELARUSIAN_BELARUS},
+	{"sl", PlatformMicrosoft, msLangID_SLOVENIAN_SLOVENIA},
+	{"et", PlatformMicrosoft, msLangID_ESTONIAN_ESTONIA},
+	{"lv", PlatformMicrosoft, msLangID_LATVIAN_LATVIA},
+	{"lt", PlatformMicrosoft, msLangID_LITHUANIAN_LITHUANIA},
+	{"lt", PlatformMicrosoft, msLangID_CLASSIC_LITHUANIAN_LITHUANIA},
+	{"mi", PlatformMicrosoft, msLangID_MAORI_NEW_ZEALAND},
+	{"fa", PlatformMicrosoft, msLangID_FARSI_IRAN},
+	{"vi", PlatformMicrosoft, msLangID_VIETNAMESE_VIET_NAM},
+	{"hy", PlatformMicrosoft, msLangID_ARMENIAN_ARMENIA},
+	{"az", PlatformMicrosoft, msLangID_AZERI_AZERBAIJAN_LATIN},
+	{"az", PlatformMicrosoft, msLangID_AZERI_AZERBAIJAN_CYRILLIC},
+	{"eu", PlatformMicrosoft, msLangID_BASQUE_BASQUE},
+	{"wen", PlatformMicrosoft, msLangID_UPPER_SORBIAN_GERMANY},
+	{"mk", PlatformMicrosoft, msLangID_MACEDONIAN_MACEDONIA},
+	{"st", PlatformMicrosoft, msLangID_SUTU_SOUTH_AFRICA},
+	{"ts", PlatformMicrosoft, msLangID_TSONGA_SOUTH_AFRICA},
+	{"tn", PlatformMicrosoft, msLangID_SETSWANA_SOUTH_AFRICA},
+	{"ven", PlatformMicrosoft, msLangID_VENDA_SOUTH_AFRICA},
+	{"xh", PlatformMicrosoft, msLangID_ISIXHOSA_SOUTH_AFRICA},
+	{"zu", PlatformMicrosoft, msLangID_ISIZULU_SOUTH_AFRICA},
+	{"af", PlatformMicrosoft, msLangID_AFRIKAANS_SOUTH_AFRICA},
+	{"ka", PlatformMicrosoft, msLangID_GEORGIAN_GEORGIA},
+	{"fo", PlatformMicrosoft, msLangID_FAEROESE_FAEROE_ISLANDS},
+	{"hi", PlatformMicrosoft, msLangID_HINDI_INDIA},
+	{"mt", PlatformMicrosoft, msLangID_MALTESE_MALTA},
+	{"se", PlatformMicrosoft, msLangID_SAAMI_LAPONIA},
+
+	{"gd", PlatformMicrosoft, msLangID_SCOTTISH_GAELIC_UNITED_KINGDOM},
+	{"ga", PlatformMicrosoft, msLangID_IRISH_GAELIC_IRELAND},
+
+	{"ms", PlatformMicrosoft, msLangID_MALAY_MALAYSIA},
+	{"ms", PlatformMicrosoft, msLangID_MALAY_BRUNEI_DARUSSALAM},
+	{"kk", PlatformMicrosoft, msLangID_KAZAKH_KAZAKHSTAN},
+	{"sw", PlatformMicrosoft, msLangID_KISWAHILI_KENYA},
+	{"uz", PlatformMicrosoft, msLangID_UZBEK_UZBEKISTAN_LATIN},
+	{"uz", PlatformMicrosoft, msLangID_UZBEK_UZBEKISTAN_CYRILLIC},
+	{"tt", PlatformMicrosoft, msLangID_TATAR_RUSSIA},
+	{"bn", PlatformMicrosoft, msLangID_BENGALI_INDIA},
+	{"pa", PlatformMicrosoft, msLangID_PUNJABI_INDIA},
+	{"gu", PlatformMicrosoft, msLangID_GUJARATI_INDIA},
+	{"or", PlatformMicrosoft, msLangID_ODIA_INDIA},
+	{"ta", PlatformMicrosoft, msLangID_TAMIL_INDIA},
+	{"te", PlatformMicrosoft, msLangID_TELUGU_INDIA},
+	{"kn", PlatformMicrosoft, msLangID_KANNADA_INDIA},
+	{"ml", PlatformMicrosoft, msLangID_MALAYALAM_INDIA},
+	{"as", PlatformMicrosoft, msLangID_ASSAMESE_INDIA},
+	{"mr", PlatformMicrosoft, msLangID_MARATHI_INDIA},
+	{"sa", PlatformMicrosoft, msLangID_SANSKRIT_INDIA},
+	{"kok", PlatformMicrosoft, msLangID_KONKANI_INDIA},
+
+	/* new as of 2001-01-01 */
+	{"ar", PlatformMicrosoft, msLangID_ARABIC_GENERAL},
+	{"zh", PlatformMicrosoft, msLangID_CHINESE_GENERAL},
+	{"en", PlatformMicrosoft, msLangID_ENGLISH_GENERAL},
+	{"fr", PlatformMicrosoft, msLangID_FRENCH_WEST_INDIES},
+	{"fr", PlatformMicrosoft, msLangID_FRENCH_REUNION},
+	{"fr", PlatformMicrosoft, msLangID_FRENCH_CONGO},
+
+	{"fr", PlatformMicrosoft, msLangID_FRENCH_SENEGAL},
+	{"fr", PlatformMicrosoft, msLangID_FRENCH_CAMEROON},
+	{"fr", PlatformMicrosoft, msLangID_FRENCH_COTE_D_IVOIRE},
+	{"fr", PlatformMicrosoft, msLangID_FRENCH_MALI},
+	{"bs", PlatformMicrosoft, msLangID_BOSNIAN_BOSNIA_HERZEGOVINA},
+	{"ur", PlatformMicrosoft, msLangID_URDU_INDIA},
+	{"tg", PlatformMicrosoft, msLangID_TAJIK_TAJIKISTAN},
+	{"yi", PlatformMicrosoft, msLangID_YIDDISH_GERMANY},
+	{"ky", PlatformMicrosoft, msLangID_KYRGYZ_KYRGYZSTAN},
+
+	{"tk", PlatformMicrosoft, msLangID_TURKMEN_TURKMENISTAN},
+	{"mn", PlatformMicrosoft, msLangID_MONGOLIAN_MONGOLIA},
+
+	// the following seems to be inconsistent;   here is the current "official" way:
+	{"bo", PlatformMicrosoft, msLangID_DZONGHKA_BHUTAN},
+	/* and here is what is used by Passport SDK */
+	{"bo", PlatformMicrosoft, msLangID_TIBETAN_PRC},
+	{"dz", PlatformMicrosoft, msLangID_DZONGHKA_BHUTAN},
+	/* end of inconsistency */
+
+	{"cy", PlatformMicrosoft, msLangID_WELSH_UNITED_KINGDOM},
+	{"km", PlatformMicrosoft, msLangID_KHMER_CAMBODIA},
+	{"lo", PlatformMicrosoft, msLangID_LAO_LAOS},
+	{"my", PlatformMicrosoft, msLangID_BURMESE_MYANMAR},
+	{"gl", PlatformMicrosoft, msLangID_GALICIAN_GALICIAN},
+	{"mni", PlatformMicrosoft, msLangID_MANIPURI_INDIA},
+	{"sd", PlatformMicrosoft, msLangID_SINDHI_INDIA},
+	// the following one is only encountered in Microsoft RTF specification
+	{"ks", PlatformMicrosoft, msLangID_KASHMIRI_PAKISTAN},
+	// the following one is not in the Passport list, looks like an omission
+	{"ks", PlatformMicrosoft, msLangID_KASHMIRI_SASIA},
+	{"ne", PlatformMicrosoft, msLangID_NEPALI_NEPAL},
+	// "ne",{PlatformMicrosoft, msLangID_NEPALI_INDIA,},
+	{"fy", PlatformMicrosoft, msLangID_FRISIAN_NETHERLANDS},
+
+	// new as of 2001-03-01 (from Office Xp)
+	{"en", PlatformMicrosoft, msLangID_ENGLISH_HONG_KONG},
+	{"en", PlatformMicrosoft, msLangID_ENGLISH_INDIA},
+	{"en", PlatformMicrosoft, msLangID_ENGLISH_MALAYSIA},
+	{"en", PlatformMicrosoft, msLangID_ENGLISH_SINGAPORE},
+	{"syr", PlatformMicrosoft, msLangID_SYRIAC_SYRIA},
+	{"si", PlatformMicrosoft, msLangID_SINHALA_SRI_LANKA},
+	{"chr", PlatformMicrosoft, msLangID_CHEROKEE_UNITED_STATES},
+	{"iu", PlatformMicrosoft, msLangID_INUKTITUT_CANADA},
+	{"am", PlatformMicrosoft, msLangID_AMHARIC_ETHIOPIA},
+
+	{"ps", PlatformMicrosoft, msLangID_PASHTO_AFGHANISTAN},
+	{"phi", PlatformMicrosoft, msLangID_FILIPINO_PHILIPPINES},
+	{"div", PlatformMicrosoft, msLangID_DHIVEHI_MALDIVES},
+
+	{"om", PlatformMicrosoft, msLangID_OROMO_ETHIOPIA},
+	{"ti", PlatformMicrosoft, msLangID_TIGRIGNA_ETHIOPIA},
+	{"ti", PlatformMicrosoft, msLangID_TIGRIGNA_ERYTHREA},
+
+	/* New additions from Windows Xp/Passport SDK 2001-11-10. */
+
+	{"es", PlatformMicrosoft, msLangID_SPANISH_UNITED_STATES},
+	// The following two IDs blatantly violate MS specs by using a sublanguage >,.                                         */
+	{"es", PlatformMicrosoft, msLangID_SPANISH_LATIN_AMERICA},
+	{"fr", PlatformMicrosoft, msLangID_FRENCH_NORTH_AFRICA},
+
+	{"fr", PlatformMicrosoft, msLangID_FRENCH_MOROCCO},
+	{"fr", PlatformMicrosoft, msLangID_FRENCH_HAITI},
+	{"bn", PlatformMicrosoft, msLangID_BENGALI_BANGLADESH},
+	{"ar", PlatformMicrosoft, msLangID_PUNJABI_ARABIC_PAKISTAN},
+	{"mn", PlatformMicrosoft, msLangID_MONGOLIAN_PRC},
+	{"ha", PlatformMicrosoft, msLangID_HAUSA_NIGERIA},
+	{"yo", PlatformMicrosoft, msLangID_YORUBA_NIGERIA},
+	/* language codes from, to, are (still) unknown. */
+	{"ibo", PlatformMicrosoft, msLangID_IGBO_NIGERIA},
+	{"kau", PlatformMicrosoft, msLangID_KANURI_NIGERIA},
+	{"gn", PlatformMicrosoft, msLangID_GUARANI_PARAGUAY},
+	{"haw", PlatformMicrosoft, msLangID_HAWAIIAN_UNITED_STATES},
+	{"la", PlatformMicrosoft, msLangID_LATIN},
+	{"so", PlatformMicrosoft, msLangID_SOMALI_SOMALIA},
+
+	/* Note: Yi does not have a (proper) ISO 639-2 code, since it is mostly */
+	/*       not written (but OTOH the peculiar writing system is worth     */
+	/*       studying).                                                     */
+	//   {  PlatformMicrosoft,	msLangID_YI_CHINA },
+
+	{"pap", PlatformMicrosoft, msLangID_PAPIAMENTU_NETHERLANDS_ANTILLES},
+}
+
+// Language returns the language of the entry, as a lowercase
+// RFC 3066 tag like "en" or "zh-tw", or an empty string
+// for entries not tied to a language (or with an unknown language).
+func (n NameEntry) Language() string {
+	for _, entry := range nameLanguages {
+		if entry.PlatformID == n.PlatformID &&
+			(entry.LanguageID == lang_DONT_CARE || entry.LanguageID == n.LanguageID) {
+			return entry.lang
+		}
+	}
+	return ""
+}
@@ -0,0 +1,42 @@
+package truetype
+
+import "testing"
+
+func TestNameLanguage(t *testing.T) {
+	utf16 := func(s string) []byte {
+		var out []byte
+		for _, r := range s {
+			out = append(out, byte(r>>8), byte(r))
+		}
+		return out
+	}
+	names := TableName{
+		{Value: utf16("Single-storey a"), PlatformID: PlatformMicrosoft, EncodingID: PEMicrosoftUnicodeCs, LanguageID: 0x0409, NameID: 256},
+		{Value: utf16("a simple"), PlatformID: PlatformMicrosoft, EncodingID: PEMicrosoftUnicodeCs, LanguageID: 0x040C, NameID: 256},
+		{Value: utf16("a simple (Canada)"), PlatformID: PlatformMicrosoft, EncodingID: PEMicrosoftUnicodeCs, LanguageID: 0x0C0C, NameID: 256},
+		{Value: []byte("Mac"), PlatformID: PlatformMac, EncodingID: PEMacRoman, LanguageID: PLMacEnglish, NameID: 257},
+	}
+	for i, exp := range []string{"en", "fr", "fr", "en"} {
+		if got := names[i].Language(); got != exp {
+			t.Fatalf("entry %d: expected language %s, got %s", i, exp, got)
+		}
+	}
+
+	for _, test := range []struct {
+		lang     string
+		expected string
+	}{
+		{"en", "Single-storey a"},
+		{"fr", "a simple"},
+		{"fr-ch", "a simple"},
+		{"de", "Single-storey a"},
+	} {
+		entry := names.SelectEntryLanguage(256, test.lang)
+		if entry == nil || entry.String() != test.expected {
+			t.Fatalf("%s: expected %s, got %v", test.lang, test.expected, entry)
+		}
+	}
+	if entry := names.SelectEntryLanguage(258, "en"); entry != nil {
+		t.Fatalf("unexpected entry %v", entry)
+	}
+}
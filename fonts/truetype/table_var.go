@@ -132,14 +132,15 @@ func parseOneVarInstance(data []byte, axisCount uint16, withPs bool) VarInstance
 
 // -------------------------- avar table --------------------------
 
-// one segment map for each axis, in the order of axes specified in the 'fvar' table.
-type tableAvar [][]axisValueMap
+// TableAvar stores one segment map for each axis, in the order of axes specified in the 'fvar' table.
+type TableAvar [][]AxisValueMap
 
-type axisValueMap struct {
-	from, to float32 // found as int16 2.14 fixed point
+// AxisValueMap maps a normalized coordinate to its modified value.
+type AxisValueMap struct {
+	From, To float32 // found as int16 2.14 fixed point
 }
 
-func parseTableAvar(data []byte, axisCountRef int) (tableAvar, error) {
+func parseTableAvar(data []byte, axisCountRef int) (TableAvar, error) {
 	const avarHeaderSize = 2 * 4
 	if len(data) < avarHeaderSize {
 		return nil, errors.New("invalid 'avar' table (EOF)")
@@ -148,7 +149,7 @@ func parseTableAvar(data []byte, axisCountRef int) (tableAvar, error) {
 	// table.minorVersion = binary.BigEndian.Uint16(data[2:])
 	// reserved
 	axisCount := binary.BigEndian.Uint16(data[6:])
-	out := make([][]axisValueMap, axisCount) // guarded by 16-bit constraint
+	out := make([][]AxisValueMap, axisCount) // guarded by 16-bit constraint
 
 	if int(axisCount) != axisCountRef {
 		return nil, errors.New("invalid 'avar' table axis count")
@@ -166,7 +167,7 @@ func parseTableAvar(data []byte, axisCountRef int) (tableAvar, error) {
 }
 
 // data is at the start of the segment, return value at the start of the next
-func parseSegmentList(data []byte) ([]axisValueMap, []byte, error) {
+func parseSegmentList(data []byte) ([]AxisValueMap, []byte, error) {
 	const mapSize = 4
 	if len(data) < 2 {
 		return nil, nil, errors.New("invalid segment in 'avar' table")
@@ -176,10 +177,10 @@ func parseSegmentList(data []byte) ([]axisValueMap, []byte, error) {
 	if len(data) < 2+size {
 		return nil, nil, errors.New("invalid segment in 'avar' table")
 	}
-	out := make([]axisValueMap, count) // guarded by 16-bit constraint
+	out := make([]AxisValueMap, count) // guarded by 16-bit constraint
 	for i := range out {
-		out[i].from = fixed214ToFloat(binary.BigEndian.Uint16(data[2+i*mapSize:]))
-		out[i].to = fixed214ToFloat(binary.BigEndian.Uint16(data[2+i*mapSize+2:]))
+		out[i].From = fixed214ToFloat(binary.BigEndian.Uint16(data[2+i*mapSize:]))
+		out[i].To = fixed214ToFloat(binary.BigEndian.Uint16(data[2+i*mapSize+2:]))
 	}
 	data = data[2+size:]
 	return out, data, nil
@@ -201,6 +202,9 @@ type VariationStore struct {
 // GetDelta uses the variation store and the selected instance coordinates
 // to compute the value at `index`.
 func (store VariationStore) GetDelta(index VariationStoreIndex, coords []float32) float32 {
+	if len(coords) == 0 { // default instance
+		return 0
+	}
 	if int(index.DeltaSetOuter) >= len(store.Datas) {
 		return 0
 	}
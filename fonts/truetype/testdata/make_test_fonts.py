@@ -0,0 +1,150 @@
+"""Generates the test fonts built by adding tables to existing fonts.
+
+Usage (from this directory): python3 make_test_fonts.py
+
+	- ToyLcarOpbd.ttf is ToyTrak.ttf with an AAT 'lcar' and an 'opbd' table
+	- Roboto-JSTF.ttf is the Roboto-Regular.ttf font of the harfbuzz package,
+	with a 'JSTF' table
+"""
+
+import struct
+
+
+def u16(*v):
+    return struct.pack(">%dH" % len(v), *v)
+
+
+def i16(*v):
+    return struct.pack(">%dh" % len(v), *v)
+
+
+def add_tables(src, dst, new_tables):
+    """Copies the font `src` to `dst`, adding the tables in the `new_tables` dict."""
+    d = open(src, "rb").read()
+    n = struct.unpack(">H", d[4:6])[0]
+    tables = []
+    for i in range(n):
+        tag, cs, off, ln = struct.unpack(">4sIII", d[12 + 16 * i : 28 + 16 * i])
+        tables.append((tag, cs, d[off : off + ln]))
+    for tag, data in new_tables.items():
+        tables.append((tag, 0, data))  # checksums are not checked
+    tables.sort()
+    n = len(tables)
+    out = d[:4] + u16(n) + d[6:12]  # search params are not checked
+    off = 12 + 16 * n
+    dir_, data = b"", b""
+    for tag, cs, t in tables:
+        dir_ += tag + struct.pack(">III", cs, off + len(data), len(t))
+        data += t + b"\0" * ((4 - len(t) % 4) % 4)
+    open(dst, "wb").write(out + dir_ + data)
+
+
+def aat_lookup6(pairs):
+    """AAT lookup table in format 6, for the given sorted (glyph, value) pairs"""
+    out = u16(6, 4, len(pairs), 0, 0, 0)
+    for glyph, value in pairs:
+        out += u16(glyph, value)
+    return out
+
+
+def aat_offsets_table(entries):
+    """'lcar' or 'opbd' table (distance format), where `entries` maps
+    sorted glyphs to their data"""
+    header = 6 + len(aat_lookup6([(0, 0)] * len(entries)))
+    pairs, body = [], b""
+    for glyph, data in entries:
+        pairs.append((glyph, header + len(body)))
+        body += data
+    return u16(1, 0, 0) + aat_lookup6(pairs) + body
+
+
+def toy_lcar_opbd():
+    lcar = aat_offsets_table(
+        [
+            (4, u16(2) + i16(300, 600)),  # glyph 4: two carets
+            (5, u16(1) + i16(500)),  # glyph 5: one caret
+        ]
+    )
+    opbd = aat_offsets_table(
+        [
+            (2, i16(100, 0, 0, 0)),  # glyph 2: left, top, right, bottom
+            (3, i16(0, 0, 150, -40)),
+        ]
+    )
+    add_tables("ToyTrak.ttf", "ToyLcarOpbd.ttf", {b"lcar": lcar, b"opbd": opbd})
+
+
+def offsets_list(items, header):
+    """Returns the offsets (from the start of the list header of size `header`)
+    and the concatenated `items`"""
+    offsets, body = [], b""
+    for item in items:
+        offsets.append(header + len(body))
+        body += item
+    return offsets, body
+
+
+def jstf_modifications(indices):
+    return u16(len(indices), *indices)
+
+
+def jstf_max(lookups):
+    offsets, body = offsets_list(lookups, 2 + 2 * len(lookups))
+    return u16(len(lookups), *offsets) + body
+
+
+def jstf_priority(shrink, extend):
+    """`shrink` and `extend` are 5-tuples (enable GSUB, disable GSUB,
+    enable GPOS, disable GPOS, max lookups), with None for absent values"""
+    offsets, body = [], b""
+    for i, value in enumerate(list(shrink) + list(extend)):
+        if value is None:
+            offsets.append(0)
+            continue
+        data = jstf_max(value) if i % 5 == 4 else jstf_modifications(value)
+        offsets.append(20 + len(body))
+        body += data
+    return u16(*offsets) + body
+
+
+def jstf_lang_sys(priorities):
+    offsets, body = offsets_list(priorities, 2 + 2 * len(priorities))
+    return u16(len(priorities), *offsets) + body
+
+
+def jstf_script(extenders, default, langs):
+    header = 6 + 6 * len(langs)
+    body = u16(len(extenders), *extenders)
+    default_offset = header + len(body)
+    body += default
+    records = b""
+    for tag, lang_sys in langs:
+        records += tag + u16(header + len(body))
+        body += lang_sys
+    return u16(header, default_offset, len(langs)) + records + body
+
+
+def gpos_single(glyph, x_advance):
+    """GPOS lookup (type 1, format 1) adding `x_advance` to `glyph`"""
+    coverage = u16(1, 1, glyph)
+    subtable = u16(1, 8, 0x0004) + i16(x_advance) + coverage
+    return u16(1, 0, 1, 8) + subtable
+
+
+def roboto_jstf():
+    N = None
+    p0 = jstf_priority((N, N, N, N, N), (N, N, [0], N, [gpos_single(3, 200)]))
+    p1 = jstf_priority((N, N, N, [1], N), (N, N, N, [1], N))
+    p2 = jstf_priority((N, N, N, N, N), (N, [16, 17], N, N, N))
+    latn = jstf_script([3], jstf_lang_sys([p0, p1]), [(b"TRK ", jstf_lang_sys([p2]))])
+    jstf = u16(1, 0, 1) + b"latn" + u16(12) + latn
+    add_tables(
+        "../../../harfbuzz/testdata/perf_reference/fonts/Roboto-Regular.ttf",
+        "Roboto-JSTF.ttf",
+        {b"JSTF": jstf},
+    )
+
+
+if __name__ == "__main__":
+    toy_lcar_opbd()
+    roboto_jstf()
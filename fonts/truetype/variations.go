@@ -165,6 +165,10 @@ func (fvar *TableFvar) normalizeCoordinates(coords []float32) []float32 {
 
 func (f *Font) Variations() TableFvar { return f.fvar }
 
+// AvarTable returns the 'avar' table, used when normalizing
+// variation coordinates. It is nil if the font has no such table.
+func (f *Font) AvarTable() TableAvar { return f.avar }
+
 // Normalizes the given design-space coordinates. The minimum and maximum
 // values for the axis are mapped to the interval [-1,1], with the default
 // axis value mapped to 0.
@@ -182,10 +186,10 @@ func (f *Font) NormalizeVariations(coords []float32) []float32 {
 	for i, av := range f.avar {
 		for j := 1; j < len(av); j++ {
 			previous, pair := av[j-1], av[j]
-			if normalized[i] < pair.from {
+			if normalized[i] < pair.From {
 				normalized[i] =
-					previous.to + (normalized[i]-previous.from)*
-						(pair.to-previous.to)/(pair.from-previous.from)
+					previous.To + (normalized[i]-previous.From)*
+						(pair.To-previous.To)/(pair.From-previous.From)
 				break
 			}
 		}
@@ -31,3 +31,42 @@ func TestKernings(t *testing.T) {
 	}
 
 }
+
+func TestLoadAFMKerning(t *testing.T) {
+	f, err := os.Open("test/Times-Bold.afm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	afm, err := ParseAFMFile(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := os.Open("test/c0419bt_.pfb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+	font, err := Parse(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	A, _ := font.NominalGlyph('A')
+	V, _ := font.NominalGlyph('V')
+	if font.KernPair(A, V) != 0 {
+		t.Fatal("unexpected kerning before loading AFM")
+	}
+
+	font.LoadAFMKerning(afm)
+	if kern := font.KernPair(A, V); kern != -145 {
+		t.Fatalf("expected -145, got %d", kern)
+	}
+	if kern := font.KernPair(V, A); kern != -135 {
+		t.Fatalf("expected -135, got %d", kern)
+	}
+	if kern := font.KernPair(A, A); kern != 0 {
+		t.Fatalf("expected no kerning, got %d", kern)
+	}
+}
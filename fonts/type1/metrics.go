@@ -32,6 +32,10 @@ func (f *Font) Upem() uint16 {
 	return upemY
 }
 
+// NumGlyphs returns the number of glyphs in this font.
+// It is also the maximum glyph index + 1.
+func (f *Font) NumGlyphs() int { return len(f.charstrings) }
+
 func (f *Font) GlyphName(gid fonts.GID) string {
 	if int(gid) >= len(f.charstrings) {
 		return ""
@@ -45,11 +49,46 @@ func (f *Font) LineMetric(metric fonts.LineMetric) (float32, bool) {
 		return float32(f.PSInfo.UnderlinePosition), true
 	case fonts.UnderlineThickness:
 		return float32(f.PSInfo.UnderlineThickness), true
+	case fonts.XHeight:
+		return f.glyphHeight('x')
+	case fonts.CapHeight:
+		return f.glyphHeight('H')
+	case fonts.HorizontalCaretRise:
+		return float32(f.Upem()), true
+	case fonts.HorizontalCaretRun:
+		// ItalicAngle is expressed in degrees counterclockwise from the vertical
+		return float32(math.Round(-float64(f.Upem()) * math.Tan(float64(f.PSInfo.ItalicAngle)*math.Pi/180))), true
+	case fonts.HorizontalCaretOffset:
+		return 0, true
+	case fonts.HorizontalClippingAscent:
+		if len(f.FontBBox) < 4 {
+			return 0, false
+		}
+		return float32(f.FontBBox[3]), true
+	case fonts.HorizontalClippingDescent:
+		if len(f.FontBBox) < 4 {
+			return 0, false
+		}
+		return -float32(f.FontBBox[1]), true
 	default:
 		return 0, false
 	}
 }
 
+// glyphHeight returns the top of the glyph for `r`,
+// used for the x-height and the cap-height
+func (f *Font) glyphHeight(r rune) (float32, bool) {
+	gid, ok := f.cmap.Lookup(r)
+	if !ok {
+		return 0, false
+	}
+	bbox, _, err := f.parseGlyphMetrics(gid, false)
+	if err != nil {
+		return 0, false
+	}
+	return float32(bbox.Max.Y), true
+}
+
 func (f *Font) FontHExtents() (fonts.FontExtents, bool) {
 	var extents fonts.FontExtents
 	if len(f.FontBBox) < 4 {
@@ -119,3 +158,36 @@ func (f *Font) GlyphExtents(glyph fonts.GID, _, _ uint16) (fonts.GlyphExtents, b
 }
 
 func (Font) NormalizeVariations(coords []float32) []float32 { return coords }
+
+// LoadAFMKerning stores the kerning pairs of `afm`, typically parsed
+// from the .afm file associated with the font.
+// Glyphs are matched by names, and pairs referencing glyphs
+// not in the font are ignored.
+func (f *Font) LoadAFMKerning(afm AFMFont) {
+	gids := make(map[string]fonts.GID, len(f.charstrings))
+	for i, charstring := range f.charstrings {
+		gids[charstring.name] = fonts.GID(i)
+	}
+	// AFM metrics are expressed in 1/1000 of the em
+	upem := int(f.Upem())
+	f.kerns = make(map[[2]fonts.GID]int16)
+	for first, pairs := range afm.KernPairs {
+		left, ok := gids[first]
+		if !ok {
+			continue
+		}
+		for _, pair := range pairs {
+			right, ok := gids[pair.SndChar]
+			if !ok {
+				continue
+			}
+			f.kerns[[2]fonts.GID{left, right}] = int16(pair.KerningDistance * upem / 1000)
+		}
+	}
+}
+
+// KernPair returns the kerning between the two glyphs, in font units,
+// as loaded by `LoadAFMKerning`, or 0.
+func (f *Font) KernPair(left, right fonts.GID) int16 {
+	return f.kerns[[2]fonts.GID{left, right}]
+}
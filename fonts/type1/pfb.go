@@ -67,6 +67,8 @@ type Font struct {
 	charstrings []charstring // slice indexed by glyph index
 	FontMatrix  []Fl
 
+	kerns map[[2]fonts.GID]int16 // see LoadAFMKerning
+
 	fonts.PSInfo
 
 	StrokeWidth Fl
@@ -161,14 +163,10 @@ func (f *Font) checkAndSwapGlyphNotdef() {
 }
 
 // Type1 fonts have no natural notion of Unicode code points
-// We use a glyph names table to identify the most commonly used runes
+// We use a glyph names table to identify the most commonly used runes,
+// falling back to the builtin encoding for symbolic glyph names.
 func (f *Font) synthetizeCmap() {
-	f.cmap = make(map[rune]fonts.GID)
-	for gid, charstring := range f.charstrings {
-		glyphName := charstring.name
-		r, _ := glyphsnames.GlyphToRune(glyphName)
-		f.cmap[r] = fonts.GID(gid)
-	}
+	f.cmap = glyphsnames.SynthesizeCmap(f, (*[256]string)(f.Encoding))
 }
 
 // parseGlyphMetrics returns the advance of the glyph with index `index`
@@ -111,6 +111,22 @@ func TestMetrics(t *testing.T) {
 			t.Fatalf("unexpected underline position %f", p)
 		}
 
+		xHeight, ok1 := font.LineMetric(fonts.XHeight)
+		capHeight, ok2 := font.LineMetric(fonts.CapHeight)
+		if !ok1 || !ok2 || xHeight <= 0 || capHeight <= xHeight {
+			t.Fatalf("unexpected x-height and cap-height %f %f", xHeight, capHeight)
+		}
+		rise, _ := font.LineMetric(fonts.HorizontalCaretRise)
+		run, _ := font.LineMetric(fonts.HorizontalCaretRun)
+		if rise != 1000 || (run == 0) != (font.ItalicAngle == 0) {
+			t.Fatalf("unexpected caret slope %f/%f for italic angle %d", rise, run, font.ItalicAngle)
+		}
+		ascent, _ := font.LineMetric(fonts.HorizontalClippingAscent)
+		descent, _ := font.LineMetric(fonts.HorizontalClippingDescent)
+		if ascent < capHeight || descent < 0 {
+			t.Fatalf("unexpected clipping extents %f %f", ascent, descent)
+		}
+
 		ext, ok := font.FontHExtents()
 		if !ok {
 			t.Fatalf("missing font horizontal extents")
@@ -43,6 +43,8 @@ type Font struct {
 	// array of length 1 for non CIDFonts
 	// For CIDFonts, it can be safely indexed by `fdSelect` output
 	localSubrs [][][]byte
+	// same length as localSubrs
+	privateDicts []PrivateDict
 	fonts.PSInfo
 }
 
@@ -88,14 +90,10 @@ func parse(file fonts.Resource) ([]Font, error) {
 }
 
 // Type1 fonts have no natural notion of Unicode code points
-// We use a glyph names table to identify the most commonly used runes
+// We use a glyph names table to identify the most commonly used runes,
+// falling back to the builtin encoding for symbolic glyph names.
 func (f *Font) synthetizeCmap() {
-	f.cmap = make(map[rune]fonts.GID)
-	for gid := range f.charstrings {
-		glyphName := f.GlyphName(fonts.GID(gid))
-		r, _ := glyphsnames.GlyphToRune(glyphName)
-		f.cmap[r] = fonts.GID(gid)
-	}
+	f.cmap = glyphsnames.SynthesizeCmap(f, (*[256]string)(f.Encoding))
 }
 
 func (f *Font) Cmap() (fonts.Cmap, fonts.CmapEncoding) {
@@ -119,6 +117,44 @@ func (f *Font) PostscriptInfo() (fonts.PSInfo, bool) { return f.PSInfo, true }
 
 func (f *Font) PoscriptName() string { return f.PSInfo.FontName }
 
+// NameIndex returns the name of the font, as found in the Name INDEX.
+func (f *Font) NameIndex() string { return string(f.fontName) }
+
+// IsCIDFont returns true if the font is a CIDFont.
+func (f *Font) IsCIDFont() bool { return f.fdSelect != nil }
+
+// CIDFontName returns the name of the CIDFont, which is
+// empty for simple fonts.
+func (f *Font) CIDFontName() string { return f.cidFontName }
+
+// Charset returns the string identifiers (for simple fonts) or the
+// CIDs (for CIDFonts) of the glyphs, indexed by glyph ID.
+func (f *Font) Charset() []uint16 { return f.charset }
+
+// NumSubroutines returns the number of global subroutines, and the number
+// of local subroutines for each font dict (there is only one for simple fonts).
+func (f *Font) NumSubroutines() (global int, local []int) {
+	local = make([]int, len(f.localSubrs))
+	for i, subrs := range f.localSubrs {
+		local[i] = len(subrs)
+	}
+	return len(f.globalSubrs), local
+}
+
+// PrivateDicts returns the Private DICT of each font dict
+// (there is only one for simple fonts).
+func (f *Font) PrivateDicts() []PrivateDict { return f.privateDicts }
+
+// FontDictIndex returns the index of the font dict used by `glyph`,
+// which is always 0 for simple fonts.
+func (f *Font) FontDictIndex(glyph fonts.GID) (int, error) {
+	if f.fdSelect == nil {
+		return 0, nil
+	}
+	index, err := f.fdSelect.fontDictIndex(glyph)
+	return int(index), err
+}
+
 // Strip all subset prefixes of the form `ABCDEF+'.  Usually, there
 // is only one, but font names like `APCOOG+JFABTD+FuturaBQ-Bold'
 // have been seen in the wild.
@@ -0,0 +1,41 @@
+package type1c
+
+import (
+	"math"
+
+	"github.com/benoitkugler/textlayout/fonts"
+)
+
+// LineMetric returns the metrics supported by CFF fonts, expressed
+// in font units (assuming the usual 1000 units per em).
+// The x-height and the cap-height are taken from the height
+// of the glyphs for 'x' and 'H'.
+func (f *Font) LineMetric(metric fonts.LineMetric) (float32, bool) {
+	switch metric {
+	case fonts.UnderlinePosition:
+		return float32(f.PSInfo.UnderlinePosition), true
+	case fonts.UnderlineThickness:
+		return float32(f.PSInfo.UnderlineThickness), true
+	case fonts.XHeight:
+		return f.glyphHeight('x')
+	case fonts.CapHeight:
+		return f.glyphHeight('H')
+	case fonts.HorizontalCaretRise:
+		return 1000, true
+	case fonts.HorizontalCaretRun:
+		// ItalicAngle is expressed in degrees counterclockwise from the vertical
+		return float32(math.Round(-1000 * math.Tan(float64(f.PSInfo.ItalicAngle)*math.Pi/180))), true
+	case fonts.HorizontalCaretOffset:
+		return 0, true
+	}
+	return 0, false
+}
+
+func (f *Font) glyphHeight(r rune) (float32, bool) {
+	gid, ok := f.cmap.Lookup(r)
+	if !ok {
+		return 0, false
+	}
+	extents, ok := f.GetExtents(gid)
+	return extents.YBearing, ok
+}
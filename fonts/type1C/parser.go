@@ -160,11 +160,15 @@ func (p *cffParser) parse() ([]Font, error) {
 
 		if !topDict.isCIDFont {
 			// Parse the Private DICT, whose location was found in the Top DICT.
-			var localSubrs [][]byte
-			localSubrs, err = p.parsePrivateDICT(topDict.privateDictOffset, topDict.privateDictLength)
+			var (
+				priv       PrivateDict
+				localSubrs [][]byte
+			)
+			priv, localSubrs, err = p.parsePrivateDICT(topDict.privateDictOffset, topDict.privateDictLength)
 			if err != nil {
 				return nil, err
 			}
+			out[i].privateDicts = []PrivateDict{priv}
 			out[i].localSubrs = [][][]byte{localSubrs}
 		} else {
 			// Parse the Font Dict Select data, whose location was found in the Top
@@ -187,15 +191,19 @@ func (p *cffParser) parse() ([]Font, error) {
 				return nil, fmt.Errorf("invalid number of font dicts: %d (for %d)",
 					len(topDicts), indexExtent)
 			}
+			multiPrivates := make([]PrivateDict, len(topDicts))
 			multiSubrs := make([][][]byte, len(topDicts))
 			for i, topDict := range topDicts {
-				multiSubrs[i], err = p.parsePrivateDICT(topDict.privateDictOffset, topDict.privateDictLength)
+				multiPrivates[i], multiSubrs[i], err = p.parsePrivateDICT(topDict.privateDictOffset, topDict.privateDictLength)
 				if err != nil {
 					return nil, err
 				}
 			}
+			out[i].privateDicts = multiPrivates
 			out[i].localSubrs = multiSubrs
 		}
+
+		out[i].synthetizeCmap()
 	}
 
 	return out, nil
@@ -516,38 +524,36 @@ func (p *cffParser) parseFDSelect(offset int32, numGlyphs uint16) (fdSelect, err
 }
 
 // Parse Private DICT and the Local Subrs [Subroutines] INDEX
-func (p *cffParser) parsePrivateDICT(offset, length int32) ([][]byte, error) {
+func (p *cffParser) parsePrivateDICT(offset, length int32) (PrivateDict, [][]byte, error) {
+	priv := privateDict{PrivateDict: defaultPrivateDict()}
 	if length == 0 {
-		return nil, nil
+		return priv.PrivateDict, nil, nil
 	}
 	if err := p.seek(offset); err != nil {
-		return nil, err
+		return PrivateDict{}, nil, err
 	}
 	buf, err := p.read(int(length))
 	if err != nil {
-		return nil, err
+		return PrivateDict{}, nil, err
 	}
-	var (
-		psi  ps.Machine
-		priv privateDict
-	)
+	var psi ps.Machine
 	if err = psi.Run(buf, nil, nil, &priv); err != nil {
-		return nil, err
+		return PrivateDict{}, nil, err
 	}
 
 	if priv.subrsOffset == 0 {
-		return nil, nil
+		return priv.PrivateDict, nil, nil
 	}
 
 	// "The local subrs offset is relative to the beginning of the Private DICT data"
 	if err = p.seek(offset + priv.subrsOffset); err != nil {
-		return nil, errors.New("invalid local subroutines offset")
+		return PrivateDict{}, nil, errors.New("invalid local subroutines offset")
 	}
 	subrs, err := p.parseIndex()
 	if err != nil {
-		return nil, err
+		return PrivateDict{}, nil, err
 	}
-	return subrs, nil
+	return priv.PrivateDict, subrs, nil
 }
 
 // read returns the n bytes from p.offset and advances p.offset by n.
@@ -843,10 +849,48 @@ var topDictOperators = [2][]topDictOperator{
 	},
 }
 
+// PrivateDict stores the hinting and width values of a Private DICT.
+// The delta encoded arrays (like BlueValues) are decoded to absolute values.
+type PrivateDict struct {
+	BlueValues, OtherBlues        []int32
+	FamilyBlues, FamilyOtherBlues []int32
+	StemSnapH, StemSnapV          []int32
+	StdHW, StdVW                  int32
+	BlueScale                     float32
+	BlueShift, BlueFuzz           int32
+	ForceBold                     bool
+	LanguageGroup                 int32
+	ExpansionFactor               float32
+	InitialRandomSeed             int32
+	DefaultWidthX, NominalWidthX  int32
+}
+
+// defaultPrivateDict returns the default values defined by
+// 5176.CFF.pdf Table 23 "Private DICT Operators".
+func defaultPrivateDict() PrivateDict {
+	return PrivateDict{
+		BlueScale:       0.039625,
+		BlueShift:       7,
+		BlueFuzz:        1,
+		ExpansionFactor: 0.06,
+	}
+}
+
 // privateDict contains fields specific to the Private DICT context.
 type privateDict struct {
-	subrsOffset                  int32
-	defaultWidthX, nominalWidthX int32
+	PrivateDict
+	subrsOffset int32
+}
+
+// deltaArray decodes the delta encoded operands on the stack.
+func deltaArray(state *ps.Machine) []int32 {
+	out := make([]int32, state.ArgStack.Top)
+	var v int32
+	for i, delta := range state.ArgStack.Vals[:state.ArgStack.Top] {
+		v += delta
+		out[i] = v
+	}
+	return out
 }
 
 func (privateDict) Context() ps.PsContext { return ps.PrivateDict }
@@ -856,21 +900,41 @@ func (privateDict) Context() ps.PsContext { return ps.PrivateDict }
 func (priv *privateDict) Apply(op ps.PsOperator, state *ps.Machine) error {
 	if !op.IsEscaped { // 1-byte operators.
 		switch op.Operator {
-		case 6, 7, 8, 9: // "BlueValues" "OtherBlues" "FamilyBlues" "FamilyOtherBlues"
+		case 6: // "BlueValues"
+			priv.BlueValues = deltaArray(state)
+			return state.ArgStack.PopN(-2)
+		case 7: // "OtherBlues"
+			priv.OtherBlues = deltaArray(state)
+			return state.ArgStack.PopN(-2)
+		case 8: // "FamilyBlues"
+			priv.FamilyBlues = deltaArray(state)
 			return state.ArgStack.PopN(-2)
-		case 10, 11: // "StdHW" "StdVW"
+		case 9: // "FamilyOtherBlues"
+			priv.FamilyOtherBlues = deltaArray(state)
+			return state.ArgStack.PopN(-2)
+		case 10: // "StdHW"
+			if state.ArgStack.Top < 1 {
+				return errors.New("invalid stack size for 'StdHW' in private Dict charstring")
+			}
+			priv.StdHW = state.ArgStack.Vals[state.ArgStack.Top-1]
+			return state.ArgStack.PopN(1)
+		case 11: // "StdVW"
+			if state.ArgStack.Top < 1 {
+				return errors.New("invalid stack size for 'StdVW' in private Dict charstring")
+			}
+			priv.StdVW = state.ArgStack.Vals[state.ArgStack.Top-1]
 			return state.ArgStack.PopN(1)
 		case 20: // "defaultWidthX"
 			if state.ArgStack.Top < 1 {
 				return errors.New("invalid stack size for 'defaultWidthX' in private Dict charstring")
 			}
-			priv.defaultWidthX = state.ArgStack.Vals[state.ArgStack.Top-1]
+			priv.DefaultWidthX = state.ArgStack.Vals[state.ArgStack.Top-1]
 			return state.ArgStack.PopN(1)
 		case 21: // "nominalWidthX"
 			if state.ArgStack.Top < 1 {
 				return errors.New("invalid stack size for 'nominalWidthX' in private Dict charstring")
 			}
-			priv.nominalWidthX = state.ArgStack.Vals[state.ArgStack.Top-1]
+			priv.NominalWidthX = state.ArgStack.Vals[state.ArgStack.Top-1]
 			return state.ArgStack.PopN(1)
 		case 19: // "Subrs" pop 1
 			if state.ArgStack.Top < 1 {
@@ -882,8 +946,31 @@ func (priv *privateDict) Apply(op ps.PsOperator, state *ps.Machine) error {
 	} else { // 2-byte operators. The first byte is the escape byte.
 		switch op.Operator {
 		case 9, 10, 11, 14, 17, 18, 19: // "BlueScale" "BlueShift" "BlueFuzz" "ForceBold" "LanguageGroup" "ExpansionFactor" "initialRandomSeed"
+			if state.ArgStack.Top < 1 {
+				return errors.New("invalid stack size in private Dict charstring")
+			}
+			switch value := state.ArgStack.Vals[state.ArgStack.Top-1]; op.Operator {
+			case 9:
+				priv.BlueScale = state.ArgStack.Float()
+			case 10:
+				priv.BlueShift = value
+			case 11:
+				priv.BlueFuzz = value
+			case 14:
+				priv.ForceBold = value != 0
+			case 17:
+				priv.LanguageGroup = value
+			case 18:
+				priv.ExpansionFactor = state.ArgStack.Float()
+			case 19:
+				priv.InitialRandomSeed = value
+			}
 			return state.ArgStack.PopN(1)
-		case 12, 13: //  "StemSnapH"  "StemSnapV"
+		case 12: // "StemSnapH"
+			priv.StemSnapH = deltaArray(state)
+			return state.ArgStack.PopN(-2)
+		case 13: // "StemSnapV"
+			priv.StemSnapV = deltaArray(state)
 			return state.ArgStack.PopN(-2)
 		}
 	}
@@ -112,3 +112,57 @@ func TestCIDFont(t *testing.T) {
 	}
 	fmt.Println(len(font.localSubrs))
 }
+
+func TestCmap(t *testing.T) {
+	b, err := ioutil.ReadFile("test/YPTQCA+CMR17.cff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	font, err := Parse(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmap, _ := font.Cmap()
+	for _, r := range "Clean" {
+		gid, ok := cmap.Lookup(r)
+		if !ok {
+			t.Fatalf("missing rune %c in cmap", r)
+		}
+		if name := font.GlyphName(gid); name != string(r) {
+			t.Fatalf("unexpected glyph %s for rune %c", name, r)
+		}
+	}
+}
+
+func TestLineMetric(t *testing.T) {
+	b, err := ioutil.ReadFile("test/AAAPKB+SourceSansPro-Bold.cff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	font, err := Parse(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, metric := range []fonts.LineMetric{fonts.XHeight, fonts.CapHeight} {
+		r := 'x'
+		if metric == fonts.CapHeight {
+			r = 'H'
+		}
+		_, hasGlyph := font.cmap.Lookup(r)
+		height, ok := font.LineMetric(metric)
+		if ok != hasGlyph || (ok && height <= 0) {
+			t.Fatalf("unexpected metric %d: %f %v", metric, height, ok)
+		}
+	}
+
+	if rise, _ := font.LineMetric(fonts.HorizontalCaretRise); rise != 1000 {
+		t.Fatalf("unexpected caret rise %f", rise)
+	}
+	if run, _ := font.LineMetric(fonts.HorizontalCaretRun); run != 0 {
+		t.Fatalf("unexpected caret run %f", run)
+	}
+	if _, ok := font.LineMetric(fonts.HorizontalClippingAscent); ok {
+		t.Fatal("unsupported metric")
+	}
+}
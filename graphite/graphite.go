@@ -289,6 +289,17 @@ func (f *GraphiteFace) FeaturesForLang(lang Tag) FeaturesValue {
 	return f.sill.getFeatures(lang, f.feat)
 }
 
+// Tables returns the parsed 'Silf', 'Sill' and 'Feat' tables, keyed by tag.
+// Their types are internal to this package: the values are only
+// meant to be inspected, for debugging purposes.
+func (f *GraphiteFace) Tables() map[string]interface{} {
+	return map[string]interface{}{
+		"Silf": f.silf,
+		"Sill": f.sill,
+		"Feat": f.feat,
+	}
+}
+
 // getGlyph return nil for invalid gid
 func (f *GraphiteFace) getGlyph(gid GID) *glyph {
 	if int(gid) < len(f.glyphs) {
@@ -3,6 +3,8 @@ package harfbuzz
 import (
 	"math"
 	"sort"
+	"unicode/utf16"
+	"unicode/utf8"
 
 	"github.com/benoitkugler/textlayout/fonts"
 	"github.com/benoitkugler/textlayout/fonts/truetype"
@@ -32,7 +34,7 @@ const (
 	bsfHasDefaultIgnorables
 	bsfHasSpaceFallback
 	bsfHasGPOSAttachment
-	bsfHasUnsafeToBreak
+	bsfHasGlyphFlags
 	bsfHasCGJ
 	bsfDefault bufferScratchFlags = 0x00000000
 
@@ -54,6 +56,8 @@ const (
 
 // Buffer is the main structure holding the input text segment and its properties before shaping,
 // and output glyphs and their information after shaping.
+// A Buffer must not be used by several goroutines at the same time :
+// see `BufferPool` and `ShapeMany` for concurrent shaping.
 type Buffer struct {
 	// Info is used as internal storage during the shaping,
 	// and also exposes the result: the glyph to display
@@ -81,11 +85,32 @@ type Buffer struct {
 	// U+0020 SPACE character is used. Otherwise, this value is used
 	// verbatim.
 	Invisible fonts.GID
+	// NotFound is the glyph used for the characters not supported by the font.
+	// The default is 0, that is the '.notdef' glyph.
+	NotFound fonts.GID
+	// Replacement is the rune used by `AddUTF8` and `AddUTF16`
+	// in place of invalid input sequences.
+	// `NewBuffer` sets it to U+FFFD REPLACEMENT CHARACTER.
+	Replacement rune
 	// Information about how the text in the buffer should be treated.
 	Flags ShappingOptions
 	// Precise the cluster handling behavior.
 	ClusterLevel ClusterLevel
 
+	// Tracer, if not nil, is called before and after each step
+	// of the Opentype shaping process (see `TraceStep`).
+	// When it returns false before a GSUB or GPOS lookup, or before
+	// a 'morx' or 'kerx' subtable, this lookup (or subtable) is skipped
+	// and no end event is sent for it. For the other steps, which
+	// are required to complete the shaping, and after a step,
+	// the returned value is ignored.
+	// It is meant for debugging purposes, and is not used
+	// by the Graphite and fallback shapers.
+	Tracer func(TraceEvent) bool
+
+	// lookups enabled or disabled by JSTF priorities, see `Buffer.ShapeJustify`
+	jstf *jstfLookups
+
 	// some pathological cases can be constructed
 	// (for example with GSUB tables), where the size of the buffer
 	// grows out of bounds
@@ -99,6 +124,10 @@ type Buffer struct {
 	scratchFlags bufferScratchFlags /* Have space-fallback, etc. */
 
 	haveOutput bool
+
+	// true when Info stores glyphs (after shaping),
+	// false when it stores runes
+	shaped bool
 }
 
 // NewBuffer allocate a storage with default options.
@@ -106,6 +135,7 @@ type Buffer struct {
 func NewBuffer() *Buffer {
 	return &Buffer{
 		ClusterLevel: MonotoneGraphemes,
+		Replacement:  utf8.RuneError,
 		maxOps:       maxOpsDefault,
 	}
 }
@@ -168,6 +198,148 @@ func (b *Buffer) AddRunes(text []rune, itemOffset, itemLength int) {
 	b.context[1] = text[itemOffset+itemLength : s]
 }
 
+// AddUTF8 is the same as `AddRunes`, for UTF-8 encoded `text`.
+// `itemOffset` and `itemLength` are expressed in bytes (-1 still meaning the end of the text),
+// and so are the cluster values, which are the byte offsets of the runes in `text`.
+// Invalid sequences are replaced by `b.Replacement`, one byte at a time.
+func (b *Buffer) AddUTF8(text []byte, itemOffset, itemLength int) {
+	if itemLength < 0 {
+		itemLength = len(text) - itemOffset
+	}
+	b.addEncoded(len(text), itemOffset, itemLength,
+		func(i int) (rune, int) { return b.decodeUTF8(utf8.DecodeRune(text[i:])) },
+		func(i int) (rune, int) { return b.decodeUTF8(utf8.DecodeLastRune(text[:i])) },
+	)
+}
+
+// AddString is the same as `AddUTF8`, for a string.
+func (b *Buffer) AddString(text string, itemOffset, itemLength int) {
+	if itemLength < 0 {
+		itemLength = len(text) - itemOffset
+	}
+	b.addEncoded(len(text), itemOffset, itemLength,
+		func(i int) (rune, int) { return b.decodeUTF8(utf8.DecodeRuneInString(text[i:])) },
+		func(i int) (rune, int) { return b.decodeUTF8(utf8.DecodeLastRuneInString(text[:i])) },
+	)
+}
+
+// AddUTF16 is the same as `AddRunes`, for UTF-16 encoded `text`.
+// `itemOffset` and `itemLength` are expressed in 16-bit code units (-1 still meaning the end of the text),
+// and so are the cluster values, which are the offsets of the runes in `text`.
+// Unpaired surrogates are replaced by `b.Replacement`.
+func (b *Buffer) AddUTF16(text []uint16, itemOffset, itemLength int) {
+	if itemLength < 0 {
+		itemLength = len(text) - itemOffset
+	}
+	b.addEncoded(len(text), itemOffset, itemLength,
+		func(i int) (rune, int) {
+			if r1 := rune(text[i]); utf16.IsSurrogate(r1) {
+				if i+1 < len(text) {
+					if r := utf16.DecodeRune(r1, rune(text[i+1])); r != utf8.RuneError {
+						return r, 2
+					}
+				}
+				return b.Replacement, 1
+			}
+			return rune(text[i]), 1
+		},
+		func(i int) (rune, int) {
+			if r2 := rune(text[i-1]); utf16.IsSurrogate(r2) {
+				if i >= 2 {
+					if r := utf16.DecodeRune(rune(text[i-2]), r2); r != utf8.RuneError {
+						return r, 2
+					}
+				}
+				return b.Replacement, 1
+			}
+			return rune(text[i-1]), 1
+		},
+	)
+}
+
+// decodeUTF8 applies the replacement character for invalid sequences
+func (b *Buffer) decodeUTF8(r rune, size int) (rune, int) {
+	if r == utf8.RuneError && size == 1 {
+		return b.Replacement, 1
+	}
+	return r, size
+}
+
+// addEncoded implements `AddRunes` for encoded text of length `textLength` (in code units) :
+// `next` decodes the rune starting at the given index, and `previous` the rune
+// ending before the given index, both returning the rune and its length.
+func (b *Buffer) addEncoded(textLength, itemOffset, itemLength int, next, previous func(int) (rune, int)) {
+	// see AddRunes
+	if len(b.Info) == 0 && itemOffset > 0 {
+		// add pre-context
+		b.clearContext(0)
+		for prev := itemOffset; prev > 0 && len(b.context[0]) < contextLength; {
+			r, size := previous(prev)
+			b.context[0] = append(b.context[0], r)
+			prev -= size
+		}
+	}
+
+	end := itemOffset + itemLength
+	for i := itemOffset; i < end; {
+		r, size := next(i)
+		b.append(r, i)
+		i += size
+	}
+
+	// add post-context
+	b.context[1] = nil
+	for i := end; i < textLength && len(b.context[1]) < contextLength; {
+		r, size := next(i)
+		b.context[1] = append(b.context[1], r)
+		i += size
+	}
+}
+
+// ClusterSpan is a range of clusters values, from `Start` (included)
+// to `End` (excluded).
+type ClusterSpan struct {
+	Start, End int
+}
+
+// NotFoundClusters returns the ranges of clusters containing a glyph
+// equal to `b.NotFound`, that is the parts of the input not supported
+// by the font used for shaping, sorted in increasing order.
+// Adjacent ranges are merged.
+// A cluster spans from its value to the next (greater) cluster value,
+// and `end` is used for the last cluster : it is usually the cluster value
+// following the last input character (for instance `itemOffset + itemLength`
+// with `AddRunes`).
+// It should be called after `Shape`.
+func (b *Buffer) NotFoundClusters(end int) []ClusterSpan {
+	clusters := make([]int, 0, len(b.Info))
+	missing := make(map[int]bool)
+	for _, info := range b.Info {
+		if _, has := missing[info.Cluster]; !has {
+			clusters = append(clusters, info.Cluster)
+		}
+		missing[info.Cluster] = missing[info.Cluster] || info.Glyph == b.NotFound
+	}
+	sort.Ints(clusters)
+
+	var out []ClusterSpan
+	for i, cluster := range clusters {
+		if !missing[cluster] {
+			continue
+		}
+		next := end
+		if i+1 < len(clusters) {
+			next = clusters[i+1]
+		}
+		if L := len(out); L != 0 && out[L-1].End == cluster {
+			out[L-1].End = next
+		} else {
+			out = append(out, ClusterSpan{Start: cluster, End: next})
+		}
+	}
+	return out
+}
+
 // GuessSegmentProperties fills unset buffer segment properties based on buffer Unicode
 // contents.
 //
@@ -183,7 +355,7 @@ func (b *Buffer) AddRunes(text []rune, itemOffset, itemLength int) {
 // Finally, if buffer language is empty,
 // it will be set to the process's default language.
 // This may change in the future by taking buffer script into consideration when choosing a language.
-func (b *Buffer) guessSegmentProperties() {
+func (b *Buffer) GuessSegmentProperties() {
 	/* If script is not set, guess from buffer contents */
 	if b.Props.Script == 0 {
 		for _, info := range b.Info {
@@ -214,12 +386,15 @@ func (b *Buffer) guessSegmentProperties() {
 func (b *Buffer) Clear() {
 	b.Flags = 0
 	b.Invisible = 0
+	b.NotFound = 0
+	b.Replacement = utf8.RuneError
 
 	b.Props = SegmentProperties{}
 	b.scratchFlags = 0
 
 	b.haveOutput = false
 	// b.have_positions = false;
+	b.shaped = false
 
 	b.idx = 0
 	b.Info = b.Info[:0]
@@ -436,18 +611,85 @@ done:
 	b.skipGlyph()
 }
 
-// unsafeToBreak adds the flag `GlyphFlagUnsafeToBreak`
+// unsafeToBreak adds the flags `GlyphUnsafeToBreak` and `GlyphUnsafeToConcat`
 // when needed, between `start` and `end`.
 func (b *Buffer) unsafeToBreak(start, end int) {
-	if end-start < 2 {
+	b.setGlyphFlags(GlyphUnsafeToBreak|GlyphUnsafeToConcat, start, end, true, false)
+}
+
+// safeToInsertTatweel adds the flag `GlyphSafeToInsertTatweel` between `start` and `end`,
+// or fallbacks to `unsafeToBreak` if the flag is not requested.
+func (b *Buffer) safeToInsertTatweel(start, end int) {
+	if b.Flags&ProduceSafeToInsertTatweel == 0 {
+		b.unsafeToBreak(start, end)
+		return
+	}
+	b.setGlyphFlags(GlyphSafeToInsertTatweel, start, end, true, false)
+}
+
+// unsafeToConcat adds the flag `GlyphUnsafeToConcat`
+// when needed, between `start` and `end`.
+func (b *Buffer) unsafeToConcat(start, end int) {
+	if b.Flags&ProduceUnsafeToConcat == 0 {
 		return
 	}
-	b.unsafeToBreakImpl(start, end)
+	b.setGlyphFlags(GlyphUnsafeToConcat, start, end, false, false)
 }
 
-func (b *Buffer) unsafeToBreakImpl(start, end int) {
-	cluster := findMinCluster(b.Info, start, end, maxInt)
-	b.unsafeToBreakSetMask(b.Info, start, end, cluster)
+func (b *Buffer) unsafeToBreakFromOutbuffer(start, end int) {
+	b.setGlyphFlags(GlyphUnsafeToBreak|GlyphUnsafeToConcat, start, end, true, true)
+}
+
+func (b *Buffer) unsafeToConcatFromOutbuffer(start, end int) {
+	if b.Flags&ProduceUnsafeToConcat == 0 {
+		return
+	}
+	b.setGlyphFlags(GlyphUnsafeToConcat, start, end, false, true)
+}
+
+// setGlyphFlags adds `mask` to the glyphs between `start` and `end`.
+// If `interior` is true, only the glyphs not belonging to the first
+// cluster of the range are affected.
+// If `fromOutBuffer` is true, `start` refers to the output buffer
+// and `end` to the input buffer.
+func (b *Buffer) setGlyphFlags(mask GlyphMask, start, end int, interior, fromOutBuffer bool) {
+	end = min(end, len(b.Info))
+
+	if interior && !fromOutBuffer && end-start < 2 {
+		return
+	}
+
+	b.scratchFlags |= bsfHasGlyphFlags
+
+	if !fromOutBuffer || !b.haveOutput {
+		if !interior {
+			for i := start; i < end; i++ {
+				b.Info[i].Mask |= mask
+			}
+		} else {
+			cluster := findMinCluster(b.Info, start, end, maxInt)
+			setMaskForCluster(b.Info, start, end, cluster, mask)
+		}
+		return
+	}
+
+	//   assert (start <= out_len);
+	//   assert (idx <= end);
+
+	if !interior {
+		for i := start; i < len(b.outInfo); i++ {
+			b.outInfo[i].Mask |= mask
+		}
+		for i := b.idx; i < end; i++ {
+			b.Info[i].Mask |= mask
+		}
+	} else {
+		cluster := math.MaxInt32
+		cluster = findMinCluster(b.outInfo, start, len(b.outInfo), cluster)
+		cluster = findMinCluster(b.Info, b.idx, end, cluster)
+		setMaskForCluster(b.outInfo, start, len(b.outInfo), cluster, mask)
+		setMaskForCluster(b.Info, b.idx, end, cluster, mask)
+	}
 }
 
 // return the smallest cluster between `cluster` and  infos[start:end]
@@ -458,32 +700,14 @@ func findMinCluster(infos []GlyphInfo, start, end, cluster int) int {
 	return cluster
 }
 
-func (b *Buffer) unsafeToBreakSetMask(infos []GlyphInfo,
-	start, end, cluster int) {
+func setMaskForCluster(infos []GlyphInfo, start, end, cluster int, mask GlyphMask) {
 	for i := start; i < end; i++ {
 		if cluster != infos[i].Cluster {
-			b.scratchFlags |= bsfHasUnsafeToBreak
-			infos[i].Mask |= GlyphUnsafeToBreak
+			infos[i].Mask |= mask
 		}
 	}
 }
 
-func (b *Buffer) unsafeToBreakFromOutbuffer(start, end int) {
-	if !b.haveOutput {
-		b.unsafeToBreakImpl(start, end)
-		return
-	}
-
-	//   assert (start <= out_len);
-	//   assert (idx <= end);
-
-	cluster := math.MaxInt32
-	cluster = findMinCluster(b.outInfo, start, len(b.outInfo), cluster)
-	cluster = findMinCluster(b.Info, b.idx, end, cluster)
-	b.unsafeToBreakSetMask(b.outInfo, start, len(b.outInfo), cluster)
-	b.unsafeToBreakSetMask(b.Info, b.idx, end, cluster)
-}
-
 // reset `b.outInfo`, and adjust `pos` to have
 // same length as `Info` (without zeroing its values)
 func (b *Buffer) clearPositions() {
@@ -516,14 +740,20 @@ func (b *Buffer) clearOutput() {
 
 func (b *Buffer) clearContext(side uint) { b.context[side] = b.context[side][:0] }
 
-func (b *Buffer) unsafeToBreakAll() { b.unsafeToBreakImpl(0, len(b.Info)) }
+func (b *Buffer) unsafeToBreakAll() {
+	mask := GlyphUnsafeToBreak
+	if b.Flags&ProduceUnsafeToConcat != 0 {
+		mask |= GlyphUnsafeToConcat
+	}
+	b.setGlyphFlags(mask, 0, len(b.Info), true, false)
+}
 
-// safeToBreakAll remove the flag `GlyphFlagUnsafeToBreak`
+// safeToBreakAll remove the flags `GlyphUnsafeToBreak` and `GlyphUnsafeToConcat`
 // to all glyphs.
 func (b *Buffer) safeToBreakAll() {
 	info := b.Info
 	for i := range info {
-		info[i].Mask &= ^GlyphUnsafeToBreak
+		info[i].Mask &= ^(GlyphUnsafeToBreak | GlyphUnsafeToConcat)
 	}
 }
 
@@ -0,0 +1,98 @@
+package harfbuzz
+
+import "github.com/benoitkugler/textlayout/fonts"
+
+// ported from harfbuzz/src/hb-buffer.cc Copyright © 1998-2004  David Turner and Werner Lemberg, 2004,2007,2009,2010  Red Hat, Inc., 2011,2012  Google, Inc. Behdad Esfahbod
+
+// DiffFlags are returned by `Buffer.Diff`, and describe
+// the differences between two buffers.
+type DiffFlags uint16
+
+const (
+	// DiffEqual means equal buffers.
+	DiffEqual DiffFlags = 0
+
+	// DiffContentTypeMismatch means that one of the buffers
+	// has been shaped and not the other.
+	DiffContentTypeMismatch DiffFlags = 1 << (iota - 1)
+	// DiffLengthMismatch means the buffers have different lengths.
+	DiffLengthMismatch
+	// DiffNotdefPresent means the '.notdef' glyph is present in the buffer.
+	DiffNotdefPresent
+	// DiffDottedCirclePresent means the dotted circle glyph is present in the buffer.
+	DiffDottedCirclePresent
+	// DiffGlyphMismatch means the glyphs (or the runes, for buffers not shaped yet) differ.
+	DiffGlyphMismatch
+	// DiffClusterMismatch means the clusters differ.
+	DiffClusterMismatch
+	// DiffGlyphFlagsMismatch means the glyphs flags differ.
+	DiffGlyphFlagsMismatch
+	// DiffPositionMismatch means the glyphs positions differ.
+	DiffPositionMismatch
+)
+
+// Diff compares the contents of `b` with `reference`.
+// Buffers with different content types (one shaped and the other not) are
+// not compared further. For buffers with different lengths, the glyph-by-glyph
+// comparison is not attempted, but `reference` is still scanned
+// for the '.notdef' and `dottedCircle` glyphs.
+// Pass ^fonts.GID(0) as `dottedCircle` to disable these two checks,
+// which is what most callers want when just comparing buffers.
+// Two positions are considered equal if they differ by at most `positionFuzz`.
+func (b *Buffer) Diff(reference *Buffer, dottedCircle fonts.GID, positionFuzz Position) DiffFlags {
+	if b.shaped != reference.shaped && len(b.Info) != 0 && len(reference.Info) != 0 {
+		return DiffContentTypeMismatch
+	}
+
+	result := DiffEqual
+	contains := dottedCircle != ^fonts.GID(0)
+	if contains && reference.shaped {
+		for _, info := range reference.Info {
+			if info.Glyph == dottedCircle {
+				result |= DiffDottedCirclePresent
+			}
+			if info.Glyph == 0 {
+				result |= DiffNotdefPresent
+			}
+		}
+	}
+
+	if len(b.Info) != len(reference.Info) {
+		return result | DiffLengthMismatch
+	}
+
+	for i, info := range b.Info {
+		ref := reference.Info[i]
+		if b.shaped && info.Glyph != ref.Glyph || !b.shaped && info.codepoint != ref.codepoint {
+			result |= DiffGlyphMismatch
+		}
+		if info.Cluster != ref.Cluster {
+			result |= DiffClusterMismatch
+		}
+		if info.Mask & ^ref.Mask & glyphFlagDefined != 0 {
+			result |= DiffGlyphFlagsMismatch
+		}
+	}
+
+	if b.shaped && len(b.Pos) == len(b.Info) && len(reference.Pos) == len(reference.Info) {
+		for i, pos := range b.Pos {
+			ref := reference.Pos[i]
+			if absDiff(pos.XAdvance, ref.XAdvance) > positionFuzz ||
+				absDiff(pos.YAdvance, ref.YAdvance) > positionFuzz ||
+				absDiff(pos.XOffset, ref.XOffset) > positionFuzz ||
+				absDiff(pos.YOffset, ref.YOffset) > positionFuzz {
+				result |= DiffPositionMismatch
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+func absDiff(a, b Position) Position {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
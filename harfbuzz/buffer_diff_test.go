@@ -0,0 +1,75 @@
+package harfbuzz
+
+import (
+	"testing"
+
+	"github.com/benoitkugler/textlayout/fonts"
+)
+
+func TestBufferDiff(t *testing.T) {
+	font := NewFont(openFontFile("testdata/perf_reference/fonts/Roboto-Regular.ttf"))
+
+	shape := func(text string, features []Feature) *Buffer {
+		buf := NewBuffer()
+		buf.AddRunes([]rune(text), 0, -1)
+		buf.GuessSegmentProperties()
+		buf.Shape(font, features)
+		return buf
+	}
+
+	const noGlyph = ^fonts.GID(0)
+
+	ref := shape("office", nil)
+	if d := shape("office", nil).Diff(ref, noGlyph, 0); d != DiffEqual {
+		t.Fatalf("expected equal buffers, got %b", d)
+	}
+
+	unshaped := NewBuffer()
+	unshaped.AddRunes([]rune("office"), 0, -1)
+	if d := unshaped.Diff(ref, noGlyph, 0); d != DiffContentTypeMismatch {
+		t.Fatalf("expected content type mismatch, got %b", d)
+	}
+	other := NewBuffer()
+	other.AddRunes([]rune("offica"), 0, -1)
+	if d := other.Diff(unshaped, noGlyph, 0); d != DiffGlyphMismatch {
+		t.Fatalf("expected rune mismatch, got %b", d)
+	}
+
+	if d := shape("offic", nil).Diff(ref, noGlyph, 0); d != DiffLengthMismatch {
+		t.Fatalf("expected length mismatch, got %b", d)
+	}
+
+	// disabling ligatures changes the length
+	noLiga := []Feature{{Tag: newTag('l', 'i', 'g', 'a'), Value: 0, Start: FeatureGlobalStart, End: FeatureGlobalEnd}}
+	if d := shape("office", noLiga).Diff(ref, noGlyph, 0); d != DiffLengthMismatch {
+		t.Fatalf("expected length mismatch, got %b", d)
+	}
+
+	modified := shape("office", nil)
+	modified.Info[0].Glyph = 0
+	modified.Info[1].Cluster = 5
+	modified.Pos[2].XAdvance += 10
+	d := modified.Diff(ref, noGlyph, 0)
+	if exp := DiffGlyphMismatch | DiffClusterMismatch | DiffPositionMismatch; d != exp {
+		t.Fatalf("expected %b, got %b", exp, d)
+	}
+	if d := modified.Diff(ref, noGlyph, 10); d&DiffPositionMismatch != 0 {
+		t.Fatalf("unexpected position mismatch with fuzz")
+	}
+
+	// presence of special glyphs is checked in the reference
+	circle := ref.Info[3].Glyph
+	if d := ref.Diff(modified, circle, 10); d != DiffGlyphMismatch|DiffClusterMismatch|DiffNotdefPresent|DiffDottedCirclePresent {
+		t.Fatalf("expected notdef and dotted circle, got %b", d)
+	}
+	if d := shape("offic", nil).Diff(modified, circle, 0); d != DiffLengthMismatch|DiffNotdefPresent|DiffDottedCirclePresent {
+		t.Fatalf("expected notdef and dotted circle, got %b", d)
+	}
+
+	flags := shape("office", nil)
+	flags.Info[0].Mask |= GlyphUnsafeToBreak
+	ref.Info[0].Mask &^= GlyphUnsafeToBreak
+	if d := flags.Diff(ref, noGlyph, 0); d != DiffGlyphFlagsMismatch {
+		t.Fatalf("expected flags mismatch, got %b", d)
+	}
+}
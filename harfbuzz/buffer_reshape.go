@@ -0,0 +1,242 @@
+package harfbuzz
+
+import "sort"
+
+// ReshapeEdit updates `b`, which must hold the result of shaping a whole text
+// with `font` and `features` (with the cluster values set by `AddRunes`),
+// after an edit of this text.
+// `text` is the new, complete text, obtained by replacing the runes between
+// `start` and `oldEnd` of the previous text by the runes between `start` and `newEnd`.
+//
+// Only the smallest span of text affected by the edit is shaped again,
+// and the resulting glyphs are spliced into `b`. The boundaries of this span
+// are chosen using the `GlyphUnsafeToConcat` flag, which requires the
+// previous shaping to be done with the `ProduceUnsafeToConcat` option and monotone
+// clusters : otherwise, the whole text is shaped again.
+// As a safety check, the first and last clusters of the span, when they are
+// not adjacent to the edit, must be shaped identically : if not, the span is extended further.
+// Note that the glyph flags of the span are computed without the text outside of
+// the span: with fonts using long contextual lookups, they may be more conservative
+// than the ones of a complete shaping.
+//
+// The returned values are the start and end (exclusive) of the span, as indices into `text`.
+func (b *Buffer) ReshapeEdit(font *Font, features []Feature, text []rune, start, oldEnd, newEnd int) (from, to int) {
+	delta := newEnd - oldEnd
+	oldLen := len(text) - delta
+
+	if b.Flags&ProduceUnsafeToConcat == 0 || !b.shaped ||
+		(b.ClusterLevel != MonotoneGraphemes && b.ClusterLevel != MonotoneCharacters) {
+		b.reshapeAll(font, features, text)
+		return 0, len(text)
+	}
+
+	// cluster starts of the previous result, in logical order,
+	// with their safety
+	clusters, safe := b.clusterStarts()
+
+	// cluster containing the start of the edit
+	cs := 0
+	if i := sort.SearchInts(clusters, start+1); i > 0 {
+		cs = clusters[i-1]
+	}
+	// first cluster after the edit
+	ce := oldLen
+	if i := sort.SearchInts(clusters, oldEnd); i < len(clusters) {
+		ce = clusters[i]
+	}
+	if ce < cs {
+		ce = cs
+	}
+
+	// the span is [s, e) in the previous text, and [s, e+delta) in the new one
+	s := previousSafe(clusters, safe, cs)
+	e := nextSafe(clusters, safe, ce, oldLen)
+	for {
+		segment := b.shapeSegment(font, features, text, s, e+delta)
+
+		// check the first and last clusters of the span, when they are not
+		// adjacent to the edit (no check is needed at the boundaries of the text)
+		var kept []clusterRange
+		okBefore, okAfter := true, true
+		if s1 := nextCluster(clusters, s, oldLen); s > 0 && s1 < cs {
+			okBefore = b.sameClusters(segment, s, s1, 0)
+			if okBefore {
+				kept = append(kept, clusterRange{s, s1, 0})
+			}
+		}
+		if e1 := previousCluster(clusters, e); e < oldLen && e1 > ce {
+			okAfter = b.sameClusters(segment, e1, e, delta)
+			if okAfter {
+				kept = append(kept, clusterRange{e1, e, delta})
+			}
+		}
+		if okBefore && okAfter {
+			b.splice(segment, s, e, delta, kept)
+			return s, e + delta
+		}
+		if !okBefore {
+			s = previousSafe(clusters, safe, s)
+		}
+		if !okAfter {
+			e = nextSafe(clusters, safe, e, oldLen)
+		}
+	}
+}
+
+func (b *Buffer) reshapeAll(font *Font, features []Feature, text []rune) {
+	segment := b.shapeSegment(font, features, text, 0, len(text))
+	b.Info, b.Pos = segment.Info, segment.Pos
+	b.shaped = true
+}
+
+// shapeSegment shapes text[start:end], using the properties
+// of `b` and the remaining text as context
+func (b *Buffer) shapeSegment(font *Font, features []Feature, text []rune, start, end int) *Buffer {
+	segment := NewBuffer()
+	segment.Props = b.Props
+	segment.ClusterLevel = b.ClusterLevel
+	segment.Invisible = b.Invisible
+	segment.NotFound = b.NotFound
+	segment.Replacement = b.Replacement
+	segment.Flags = b.Flags
+	if start > 0 {
+		segment.Flags &^= Bot
+	}
+	if end < len(text) {
+		segment.Flags &^= Eot
+	}
+	segment.AddRunes(text, start, end-start)
+	segment.Shape(font, features)
+	return segment
+}
+
+// clusterStarts returns the sorted cluster values, and whether
+// it is safe to change the text on one side of each of them.
+func (b *Buffer) clusterStarts() (clusters []int, safe []bool) {
+	unsafe := make(map[int]bool)
+	for _, info := range b.Info {
+		if _, has := unsafe[info.Cluster]; !has {
+			clusters = append(clusters, info.Cluster)
+		}
+		unsafe[info.Cluster] = unsafe[info.Cluster] || info.Mask&GlyphUnsafeToConcat != 0
+	}
+	sort.Ints(clusters)
+	safe = make([]bool, len(clusters))
+	for i, c := range clusters {
+		safe[i] = !unsafe[c]
+	}
+	return clusters, safe
+}
+
+// previousSafe returns the last safe cluster start strictly before `c`, or 0
+func previousSafe(clusters []int, safe []bool, c int) int {
+	for i := sort.SearchInts(clusters, c) - 1; i >= 0; i-- {
+		if safe[i] {
+			return clusters[i]
+		}
+	}
+	return 0
+}
+
+// nextCluster returns the first cluster start strictly after `c`, or `end`
+func nextCluster(clusters []int, c, end int) int {
+	if i := sort.SearchInts(clusters, c+1); i < len(clusters) {
+		return clusters[i]
+	}
+	return end
+}
+
+// previousCluster returns the last cluster start strictly before `c`, or 0
+func previousCluster(clusters []int, c int) int {
+	if i := sort.SearchInts(clusters, c); i > 0 {
+		return clusters[i-1]
+	}
+	return 0
+}
+
+// nextSafe returns the first safe cluster start strictly after `c`, or `end`
+func nextSafe(clusters []int, safe []bool, c, end int) int {
+	for i := sort.SearchInts(clusters, c+1); i < len(clusters); i++ {
+		if safe[i] {
+			return clusters[i]
+		}
+	}
+	return end
+}
+
+// clusterRange is a range [start, end) of clusters,
+// shifted by delta after an edit
+type clusterRange struct {
+	start, end, delta int
+}
+
+// glyphRange returns the range of glyphs whose cluster is in [start, end),
+// which is contiguous for monotone cluster levels.
+// An empty range is returned as (0, 0).
+func glyphRange(infos []GlyphInfo, start, end int) (i, j int) {
+	i, j = len(infos), 0
+	for k, info := range infos {
+		if start <= info.Cluster && info.Cluster < end {
+			i = min(i, k)
+			j = max(j, k+1)
+		}
+	}
+	if i > j {
+		return 0, 0
+	}
+	return i, j
+}
+
+// sameClusters returns true if the glyphs of `b` with clusters in [start, end)
+// are identical to the glyphs of `segment` with clusters in [start+delta, end+delta)
+func (b *Buffer) sameClusters(segment *Buffer, start, end, delta int) bool {
+	i1, j1 := glyphRange(b.Info, start, end)
+	i2, j2 := glyphRange(segment.Info, start+delta, end+delta)
+	if j1-i1 != j2-i2 {
+		return false
+	}
+	for k := 0; k < j1-i1; k++ {
+		g1, g2 := b.Info[i1+k], segment.Info[i2+k]
+		if g1.Glyph != g2.Glyph || g1.Cluster+delta != g2.Cluster || b.Pos[i1+k] != segment.Pos[i2+k] {
+			return false
+		}
+	}
+	return true
+}
+
+// splice replaces the glyphs of `b` for the clusters in [start, end)
+// by the glyphs of `segment`, and shifts the clusters after `end`.
+// The flags of the clusters in `kept` (given in the previous text, and identical in
+// both buffers), computed with the whole text as context, are preserved.
+func (b *Buffer) splice(segment *Buffer, start, end, delta int, kept []clusterRange) {
+	for _, r := range kept {
+		i1, j1 := glyphRange(b.Info, r.start, r.end)
+		i2, _ := glyphRange(segment.Info, r.start+r.delta, r.end+r.delta)
+		for k := 0; k < j1-i1; k++ {
+			mask := &segment.Info[i2+k].Mask
+			*mask = (*mask & ^glyphFlagDefined) | (b.Info[i1+k].Mask & glyphFlagDefined)
+		}
+	}
+
+	i, j := glyphRange(b.Info, start, end)
+	infos := make([]GlyphInfo, 0, len(b.Info)-(j-i)+len(segment.Info))
+	pos := make([]GlyphPosition, 0, cap(infos))
+	infos = append(infos, b.Info[:i]...)
+	pos = append(pos, b.Pos[:i]...)
+	infos = append(infos, segment.Info...)
+	pos = append(pos, segment.Pos...)
+	for _, info := range b.Info[j:] {
+		if info.Cluster >= end {
+			info.Cluster += delta
+		}
+		infos = append(infos, info)
+	}
+	pos = append(pos, b.Pos[j:]...)
+	// for backward directions, the glyphs before the segment follow it in the text
+	for k := range infos[:i] {
+		if infos[k].Cluster >= end {
+			infos[k].Cluster += delta
+		}
+	}
+	b.Info, b.Pos = infos, pos
+}
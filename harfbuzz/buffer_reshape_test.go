@@ -0,0 +1,110 @@
+package harfbuzz
+
+import (
+	"testing"
+
+	"github.com/benoitkugler/textlayout/fonts"
+)
+
+func TestGlyphFlagsOptions(t *testing.T) {
+	font := NewFont(openFontFile("testdata/perf_reference/fonts/Amiri-Regular.ttf"))
+	text := []rune("بسم الله الرحمن الرحيم")
+
+	hasFlag := func(b *Buffer, flag uint32) bool {
+		for _, info := range b.Info {
+			if info.Mask&flag != 0 {
+				return true
+			}
+		}
+		return false
+	}
+
+	buf := shapeRunes(font, text, 0, nil)
+	assert(t, hasFlag(buf, GlyphUnsafeToBreak))
+	assert(t, !hasFlag(buf, GlyphUnsafeToConcat))
+	assert(t, !hasFlag(buf, GlyphSafeToInsertTatweel))
+
+	buf = shapeRunes(font, text, ProduceUnsafeToConcat|ProduceSafeToInsertTatweel, nil)
+	assert(t, hasFlag(buf, GlyphUnsafeToConcat))
+	assert(t, hasFlag(buf, GlyphSafeToInsertTatweel))
+
+	// unsafe to break implies unsafe to concat
+	for _, info := range buf.Info {
+		if info.Mask&GlyphUnsafeToBreak != 0 {
+			assert(t, info.Mask&GlyphUnsafeToConcat != 0)
+		}
+	}
+}
+
+func TestReshapeEdit(t *testing.T) {
+	const noGlyph = ^fonts.GID(0)
+
+	type edit struct {
+		start, end  int    // in the old text
+		replacement string // new content
+	}
+	for _, test := range []struct {
+		fontFile string
+		text     string
+		edits    []edit
+		partial  bool // all the edits only require a partial reshaping
+	}{
+		{
+			"testdata/perf_reference/fonts/Roboto-Regular.ttf",
+			"The official office of the AVATAR, finally.",
+			[]edit{{0, 0, "Well, "}, {4, 12, "final"}, {13, 19, "ffi"}, {42, 43, "!!"}, {35, 43, ""}, {3, 4, "  "}},
+			false, // kerning makes every boundary unsafe to concat
+		},
+		{
+			"testdata/perf_reference/fonts/Roboto-Regular.ttf",
+			"office 2021 finally",
+			[]edit{{0, 6, "offices"}, {9, 10, "1"}, {12, 19, "final"}, {6, 7, "  "}},
+			true, // digits are not kerned
+		},
+		{
+			"testdata/perf_reference/fonts/Amiri-Regular.ttf",
+			"بسم الله الرحمن الرحيم",
+			[]edit{{0, 0, "و"}, {4, 8, "الرحمن"}, {9, 9, "ل"}, {21, 22, ""}, {3, 4, "ـ"}},
+			false, // arabic joining and kerning make every boundary unsafe to concat
+		},
+		{
+			"testdata/fonts/NotoNastaliqUrdu-Regular.ttf",
+			"سلام 123 دنيا",
+			[]edit{{9, 13, "عالم"}, {6, 7, "4"}, {1, 2, "ل"}, {12, 13, "ى"}, {9, 9, "ال"}},
+			true,
+		},
+		{
+			"testdata/perf_reference/fonts/NotoSansDevanagari-Regular.ttf",
+			"हिन्दी भाषा का विकास",
+			[]edit{{0, 1, "कि"}, {7, 11, "भाषाएँ"}, {3, 4, ""}, {12, 12, "र्"}},
+			true,
+		},
+	} {
+		font := NewFont(openFontFile(test.fontFile))
+		for _, ed := range test.edits {
+			old := []rune(test.text)
+			repl := []rune(ed.replacement)
+			text := append(append(append([]rune(nil), old[:ed.start]...), repl...), old[ed.end:]...)
+
+			buf := shapeRunes(font, old, ProduceUnsafeToConcat, nil)
+			from, to := buf.ReshapeEdit(font, nil, text, ed.start, ed.end, ed.start+len(repl))
+			assert(t, from <= ed.start && ed.start+len(repl) <= to)
+
+			exp := shapeRunes(font, text, ProduceUnsafeToConcat, nil)
+			if d := buf.Diff(exp, noGlyph, 0); d != DiffEqual {
+				t.Fatalf("%s: edit %v: invalid reshaping (%b)", test.fontFile, ed, d)
+			}
+			if test.partial && from == 0 && to == len(text) {
+				t.Errorf("%s: edit %v: unexpected full reshaping", test.fontFile, ed)
+			}
+		}
+	}
+
+	// without the required flag, everything is shaped again
+	font := NewFont(openFontFile("testdata/perf_reference/fonts/Roboto-Regular.ttf"))
+	buf := shapeRunes(font, []rune("office"), 0, nil)
+	from, to := buf.ReshapeEdit(font, nil, []rune("offices"), 6, 6, 7)
+	assertEqualInt(t, 0, from)
+	assertEqualInt(t, 7, to)
+	assertEqualInt(t, 5, len(buf.Info)) // o ffi c e s
+}
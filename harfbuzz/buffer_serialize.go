@@ -0,0 +1,412 @@
+package harfbuzz
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/benoitkugler/textlayout/fonts"
+	"github.com/benoitkugler/textlayout/fonts/truetype"
+)
+
+// ported from harfbuzz/src/hb-buffer-serialize.cc Copyright © 2012,2013  Google, Inc. Behdad Esfahbod
+
+// SerializeFormat selects the textual representation
+// used to serialize a buffer.
+type SerializeFormat uint8
+
+const (
+	// SerializeText is the compact format used by hb-shape,
+	// such as [uni0041=0+1024|uni0042=1@10,0+980] for glyphs
+	// or <U+0041=0|U+0042=1> for Unicode code points.
+	SerializeText SerializeFormat = iota
+	// SerializeJSON outputs an array of objects, one per item, such as
+	// [{"g":"uni0041","cl":0,"dx":0,"dy":0,"ax":1024,"ay":0}].
+	SerializeJSON
+)
+
+// SerializeFlags controls the attributes written
+// when serializing a buffer.
+type SerializeFlags uint8
+
+const (
+	// Do not serialize glyph cluster.
+	SerializeNoClusters SerializeFlags = 1 << iota
+	// Do not serialize glyph position information.
+	SerializeNoPositions
+	// Do not serialize glyph name, but the glyph index instead.
+	SerializeNoGlyphNames
+	// Serialize glyph extents.
+	SerializeGlyphExtents
+	// Serialize glyph flags (see `GlyphUnsafeToBreak`).
+	SerializeGlyphFlags
+	// Do not serialize glyph advances, but include
+	// them in the offsets instead.
+	SerializeNoAdvances
+)
+
+// Serialize returns a textual representation of the glyphs in the buffer,
+// which must have been shaped. It is the inverse of `DeserializeGlyphs`.
+// `font` is used to fetch glyph names and extents : it may be nil
+// when `SerializeNoGlyphNames` is set and `SerializeGlyphExtents` is not.
+// An empty string is returned for an empty buffer.
+func (b *Buffer) Serialize(font *Font, format SerializeFormat, flags SerializeFlags) string {
+	if len(b.Info) == 0 {
+		return "" //  the reference does not return []
+	}
+	gs := new(strings.Builder)
+	gs.WriteByte('[')
+	var x, y Position
+	for i, glyph := range b.Info {
+		pos := b.Pos[i]
+		if i != 0 {
+			if format == SerializeJSON {
+				gs.WriteByte(',')
+			} else {
+				gs.WriteByte('|')
+			}
+		}
+
+		var extents GlyphExtents
+		if flags&SerializeGlyphExtents != 0 {
+			extents, _ = font.GlyphExtents(glyph.Glyph)
+		}
+		glyphFlags := glyph.Mask & glyphFlagDefined
+
+		if format == SerializeJSON {
+			if flags&SerializeNoGlyphNames != 0 {
+				fmt.Fprintf(gs, `{"g":%d`, glyph.Glyph)
+			} else {
+				fmt.Fprintf(gs, `{"g":%s`, strconv.Quote(font.glyphToString(glyph.Glyph)))
+			}
+			if flags&SerializeNoClusters == 0 {
+				fmt.Fprintf(gs, `,"cl":%d`, glyph.Cluster)
+			}
+			if flags&SerializeNoPositions == 0 {
+				fmt.Fprintf(gs, `,"dx":%d,"dy":%d`, x+pos.XOffset, y+pos.YOffset)
+				if flags&SerializeNoAdvances == 0 {
+					fmt.Fprintf(gs, `,"ax":%d,"ay":%d`, pos.XAdvance, pos.YAdvance)
+				}
+			}
+			if flags&SerializeGlyphFlags != 0 && glyphFlags != 0 {
+				fmt.Fprintf(gs, `,"fl":%d`, glyphFlags)
+			}
+			if flags&SerializeGlyphExtents != 0 {
+				fmt.Fprintf(gs, `,"xb":%d,"yb":%d,"w":%d,"h":%d`, extents.XBearing, extents.YBearing, extents.Width, extents.Height)
+			}
+			gs.WriteByte('}')
+		} else {
+			if flags&SerializeNoGlyphNames != 0 {
+				fmt.Fprintf(gs, "%d", glyph.Glyph)
+			} else {
+				gs.WriteString(font.glyphToString(glyph.Glyph))
+			}
+			if flags&SerializeNoClusters == 0 {
+				fmt.Fprintf(gs, "=%d", glyph.Cluster)
+			}
+			if flags&SerializeNoPositions == 0 {
+				if x+pos.XOffset != 0 || y+pos.YOffset != 0 {
+					fmt.Fprintf(gs, "@%d,%d", x+pos.XOffset, y+pos.YOffset)
+				}
+				if flags&SerializeNoAdvances == 0 {
+					fmt.Fprintf(gs, "+%d", pos.XAdvance)
+					if pos.YAdvance != 0 {
+						fmt.Fprintf(gs, ",%d", pos.YAdvance)
+					}
+				}
+			}
+			if flags&SerializeGlyphFlags != 0 && glyphFlags != 0 {
+				fmt.Fprintf(gs, "#%X", glyphFlags)
+			}
+			if flags&SerializeGlyphExtents != 0 {
+				fmt.Fprintf(gs, "<%d,%d,%d,%d>", extents.XBearing, extents.YBearing, extents.Width, extents.Height)
+			}
+		}
+
+		if flags&SerializeNoAdvances != 0 {
+			x += pos.XAdvance
+			y += pos.YAdvance
+		}
+	}
+	gs.WriteByte(']')
+	return gs.String()
+}
+
+// SerializeUnicode returns a textual representation of the
+// Unicode code points in the buffer, which must not have been shaped yet.
+// Only the `SerializeNoClusters` flag is used.
+// It is the inverse of `DeserializeUnicode`.
+func (b *Buffer) SerializeUnicode(format SerializeFormat, flags SerializeFlags) string {
+	if len(b.Info) == 0 {
+		return ""
+	}
+	gs := new(strings.Builder)
+	if format == SerializeJSON {
+		gs.WriteByte('[')
+	} else {
+		gs.WriteByte('<')
+	}
+	for i, info := range b.Info {
+		if format == SerializeJSON {
+			if i != 0 {
+				gs.WriteByte(',')
+			}
+			fmt.Fprintf(gs, `{"u":%d`, info.codepoint)
+			if flags&SerializeNoClusters == 0 {
+				fmt.Fprintf(gs, `,"cl":%d`, info.Cluster)
+			}
+			gs.WriteByte('}')
+		} else {
+			if i != 0 {
+				gs.WriteByte('|')
+			}
+			fmt.Fprintf(gs, "U+%04X", info.codepoint)
+			if flags&SerializeNoClusters == 0 {
+				fmt.Fprintf(gs, "=%d", info.Cluster)
+			}
+		}
+	}
+	if format == SerializeJSON {
+		gs.WriteByte(']')
+	} else {
+		gs.WriteByte('>')
+	}
+	return gs.String()
+}
+
+// glyphNameResolver is the inverse of `glyphToString`, also
+// accepting glyph indexes and uniXXXX names.
+type glyphNameResolver struct {
+	font  *Font
+	names map[string]fonts.GID // lazily built
+}
+
+func (r *glyphNameResolver) resolve(s string) (fonts.GID, error) {
+	if gid, err := strconv.ParseUint(s, 10, 32); err == nil {
+		return fonts.GID(gid), nil
+	}
+	if r.font == nil {
+		return 0, fmt.Errorf("glyph name %s requires a font", s)
+	}
+	if r.names == nil {
+		numGlyphs := 0xFFFF
+		if ft, ok := r.font.face.(*truetype.Font); ok {
+			numGlyphs = ft.NumGlyphs
+		}
+		r.names = make(map[string]fonts.GID)
+		for gid := numGlyphs - 1; gid >= 0; gid-- { // prefer lower indexes
+			if name := r.font.face.GlyphName(fonts.GID(gid)); name != "" {
+				r.names[name] = fonts.GID(gid)
+			}
+		}
+	}
+	if gid, ok := r.names[s]; ok {
+		return gid, nil
+	}
+	if strings.HasPrefix(s, "gid") {
+		if gid, err := strconv.ParseUint(s[3:], 10, 32); err == nil {
+			return fonts.GID(gid), nil
+		}
+	}
+	if strings.HasPrefix(s, "uni") {
+		if u, err := strconv.ParseUint(s[3:], 16, 32); err == nil {
+			if gid, ok := r.font.face.NominalGlyph(rune(u)); ok {
+				return gid, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("unknown glyph name %s", s)
+}
+
+// DeserializeGlyphs parses `s`, as written by `Serialize`, and appends the glyphs
+// (with their positions) to the buffer.
+// `font` is used to resolve glyph names : it may be nil if `s` only
+// contains glyph indexes.
+// Glyph extents, if present, are ignored.
+func (b *Buffer) DeserializeGlyphs(s string, font *Font, format SerializeFormat) error {
+	b.shaped = true
+	resolver := glyphNameResolver{font: font}
+	if format == SerializeJSON {
+		return b.deserializeGlyphsJSON(s, &resolver)
+	}
+
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(s, "[")
+	s = strings.TrimSuffix(s, "]")
+	if s == "" {
+		return nil
+	}
+	for _, item := range strings.Split(s, "|") {
+		info, pos, err := parseGlyphItem(item, &resolver)
+		if err != nil {
+			return err
+		}
+		b.Info = append(b.Info, info)
+		b.Pos = append(b.Pos, pos)
+	}
+	return nil
+}
+
+// parse an item such as name=cluster@dx,dy+ax,ay#flags<xb,yb,w,h>
+func parseGlyphItem(item string, resolver *glyphNameResolver) (info GlyphInfo, pos GlyphPosition, err error) {
+	item = strings.TrimSpace(item)
+	end := strings.IndexAny(item, "=@+#<")
+	if end == -1 {
+		end = len(item)
+	}
+	info.Glyph, err = resolver.resolve(item[:end])
+	if err != nil {
+		return info, pos, err
+	}
+	info.codepoint = rune(info.Glyph)
+	item = item[end:]
+
+	// returns the comma separated numbers of the next field
+	parseField := func() ([]int32, error) {
+		end := strings.IndexAny(item[1:], "=@+#<")
+		if end == -1 {
+			end = len(item)
+		} else {
+			end++
+		}
+		field := strings.TrimSuffix(item[1:end], ">")
+		item = item[end:]
+		var out []int32
+		for _, chunk := range strings.Split(field, ",") {
+			v, err := strconv.ParseInt(chunk, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid glyph item value %s", chunk)
+			}
+			out = append(out, int32(v))
+		}
+		return out, nil
+	}
+
+	for len(item) != 0 {
+		if item[0] == '#' {
+			end := strings.IndexByte(item, '<')
+			if end == -1 {
+				end = len(item)
+			}
+			fl, err := strconv.ParseUint(item[1:end], 16, 32)
+			if err != nil {
+				return info, pos, fmt.Errorf("invalid glyph flags %s", item[1:end])
+			}
+			info.Mask = GlyphMask(fl) & glyphFlagDefined
+			item = item[end:]
+			continue
+		}
+
+		kind := item[0]
+		values, err := parseField()
+		if err != nil {
+			return info, pos, err
+		}
+		switch {
+		case kind == '=' && len(values) == 1:
+			info.Cluster = int(values[0])
+		case kind == '@' && len(values) == 2:
+			pos.XOffset, pos.YOffset = values[0], values[1]
+		case kind == '+' && len(values) == 1:
+			pos.XAdvance = values[0]
+		case kind == '+' && len(values) == 2:
+			pos.XAdvance, pos.YAdvance = values[0], values[1]
+		case kind == '<' && len(values) == 4: // extents are ignored
+		default:
+			return info, pos, fmt.Errorf("invalid glyph item field %c%v", kind, values)
+		}
+	}
+	return info, pos, nil
+}
+
+// glyphJSON is one item of the JSON formats (glyph or code point)
+type glyphJSON struct {
+	G  json.RawMessage `json:"g"`
+	U  *rune           `json:"u"`
+	Cl int             `json:"cl"`
+	Dx int32           `json:"dx"`
+	Dy int32           `json:"dy"`
+	Ax int32           `json:"ax"`
+	Ay int32           `json:"ay"`
+	Fl uint32          `json:"fl"`
+}
+
+func (b *Buffer) deserializeGlyphsJSON(s string, resolver *glyphNameResolver) error {
+	var items []glyphJSON
+	if err := json.Unmarshal([]byte(s), &items); err != nil {
+		return fmt.Errorf("invalid glyphs JSON: %s", err)
+	}
+	for _, item := range items {
+		var (
+			info GlyphInfo
+			err  error
+		)
+		var name string
+		if json.Unmarshal(item.G, &name) == nil {
+			info.Glyph, err = resolver.resolve(name)
+		} else {
+			var gid uint32
+			if err = json.Unmarshal(item.G, &gid); err != nil {
+				err = fmt.Errorf("invalid glyph %s", item.G)
+			}
+			info.Glyph = fonts.GID(gid)
+		}
+		if err != nil {
+			return err
+		}
+		info.codepoint = rune(info.Glyph)
+		info.Cluster = item.Cl
+		info.Mask = GlyphMask(item.Fl) & glyphFlagDefined
+		b.Info = append(b.Info, info)
+		b.Pos = append(b.Pos, GlyphPosition{XOffset: item.Dx, YOffset: item.Dy, XAdvance: item.Ax, YAdvance: item.Ay})
+	}
+	return nil
+}
+
+// DeserializeUnicode parses `s`, as written by `SerializeUnicode`, and appends the
+// code points (with their clusters) to the buffer.
+func (b *Buffer) DeserializeUnicode(s string, format SerializeFormat) error {
+	if format == SerializeJSON {
+		var items []glyphJSON
+		if err := json.Unmarshal([]byte(s), &items); err != nil {
+			return fmt.Errorf("invalid Unicode JSON: %s", err)
+		}
+		for _, item := range items {
+			if item.U == nil {
+				return errors.New("invalid Unicode JSON: missing code point")
+			}
+			b.append(*item.U, item.Cl)
+		}
+		return nil
+	}
+
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(s, "<")
+	s = strings.TrimSuffix(s, ">")
+	if s == "" {
+		return nil
+	}
+	for _, item := range strings.Split(s, "|") {
+		item = strings.TrimSpace(item)
+		var cluster int64
+		if i := strings.IndexByte(item, '='); i != -1 {
+			var err error
+			cluster, err = strconv.ParseInt(item[i+1:], 10, 32)
+			if err != nil {
+				return fmt.Errorf("invalid cluster in %s", item)
+			}
+			item = item[:i]
+		}
+		if !strings.HasPrefix(item, "U+") {
+			return fmt.Errorf("invalid code point %s", item)
+		}
+		u, err := strconv.ParseUint(item[2:], 16, 32)
+		if err != nil {
+			return fmt.Errorf("invalid code point %s", item)
+		}
+		b.append(rune(u), int(cluster))
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package harfbuzz
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSerializeRoundTrip(t *testing.T) {
+	font := NewFont(openFontFile("testdata/perf_reference/fonts/Roboto-Regular.ttf"))
+	buf := shapeRunes(font, []rune("Toffee, Va"), 0, nil)
+
+	for _, format := range []SerializeFormat{SerializeText, SerializeJSON} {
+		for _, flags := range []SerializeFlags{0, SerializeNoGlyphNames, SerializeGlyphFlags} {
+			s := buf.Serialize(font, format, flags)
+
+			got := NewBuffer()
+			if err := got.DeserializeGlyphs(s, font, format); err != nil {
+				t.Fatal(err)
+			}
+			if len(got.Info) != len(buf.Info) {
+				t.Fatalf("expected %d glyphs, got %d", len(buf.Info), len(got.Info))
+			}
+			for i, info := range got.Info {
+				exp := buf.Info[i]
+				if info.Glyph != exp.Glyph || info.Cluster != exp.Cluster {
+					t.Fatalf("glyph %d: expected %v, got %v", i, exp, info)
+				}
+				if flags&SerializeGlyphFlags != 0 && info.Mask != exp.Mask&glyphFlagDefined {
+					t.Fatalf("glyph %d: expected flags %d, got %d", i, exp.Mask&glyphFlagDefined, info.Mask)
+				}
+				gotPos, expPos := got.Pos[i], buf.Pos[i]
+				if gotPos.XAdvance != expPos.XAdvance || gotPos.XOffset != expPos.XOffset || gotPos.YOffset != expPos.YOffset {
+					t.Fatalf("glyph %d: expected %v, got %v", i, expPos, gotPos)
+				}
+			}
+			if s2 := got.Serialize(font, format, flags); s2 != s {
+				t.Fatalf("expected %s, got %s", s, s2)
+			}
+		}
+	}
+}
+
+func TestSerializeFormats(t *testing.T) {
+	buf := NewBuffer()
+	buf.Info = []GlyphInfo{{Glyph: 3, Cluster: 0, Mask: GlyphUnsafeToBreak}, {Glyph: 4, Cluster: 2}}
+	buf.Pos = []GlyphPosition{{XAdvance: 100}, {XAdvance: 50, XOffset: 10, YOffset: -5}}
+
+	for _, test := range []struct {
+		format   SerializeFormat
+		flags    SerializeFlags
+		expected string
+	}{
+		{SerializeText, SerializeNoGlyphNames, "[3=0+100|4=2@10,-5+50]"},
+		{SerializeText, SerializeNoGlyphNames | SerializeNoClusters | SerializeGlyphFlags, "[3+100#1|4@10,-5+50]"},
+		{SerializeText, SerializeNoGlyphNames | SerializeNoAdvances, "[3=0|4=2@110,-5]"},
+		{SerializeText, SerializeNoGlyphNames | SerializeNoPositions, "[3=0|4=2]"},
+		{SerializeJSON, SerializeNoGlyphNames | SerializeGlyphFlags, `[{"g":3,"cl":0,"dx":0,"dy":0,"ax":100,"ay":0,"fl":1},{"g":4,"cl":2,"dx":10,"dy":-5,"ax":50,"ay":0}]`},
+	} {
+		if got := buf.Serialize(nil, test.format, test.flags); got != test.expected {
+			t.Errorf("expected %s, got %s", test.expected, got)
+		}
+	}
+}
+
+func TestDeserializeGlyphs(t *testing.T) {
+	buf := NewBuffer()
+	err := buf.DeserializeGlyphs("[1=0@-10,20+300,10#1<0,500,300,-500>|2=3+200]", nil, SerializeText)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []GlyphPosition{{XOffset: -10, YOffset: 20, XAdvance: 300, YAdvance: 10}, {XAdvance: 200}}
+	if !reflect.DeepEqual(buf.Pos, expected) {
+		t.Fatalf("expected %v, got %v", expected, buf.Pos)
+	}
+	if buf.Info[0].Mask != GlyphUnsafeToBreak || buf.Info[1].Cluster != 3 {
+		t.Fatalf("unexpected glyphs %v", buf.Info)
+	}
+
+	for _, invalid := range []string{"[a=0]", "[1=x]", "[1=0@3]", "[1#z]"} {
+		if err := NewBuffer().DeserializeGlyphs(invalid, nil, SerializeText); err == nil {
+			t.Errorf("expected error for %s", invalid)
+		}
+	}
+	if err := NewBuffer().DeserializeGlyphs(`[{"g":"a"}]`, nil, SerializeJSON); err == nil {
+		t.Error("expected error for missing font")
+	}
+}
+
+func TestSerializeUnicode(t *testing.T) {
+	buf := NewBuffer()
+	buf.AddRunes([]rune("aé€\U0001F600"), 0, -1)
+
+	for _, format := range []SerializeFormat{SerializeText, SerializeJSON} {
+		s := buf.SerializeUnicode(format, 0)
+		got := NewBuffer()
+		if err := got.DeserializeUnicode(s, format); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got.Info, buf.Info) {
+			t.Fatalf("expected %v, got %v", buf.Info, got.Info)
+		}
+	}
+
+	if s := buf.SerializeUnicode(SerializeText, 0); s != "<U+0061=0|U+00E9=1|U+20AC=2|U+1F600=3>" {
+		t.Fatalf("unexpected serialization %s", s)
+	}
+	if s := buf.SerializeUnicode(SerializeJSON, SerializeNoClusters); s != `[{"u":97},{"u":233},{"u":8364},{"u":128512}]` {
+		t.Fatalf("unexpected serialization %s", s)
+	}
+}
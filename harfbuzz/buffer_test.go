@@ -207,136 +207,139 @@ func TestBuffer(t *testing.T) {
 	}
 }
 
-/*
- * Comparing buffers.
- */
-
-/**
- *
- * Flags from comparing two #hb_buffer_t's.
- *
- * Buffer with different #hb_buffer_content_type_t cannot be meaningfully
- * compared in any further detail.
- *
- * For buffers with differing length, the per-glyph comparison is not
- * attempted, though we do still scan reference buffer for dotted circle and
- * `.notdef` glyphs.
- *
- * If the buffers have the same length, we compare them glyph-by-glyph and
- * report which aspect(s) of the glyph info/position are different.
- *
- * Since: 1.5.0
- */
-const (
-	bufferDiffFlagEqual = 0x0000
-
-	// /* Buffers with different content_type cannot be meaningfully compared
-	//  * in any further detail. */
-	// HB_BUFFER_DIFF_FLAG_CONTENT_TYPE_MISMATCH	= 0x0001
-
-	/* For buffers with differing length, the per-glyph comparison is not
-	 * attempted, though we do still scan reference for dottedcircle / .notdef
-	 * glyphs. */
-	HB_BUFFER_DIFF_FLAG_LENGTH_MISMATCH = 0x0002
-
-	/* We want to know if dottedcircle / .notdef glyphs are present in the
-	 * reference, as we may not care so much about other differences in this
-	 * case. */
-	HB_BUFFER_DIFF_FLAG_NOTDEF_PRESENT        = 0x0004
-	HB_BUFFER_DIFF_FLAG_DOTTED_CIRCLE_PRESENT = 0x0008
-
-	/* If the buffers have the same length, we compare them glyph-by-glyph
-	 * and report which aspect(s) of the glyph info/position are different. */
-	HB_BUFFER_DIFF_FLAG_CODEPOINT_MISMATCH   = 0x0010
-	HB_BUFFER_DIFF_FLAG_CLUSTER_MISMATCH     = 0x0020
-	HB_BUFFER_DIFF_FLAG_GLYPH_FLAGS_MISMATCH = 0x0040
-	HB_BUFFER_DIFF_FLAG_POSITION_MISMATCH    = 0x0080
-)
+func TestBufferUTF8Conversion(t *testing.T) {
+	text := "\x1e\U0001d306é水\U0001f600z"
+	expected := []struct {
+		r       rune
+		cluster int
+	}{{0x1e, 0}, {0x1d306, 1}, {0xe9, 5}, {0x6c34, 7}, {0x1f600, 10}, {'z', 14}}
+
+	for _, add := range []func(b *Buffer){
+		func(b *Buffer) { b.AddUTF8([]byte(text), 0, -1) },
+		func(b *Buffer) { b.AddString(text, 0, -1) },
+	} {
+		b := NewBuffer()
+		add(b)
+		assertEqualInt(t, len(expected), len(b.Info))
+		assertEqualInt(t, len(expected), len(b.Pos))
+		for i, exp := range expected {
+			assertEqualInt(t, int(exp.r), int(b.Info[i].codepoint))
+			assertEqualInt(t, exp.cluster, b.Info[i].Cluster)
+		}
+	}
+
+	// context
+	b := NewBuffer()
+	b.AddString(text, 5, 5)
+	assertEqualInt(t, 2, len(b.Info))
+	assertEqualInt(t, 5, b.Info[0].Cluster)
+	assertEqualInt(t, 7, b.Info[1].Cluster)
+	assert(t, string(b.context[0]) == "\U0001d306\x1e")
+	assert(t, string(b.context[1]) == "\U0001f600z")
+}
 
-/**
- * hb_buffer_diff:
- * @buffer: a buffer.
- * @reference: other buffer to compare to.
- * @dottedcircleGlyph: glyph id of U+25CC DOTTED CIRCLE, or (hb_codepont_t) -1.
- * @positionFuzz: allowed absolute difference in position values.
- *
- * If dottedcircleGlyph is (hb_codepoint_t) -1 then #HB_BUFFER_DIFF_FLAG_DOTTED_CIRCLE_PRESENT
- * and #HB_BUFFER_DIFF_FLAG_NOTDEF_PRESENT are never returned.  This should be used by most
- * callers if just comparing two buffers is needed.
- *
- * Since: 1.5.0
- **/
-
-func bufferDiff(buffer, reference *Buffer, dottedcircleGlyph fonts.GID, positionFuzz int32) int {
-	//    if (buffer.content_type != reference.content_type && buffer.len && reference.len){
-	// 	 return HB_BUFFER_DIFF_FLAG_CONTENT_TYPE_MISMATCH;}
-
-	result := bufferDiffFlagEqual
-	contains := dottedcircleGlyph != ^fonts.GID(0)
-
-	count := len(reference.Info)
-
-	if len(buffer.Info) != count {
-		/*
-		 * we can't compare glyph-by-glyph, but we do want to know if there
-		 * are .notdef or dottedcircle glyphs present in the reference buffer
-		 */
-		info := reference.Info
-		for i := 0; i < count; i++ {
-			if contains && info[i].Glyph == dottedcircleGlyph {
-				result |= HB_BUFFER_DIFF_FLAG_DOTTED_CIRCLE_PRESENT
-			}
-			if contains && info[i].Glyph == 0 {
-				result |= HB_BUFFER_DIFF_FLAG_NOTDEF_PRESENT
-			}
+func TestBufferUTF8Validity(t *testing.T) {
+	for _, test := range []struct {
+		text     string
+		expected []rune
+	}{
+		{"abc", []rune("abc")},
+		{"a\x80b", []rune{'a', -1, 'b'}},
+		{"\xc0\x80", []rune{-1, -1}},                  // overlong
+		{"\xed\xa0\x80", []rune{-1, -1, -1}},          // surrogate
+		{"\xf4\x90\x80\x80", []rune{-1, -1, -1, -1}},  // out of range
+		{"\xe6\xb0", []rune{-1, -1}},                  // truncated
+		{"\xef\xbf\xbdx", []rune{0xFFFD, 'x'}},        // valid replacement character
+		{"\xf0\x9f\x98\x80\xf0", []rune{0x1f600, -1}}, // truncated at the end
+	} {
+		b := NewBuffer()
+		b.Replacement = -1
+		b.AddString(test.text, 0, -1)
+		assertEqualInt(t, len(test.expected), len(b.Info))
+		for i, r := range test.expected {
+			assertEqualInt(t, int(r), int(b.Info[i].codepoint))
 		}
-		result |= HB_BUFFER_DIFF_FLAG_LENGTH_MISMATCH
-		return result
 	}
 
-	if count == 0 {
-		return result
+	b := NewBuffer()
+	b.AddString("a\x80", 0, -1)
+	assertEqualInt(t, 0xFFFD, int(b.Info[1].codepoint))
+	b.Clear()
+	assertEqualInt(t, 0xFFFD, int(b.Replacement))
+}
+
+func TestBufferUTF16(t *testing.T) {
+	// a, U+1D306, é, unpaired high surrogate, b, unpaired low surrogate
+	text := []uint16{'a', 0xd834, 0xdf06, 0xe9, 0xd800, 'b', 0xdc00}
+	b := NewBuffer()
+	b.AddUTF16(text, 0, -1)
+	expected := []struct {
+		r       rune
+		cluster int
+	}{{'a', 0}, {0x1d306, 1}, {0xe9, 3}, {0xFFFD, 4}, {'b', 5}, {0xFFFD, 6}}
+	assertEqualInt(t, len(expected), len(b.Info))
+	for i, exp := range expected {
+		assertEqualInt(t, int(exp.r), int(b.Info[i].codepoint))
+		assertEqualInt(t, exp.cluster, b.Info[i].Cluster)
 	}
 
-	bufInfo := buffer.Info
-	refInfo := reference.Info
-	for i := 0; i < count; i++ {
-		if bufInfo[i].codepoint != refInfo[i].codepoint {
-			result |= HB_BUFFER_DIFF_FLAG_CODEPOINT_MISMATCH
-		}
-		if bufInfo[i].Cluster != refInfo[i].Cluster {
-			result |= HB_BUFFER_DIFF_FLAG_CLUSTER_MISMATCH
-		}
-		if (bufInfo[i].Mask & ^refInfo[i].Mask & glyphFlagDefined) != 0 {
-			result |= HB_BUFFER_DIFF_FLAG_GLYPH_FLAGS_MISMATCH
-		}
-		if contains && refInfo[i].Glyph == dottedcircleGlyph {
-			result |= HB_BUFFER_DIFF_FLAG_DOTTED_CIRCLE_PRESENT
-		}
-		if contains && refInfo[i].Glyph == 0 {
-			result |= HB_BUFFER_DIFF_FLAG_NOTDEF_PRESENT
-		}
+	// context
+	b = NewBuffer()
+	b.AddUTF16(text, 3, 1)
+	assertEqualInt(t, 1, len(b.Info))
+	assertEqualInt(t, 3, b.Info[0].Cluster)
+	assert(t, string(b.context[0]) == "\U0001d306a")
+	assert(t, string(b.context[1]) == "�b�")
+}
+
+func TestNotFoundClusters(t *testing.T) {
+	font := NewFont(openFontFile("testdata/perf_reference/fonts/Roboto-Regular.ttf"))
+
+	text := []rune("ab水क्षćd漢")
+	shape := func(notFound fonts.GID) *Buffer {
+		buf := NewBuffer()
+		buf.NotFound = notFound
+		buf.AddRunes(text, 0, -1)
+		buf.Props = SegmentProperties{Direction: LeftToRight, Script: language.Latin}
+		buf.Shape(font, nil)
+		return buf
 	}
 
-	isDifferent := func(a, b int32) bool {
-		d := a - b
-		if d < 0 {
-			d = -d
+	buf := shape(0)
+	got := buf.NotFoundClusters(len(text))
+	expected := []ClusterSpan{{2, 6}, {9, 10}}
+	if len(got) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	for i := range got {
+		if got[i] != expected[i] {
+			t.Fatalf("expected %v, got %v", expected, got)
 		}
-		return d > positionFuzz
 	}
 
-	bufPos := buffer.Pos
-	refPos := reference.Pos
-	for i := 0; i < count; i++ {
-		if isDifferent(bufPos[i].XAdvance, refPos[i].XAdvance) ||
-			isDifferent(bufPos[i].YAdvance, refPos[i].YAdvance) ||
-			isDifferent(bufPos[i].XOffset, refPos[i].XOffset) ||
-			isDifferent(bufPos[i].YOffset, refPos[i].YOffset) {
-			result |= HB_BUFFER_DIFF_FLAG_POSITION_MISMATCH
-			break
-		}
+	const notFound = 5
+	buf = shape(notFound)
+	for _, info := range buf.Info {
+		assert(t, info.Glyph != 0)
 	}
+	assertEqualInt(t, notFound, int(buf.Info[2].Glyph))
+	assertEqualInt(t, 2, len(buf.NotFoundClusters(len(text))))
+
+	buf = shape(0)
+	buf.Info = buf.Info[:2]
+	assertEqualInt(t, 0, len(buf.NotFoundClusters(2)))
+}
 
-	return result
+func TestInvisibleGlyph(t *testing.T) {
+	// a non zero Invisible glyph replaces the default ignorables,
+	// instead of removing them
+	font := NewFont(openFontFile("testdata/perf_reference/fonts/Roboto-Regular.ttf"))
+	buf := NewBuffer()
+	buf.Invisible = 7
+	buf.AddRunes([]rune("a​b"), 0, -1)
+	buf.GuessSegmentProperties()
+	buf.Shape(font, nil)
+	assertEqualInt(t, 3, len(buf.Info))
+	assertEqualInt(t, 7, int(buf.Info[1].Glyph))
+	assertEqualInt(t, 0, int(buf.Pos[1].XAdvance))
 }
@@ -0,0 +1,111 @@
+package harfbuzz
+
+import "fmt"
+
+// TraceStep identifies a step of the Opentype shaping process,
+// reported to `Buffer.Tracer`.
+type TraceStep uint8
+
+const (
+	// TracePreprocess is the text preprocessing done by the complex shaper.
+	TracePreprocess TraceStep = iota
+	// TraceNormalize is the normalization pass (decomposition, reordering and composition).
+	TraceNormalize
+	// TraceGSUB is the application of one GSUB lookup.
+	TraceGSUB
+	// TraceMorx is the application of one subtable of an AAT 'morx' chain.
+	TraceMorx
+	// TracePause is a stage of the complex shaper, run between
+	// two groups of GSUB or GPOS lookups.
+	TracePause
+	// TraceGPOS is the application of one GPOS lookup.
+	TraceGPOS
+	// TraceKerx is the application of one subtable of an AAT 'kerx' table.
+	TraceKerx
+	// TracePostprocess is the glyph postprocessing done by the complex shaper.
+	TracePostprocess
+)
+
+func (ts TraceStep) String() string {
+	switch ts {
+	case TracePreprocess:
+		return "preprocess"
+	case TraceNormalize:
+		return "normalize"
+	case TraceGSUB:
+		return "GSUB lookup"
+	case TraceMorx:
+		return "morx subtable"
+	case TracePause:
+		return "pause"
+	case TraceGPOS:
+		return "GPOS lookup"
+	case TraceKerx:
+		return "kerx subtable"
+	case TracePostprocess:
+		return "postprocess"
+	default:
+		return fmt.Sprintf("<invalid step %d>", ts)
+	}
+}
+
+// TraceEvent is sent to `Buffer.Tracer` before and after each shaping step.
+type TraceEvent struct {
+	// Info and Pos are copies of the buffer content.
+	// Note that Pos is only meaningfull for the positionning steps
+	// (TraceGPOS and TraceKerx).
+	Info []GlyphInfo
+	Pos  []GlyphPosition
+
+	// Index is the lookup index for GSUB and GPOS steps,
+	// the subtable index for 'morx' and 'kerx' steps,
+	// and the stage index for pauses.
+	// It is 0 for the other steps.
+	Index int
+
+	Step TraceStep
+	// Start is true before the step is applied,
+	// false after.
+	Start bool
+}
+
+func (ev TraceEvent) String() string {
+	verb := "end"
+	if ev.Start {
+		verb = "start"
+	}
+	switch ev.Step {
+	case TraceGSUB, TraceMorx, TracePause, TraceGPOS, TraceKerx:
+		return fmt.Sprintf("%s %s %d", verb, ev.Step, ev.Index)
+	default:
+		return fmt.Sprintf("%s %s", verb, ev.Step)
+	}
+}
+
+// isLookup returns true for the steps which may be skipped,
+// that is the application of a lookup or an AAT subtable.
+func (ts TraceStep) isLookup() bool {
+	switch ts {
+	case TraceGSUB, TraceMorx, TraceGPOS, TraceKerx:
+		return true
+	default:
+		return false
+	}
+}
+
+// trace calls the tracer, if any, and returns
+// false if the step should be skipped, which is only
+// possible for lookups (see `Buffer.Tracer`).
+func (b *Buffer) trace(step TraceStep, index int, start bool) bool {
+	if b.Tracer == nil {
+		return true
+	}
+	ev := TraceEvent{
+		Info:  append([]GlyphInfo(nil), b.Info...),
+		Pos:   append([]GlyphPosition(nil), b.Pos...),
+		Index: index,
+		Step:  step,
+		Start: start,
+	}
+	return b.Tracer(ev) || !start || !step.isLookup()
+}
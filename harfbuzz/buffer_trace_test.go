@@ -0,0 +1,84 @@
+package harfbuzz
+
+import "testing"
+
+func TestTracer(t *testing.T) {
+	font := NewFont(openFontFile("testdata/perf_reference/fonts/NotoSansDevanagari-Regular.ttf"))
+	const text = "क्षत्रिय"
+
+	var events []TraceEvent
+	ref := shapeRunes(font, []rune(text), 0, func(ev TraceEvent) bool {
+		events = append(events, ev)
+		return true
+	})
+
+	counts := map[TraceStep]int{}
+	for i, ev := range events {
+		if ev.Start {
+			// each start is immediately followed by its end
+			if i+1 >= len(events) || events[i+1].Start || events[i+1].Step != ev.Step || events[i+1].Index != ev.Index {
+				t.Fatalf("unmatched event %s", ev)
+			}
+			counts[ev.Step]++
+		}
+	}
+	for _, step := range []TraceStep{TracePreprocess, TraceNormalize, TraceGSUB, TracePause, TraceGPOS, TracePostprocess} {
+		if counts[step] == 0 {
+			t.Fatalf("missing step %s", step)
+		}
+	}
+	if counts[TraceMorx] != 0 || counts[TraceKerx] != 0 {
+		t.Fatal("unexpected AAT steps")
+	}
+	last := events[len(events)-1]
+	if len(last.Info) != len(ref.Info) {
+		t.Fatalf("unexpected final snapshot %d", len(last.Info))
+	}
+
+	// skipping GSUB lookups disables the ligatures and conjuncts
+	noGSUB := shapeRunes(font, []rune(text), 0, func(ev TraceEvent) bool { return ev.Step != TraceGSUB })
+	if len(noGSUB.Info) <= len(ref.Info) {
+		t.Fatalf("expected more glyphs without GSUB, got %d (ref %d)", len(noGSUB.Info), len(ref.Info))
+	}
+
+	// the other steps can't be skipped
+	noOther := shapeRunes(font, []rune(text), 0, func(ev TraceEvent) bool { return ev.Step.isLookup() })
+	if len(noOther.Info) != len(ref.Info) {
+		t.Fatalf("unexpected output %v (ref %v)", noOther.Info, ref.Info)
+	}
+	for i := range ref.Info {
+		if noOther.Info[i].Glyph != ref.Info[i].Glyph || noOther.Pos[i] != ref.Pos[i] {
+			t.Fatalf("unexpected output %v (ref %v)", noOther.Info, ref.Info)
+		}
+	}
+
+	events = events[:0]
+	noLookup := shapeRunes(font, []rune(text), 0, func(ev TraceEvent) bool {
+		events = append(events, ev)
+		return false
+	})
+	for _, ev := range events {
+		if !ev.Start && ev.Step.isLookup() {
+			t.Fatalf("unexpected end event for skipped %s", ev)
+		}
+	}
+	for _, info := range noLookup.Info {
+		if info.Glyph == 0 {
+			t.Fatalf("unmapped glyph in %v", noLookup.Info)
+		}
+	}
+}
+
+func TestTracerAAT(t *testing.T) {
+	font := NewFont(openFontFile("testdata/fonts/aat-morx.ttf"))
+	var morx int
+	shapeRunes(font, []rune("abc"), 0, func(ev TraceEvent) bool {
+		if ev.Step == TraceMorx && ev.Start {
+			morx++
+		}
+		return true
+	})
+	if morx == 0 {
+		t.Fatal("expected morx steps")
+	}
+}
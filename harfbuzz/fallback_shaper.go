@@ -6,6 +6,9 @@ var _ shaper = shaperFallback{}
 
 // shaperFallback implements a naive shaper, which does the minimum,
 // without requiring advanced Opentype font features.
+// It composes or decomposes the characters according to the font cmap,
+// applies the kerning provided by faces implementing `FaceKerning`
+// and positions the marks using their combining class.
 type shaperFallback struct{}
 
 func (shaperFallback) kind() shaperKind { return skFallback }
@@ -14,7 +17,17 @@ func (shaperFallback) compile(props SegmentProperties, userFeatures []Feature) {
 }
 
 func (shaperFallback) shape(font *Font, buffer *Buffer, _ []Feature) {
-	space, hasSpace := font.face.NominalGlyph(' ')
+	buffer.scratchFlags = bsfDefault
+	buffer.setUnicodeProps()
+	buffer.formClusters()
+
+	// the normalization and mark positioning only use
+	// the unicode functions of the default shaper
+	plan := otShapePlan{shaper: complexShaperDefault{}, props: buffer.Props}
+
+	otShapeNormalize(&plan, buffer, font) // also sets the glyphs
+	synthesizeGlyphClasses(buffer)
+	fallbackMarkPositionRecategorizeMarks(buffer)
 
 	buffer.clearPositions()
 
@@ -22,21 +35,71 @@ func (shaperFallback) shape(font *Font, buffer *Buffer, _ []Feature) {
 	info := buffer.Info
 	pos := buffer.Pos
 	for i := range info {
-		if hasSpace && uni.isDefaultIgnorable(info[i].codepoint) {
-			info[i].Glyph = space
-			pos[i].XAdvance = 0
-			pos[i].YAdvance = 0
-		} else {
-			info[i].Glyph, _ = font.face.NominalGlyph(info[i].codepoint)
-			pos[i].XAdvance, pos[i].YAdvance = font.GlyphAdvanceForDirection(info[i].Glyph, direction)
-			pos[i].XOffset, pos[i].YOffset = font.subtractGlyphOriginForDirection(info[i].Glyph, direction,
-				pos[i].XOffset, pos[i].YOffset)
+		pos[i].XAdvance, pos[i].YAdvance = font.GlyphAdvanceForDirection(info[i].Glyph, direction)
+		pos[i].XOffset, pos[i].YOffset = font.subtractGlyphOriginForDirection(info[i].Glyph, direction,
+			pos[i].XOffset, pos[i].YOffset)
+	}
+	if buffer.scratchFlags&bsfHasSpaceFallback != 0 {
+		fallbackSpaces(font, buffer)
+	}
+
+	if kerning, ok := font.face.(FaceKerning); ok {
+		// kerning is applied in visual order
+		if direction.isBackward() {
+			buffer.Reverse()
+		}
+		fallbackKern(kerning, font, buffer)
+		if direction.isBackward() {
+			buffer.Reverse()
 		}
 	}
 
+	zeroWidthDefaultIgnorables(buffer)
+	fallbackMarkPosition(&plan, font, buffer, false)
+
 	if direction.isBackward() {
 		buffer.Reverse()
 	}
 
-	buffer.safeToBreakAll()
+	hideDefaultIgnorables(buffer, font)
+	propagateFlags(buffer)
+}
+
+// fallbackKern applies the kerning between consecutive base glyphs,
+// skipping marks and default ignorables
+func fallbackKern(kerning FaceKerning, font *Font, buffer *Buffer) {
+	horizontal := buffer.Props.Direction.isHorizontal()
+	info := buffer.Info
+	pos := buffer.Pos
+	i := -1 // previous base glyph
+	for j := range info {
+		if info[j].isMark() || info[j].isDefaultIgnorable() {
+			continue
+		}
+		if i == -1 {
+			i = j
+			continue
+		}
+
+		rawKern := kerning.KernPair(info[i].Glyph, info[j].Glyph)
+		if rawKern != 0 {
+			if horizontal {
+				kern := font.emScaleX(rawKern)
+				kern1 := kern >> 1
+				kern2 := kern - kern1
+				pos[i].XAdvance += kern1
+				pos[j].XAdvance += kern2
+				pos[j].XOffset += kern2
+			} else {
+				kern := font.emScaleY(rawKern)
+				kern1 := kern >> 1
+				kern2 := kern - kern1
+				pos[i].YAdvance += kern1
+				pos[j].YAdvance += kern2
+				pos[j].YOffset += kern2
+			}
+			buffer.unsafeToBreak(i, j+1)
+		}
+		i = j
+	}
 }
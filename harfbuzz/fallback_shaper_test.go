@@ -1,9 +1,11 @@
 package harfbuzz
 
 import (
+	"os"
 	"testing"
 
 	"github.com/benoitkugler/textlayout/fonts"
+	"github.com/benoitkugler/textlayout/fonts/type1"
 )
 
 // ported from harfbuzz/test/api/test-shape.c  Copyright © 2011  Google, Inc. Behdad Esfahbod
@@ -73,3 +75,118 @@ func TestShape(t *testing.T) {
 	font.XScale = 100
 	testFont(t, font)
 }
+
+// markFace supports 'e', 'é' (only when decomposed) and 'V'
+type markFace struct {
+	dummyFace
+}
+
+func (f markFace) LoadMetrics() fonts.FaceMetrics { return f }
+
+func (markFace) NominalGlyph(ch rune) (fonts.GID, bool) {
+	switch ch {
+	case 'e':
+		return 1, true
+	case 0x0301:
+		return 2, true
+	case 'V':
+		return 3, true
+	}
+	return 0, false
+}
+
+func (markFace) HorizontalAdvance(gid fonts.GID) float32 {
+	if gid == 2 {
+		return 200
+	}
+	return 500
+}
+
+func (markFace) GlyphExtents(gid fonts.GID, _, _ uint16) (fonts.GlyphExtents, bool) {
+	switch gid {
+	case 1:
+		return fonts.GlyphExtents{XBearing: 50, YBearing: 500, Width: 400, Height: -500}, true
+	case 2:
+		return fonts.GlyphExtents{XBearing: 0, YBearing: 100, Width: 200, Height: -100}, true
+	}
+	return fonts.GlyphExtents{}, false
+}
+
+type kernMarkFace struct {
+	markFace
+}
+
+func (f kernMarkFace) LoadMetrics() fonts.FaceMetrics { return f }
+
+func (kernMarkFace) KernPair(left, right fonts.GID) int16 {
+	if left == 3 && right == 1 {
+		return -100
+	}
+	return 0
+}
+
+func TestShapeFallbackMarks(t *testing.T) {
+	for _, face := range []Face{markFace{}, kernMarkFace{}} {
+		font := NewFont(face)
+		buffer := shapeRunes(font, []rune("Vé"), 0, nil)
+
+		// 'é' is decomposed, and the mark positioned above the base
+		assertEqualInt(t, len(buffer.Info), 3)
+		for i, glyph := range []fonts.GID{3, 1, 2} {
+			assertEqualInt(t, int(buffer.Info[i].Glyph), int(glyph))
+		}
+		assertEqualInt(t, buffer.Info[1].Cluster, 1)
+		assertEqualInt(t, buffer.Info[2].Cluster, 1)
+		assertEqualInt(t, int(buffer.Pos[2].XAdvance), 0)
+		assert(t, buffer.Pos[2].YOffset > 0)
+
+		// the kerning is applied between the bases
+		if _, kerned := face.(FaceKerning); kerned {
+			assertEqualInt(t, int(totalAdvance(buffer)), 500+500-100)
+			assert(t, buffer.Info[1].Mask&GlyphUnsafeToBreak != 0)
+		} else {
+			assertEqualInt(t, int(totalAdvance(buffer)), 500+500)
+		}
+	}
+}
+
+func TestShapeFallbackType1(t *testing.T) {
+	f, err := os.Open("../fonts/type1/test/c0419bt_.pfb")
+	check(err)
+	defer f.Close()
+	face, err := type1.Parse(f)
+	check(err)
+
+	shape := func(text string) *Buffer {
+		return shapeRunes(NewFont(face), []rune(text), 0, nil)
+	}
+
+	// the font only has the precomposed glyph
+	eacute, _ := face.NominalGlyph('é')
+	buffer := shape("AVé")
+	assertEqualInt(t, len(buffer.Info), 3)
+	assertEqualInt(t, int(buffer.Info[2].Glyph), int(eacute))
+	assertEqualInt(t, buffer.Info[2].Cluster, 2)
+	plain := totalAdvance(buffer)
+
+	a, err := os.Open("../fonts/type1/test/Times-Bold.afm")
+	check(err)
+	defer a.Close()
+	afm, err := type1.ParseAFMFile(a)
+	check(err)
+	face.LoadAFMKerning(afm)
+
+	buffer = shape("AVé")
+	assertEqualInt(t, int(totalAdvance(buffer)), int(plain)-145-100)
+
+	// the kerning is inherited by sub fonts
+	sub := NewFont(face).SubFont(FontFuncs{})
+	buffer = shapeRunes(sub, []rune("AVé"), 0, nil)
+	assertEqualInt(t, int(totalAdvance(buffer)), int(plain)-145-100)
+
+	sub = NewFont(face).SubFont(FontFuncs{
+		KernPair: func(parent Face, left, right fonts.GID) int16 { return 0 },
+	})
+	buffer = shapeRunes(sub, []rune("AVé"), 0, nil)
+	assertEqualInt(t, int(totalAdvance(buffer)), int(plain))
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/benoitkugler/textlayout/fonts"
 	"github.com/benoitkugler/textlayout/fonts/truetype"
 	tt "github.com/benoitkugler/textlayout/fonts/truetype"
+	"github.com/benoitkugler/textlayout/fonts/type1"
 	"github.com/benoitkugler/textlayout/graphite"
 )
 
@@ -43,6 +44,30 @@ type FaceOpentype interface {
 	VariationGlyph(ch, varSelector rune) (fonts.GID, bool)
 }
 
+var _ FaceNamed = (*truetype.Font)(nil)
+
+// FaceNamed is an optional interface, implemented by faces
+// providing the 'name' table.
+// It is used by `Font.Name` to resolve the names referenced by the layout tables.
+type FaceNamed interface {
+	// NameTable returns the 'name' table of the font.
+	NameTable() truetype.TableName
+}
+
+var _ FaceKerning = (*type1.Font)(nil)
+
+// FaceKerning is an optional interface, implemented by faces
+// providing pair kerning outside of the Opentype layout tables,
+// like the kerning pairs of an AFM file (see `type1.Font.LoadAFMKerning`),
+// or a legacy 'kern' subtable (see `truetype.SimpleKerns`).
+// It is used by the fallback shaper, chosen for faces which
+// do not implement `FaceOpentype`.
+type FaceKerning interface {
+	// KernPair returns the kerning to apply between the two glyphs, in font units,
+	// or 0 if the pair is not kerned.
+	KernPair(left, right fonts.GID) int16
+}
+
 // Font is used internally as a light wrapper around the provided Face.
 //
 // While a font face is generally the in-memory representation of a static font file,
@@ -53,6 +78,15 @@ type FaceOpentype interface {
 // Font are constructed with `NewFont` and adjusted by accessing the fields
 // XPpem, YPpem, Ptem,XScale, YScale and with the method `SetVarCoordsDesign` for
 // variable fonts.
+//
+// A Font may be used by several goroutines at the same time for shaping : the layout tables
+// and accelerators are built in `NewFont` and never modified, and the plan cache
+// is safe for concurrent use. This requires the face to support concurrent reads,
+// which is the case of `*truetype.Font`. However, the font must not be adjusted
+// (fields, `SetVarCoordsDesign`) while it is used by other goroutines, and since
+// `Buffer.ShapeJustify` temporarily modifies the face when a variation axis is used,
+// it must not be called concurrently with other uses of the font in this case. Also note that a `Buffer` is not
+// safe for concurrent use.
 type Font struct {
 	face Face
 
@@ -80,6 +114,11 @@ type Font struct {
 	// Is is used to select bitmap sizes and to perform some Opentype
 	// positionning.
 	XPpem, YPpem uint16
+
+	// PlanCache stores the shaping plans used by `Buffer.Shape`.
+	// `NewFont` creates a new cache for each font, but fonts
+	// may share the same cache. If nil, plans are not cached.
+	PlanCache *ShapePlanCache
 }
 
 // NewFont constructs a new font object from the specified face.
@@ -99,6 +138,7 @@ func NewFont(face Face) *Font {
 	font.faceUpem = Position(font.face.Upem())
 	font.XScale = font.faceUpem
 	font.YScale = font.faceUpem
+	font.PlanCache = NewShapePlanCache(0)
 
 	if opentypeFace, ok := face.(FaceOpentype); ok {
 		lt := opentypeFace.LayoutTables()
@@ -340,9 +380,16 @@ func (f *Font) ExtentsForDirection(direction Direction) fonts.FontExtents {
 }
 
 // LineMetric fetches the given metric, applying potential variations
-// and scaling.
+// and scaling. Horizontal quantities (like `fonts.SubscriptEmXOffset` or
+// `fonts.HorizontalCaretRun`) use the X scale, the others the Y scale.
 func (f *Font) LineMetric(metric fonts.LineMetric) (int32, bool) {
 	m, ok := f.face.LineMetric(metric)
+	switch metric {
+	case fonts.SuperscriptEmXSize, fonts.SuperscriptEmXOffset, fonts.SubscriptEmXSize, fonts.SubscriptEmXOffset,
+		fonts.HorizontalCaretRun, fonts.HorizontalCaretOffset, fonts.VerticalCaretRise,
+		fonts.VerticalClippingAscent, fonts.VerticalClippingDescent:
+		return f.emScalefX(m), ok
+	}
 	return f.emScalefY(m), ok
 }
 
@@ -390,12 +437,20 @@ func (f *Font) GetOTGlyphClass(glyph fonts.GID) uint32 {
 }
 
 // GetOTLigatureCarets fetches a list of the caret positions defined for a ligature glyph in the GDEF
-// table of the font (or nil if not found).
+// table of the font, or, as a fallback, in the AAT 'lcar' table (or nil if not found).
 func (f *Font) GetOTLigatureCarets(direction Direction, glyph fonts.GID) []Position {
 	if f.otTables == nil {
 		return nil
 	}
 
+	if out := f.getGDEFLigatureCarets(direction, glyph); len(out) != 0 {
+		return out
+	}
+
+	return f.getLcarLigatureCarets(direction, glyph)
+}
+
+func (f *Font) getGDEFLigatureCarets(direction Direction, glyph fonts.GID) []Position {
 	varStore := f.otTables.GDEF.VariationStore
 
 	list := f.otTables.GDEF.LigatureCaretList
@@ -416,6 +471,79 @@ func (f *Font) GetOTLigatureCarets(direction Direction, glyph fonts.GID) []Posit
 	return out
 }
 
+func (f *Font) getLcarLigatureCarets(direction Direction, glyph fonts.GID) []Position {
+	lcar := f.otTables.Lcar
+	carets := lcar.LigatureCarets(glyph)
+	if carets == nil {
+		return nil
+	}
+
+	out := make([]Position, len(carets))
+	for i, c := range carets {
+		if lcar.IsControlPoint {
+			x, y, _ := f.getGlyphContourPointForOrigin(glyph, uint16(c), direction)
+			if direction.isHorizontal() {
+				out[i] = x
+			} else {
+				out[i] = y
+			}
+		} else if direction.isHorizontal() {
+			out[i] = f.emScaleX(c)
+		} else {
+			out[i] = f.emScaleY(c)
+		}
+	}
+	return out
+}
+
+// GetOpticalBounds fetches the optical bounds of `glyph`, as defined by the AAT 'opbd' table,
+// for a text segment in the given direction. It returns false if the font has no such information.
+// The returned values are the distances the glyph may protrude beyond its start
+// and end edges (left and right for horizontal text, top and bottom for vertical text).
+// They are positive when the optical edge is inside the glyph advance.
+func (f *Font) GetOpticalBounds(direction Direction, glyph fonts.GID) (start, end Position, ok bool) {
+	if f.otTables == nil {
+		return 0, 0, false
+	}
+
+	opbd := f.otTables.Opbd
+	bounds, ok := opbd.OpticalBounds(glyph)
+	if !ok {
+		return 0, 0, false
+	}
+
+	isHorizontal := direction.isHorizontal()
+	if !opbd.IsControlPoint {
+		if isHorizontal {
+			return f.emScaleX(bounds.Left), f.emScaleX(bounds.Right), true
+		}
+		return f.emScaleY(bounds.Top), f.emScaleY(bounds.Bottom), true
+	}
+
+	// the bounds are given by the position of the control points
+	startPoint, endPoint := bounds.Left, bounds.Right
+	if !isHorizontal {
+		startPoint, endPoint = bounds.Top, bounds.Bottom
+	}
+	if uint16(startPoint) != 0xFFFF {
+		x, y, _ := f.getGlyphContourPointForOrigin(glyph, uint16(startPoint), direction)
+		if isHorizontal {
+			start = x
+		} else {
+			start = -y // y axis points upward
+		}
+	}
+	if uint16(endPoint) != 0xFFFF {
+		x, y, _ := f.getGlyphContourPointForOrigin(glyph, uint16(endPoint), direction)
+		if isHorizontal {
+			end = f.GlyphHAdvance(glyph) - x
+		} else {
+			end = y - f.getGlyphVAdvance(glyph) // vertical advances are negative
+		}
+	}
+	return start, end, true
+}
+
 // interpreted the CaretValue according to its format
 func (f *Font) getCaretValue(caret truetype.CaretValue, direction Direction, glyph fonts.GID, varStore truetype.VariationStore) Position {
 	switch caret := caret.(type) {
@@ -0,0 +1,178 @@
+package harfbuzz
+
+import (
+	"github.com/benoitkugler/textlayout/fonts"
+	"github.com/benoitkugler/textlayout/fonts/truetype"
+)
+
+// ported from src/hb-font.cc Copyright © 2009  Red Hat, Inc., 2012  Google, Inc.  Behdad Esfahbod
+
+// FontFuncs stores optional callbacks overriding the glyph lookups
+// and metrics of a font. See `Font.SubFont`.
+//
+// Each callback is given the face of the parent font, so that
+// it may delegate to it, and returns values in font units.
+// The nil callbacks are inherited from the parent.
+type FontFuncs struct {
+	NominalGlyph      func(parent Face, ch rune) (fonts.GID, bool)
+	VariationGlyph    func(parent Face, ch, varSelector rune) (fonts.GID, bool)
+	HorizontalAdvance func(parent Face, gid fonts.GID) float32
+	VerticalAdvance   func(parent Face, gid fonts.GID) float32
+	GlyphHOrigin      func(parent Face, gid fonts.GID) (x, y int32, found bool)
+	GlyphVOrigin      func(parent Face, gid fonts.GID) (x, y int32, found bool)
+	GlyphExtents      func(parent Face, gid fonts.GID, xPpem, yPpem uint16) (fonts.GlyphExtents, bool)
+	GlyphName         func(parent Face, gid fonts.GID) string
+	// KernPair is only used for faces which are not `FaceOpentype` (see `FaceKerning`).
+	KernPair func(parent Face, left, right fonts.GID) int16
+}
+
+// SubFont returns a child font, whose glyph lookups and metrics
+// are given by `funcs`, falling back to `f` for the nil callbacks.
+//
+// The child starts with the scale, ppem and point size of `f`, which may
+// then be changed independently. It shares the layout tables, the lookup accelerators
+// and the plan cache of `f`, so that creating a sub font is cheap.
+//
+// Note that the returned font `Face` is a wrapper around the face of `f`.
+func (f *Font) SubFont(funcs FontFuncs) *Font {
+	child := *f
+	sub := &subFace{parent: f.face, funcs: funcs}
+	if ot, ok := f.face.(FaceOpentype); ok {
+		child.face = &subFaceOpentype{subFace: sub, parentOT: ot}
+	} else if _, ok := f.face.(FaceKerning); ok || funcs.KernPair != nil {
+		child.face = &subFaceKerning{subFace: sub}
+	} else {
+		child.face = sub
+	}
+	return &child
+}
+
+// subFace implements Face by applying
+// the overrides from `funcs` to `parent`.
+type subFace struct {
+	parent Face
+	funcs  FontFuncs
+}
+
+var (
+	_ FaceOpentype = (*subFaceOpentype)(nil)
+	_ FaceNamed    = (*subFace)(nil)
+	_ FaceKerning  = (*subFaceKerning)(nil)
+)
+
+func (s *subFace) Upem() uint16 { return s.parent.Upem() }
+
+func (s *subFace) GlyphName(gid fonts.GID) string {
+	if s.funcs.GlyphName != nil {
+		return s.funcs.GlyphName(s.parent, gid)
+	}
+	return s.parent.GlyphName(gid)
+}
+
+func (s *subFace) LineMetric(metric fonts.LineMetric) (float32, bool) {
+	return s.parent.LineMetric(metric)
+}
+
+func (s *subFace) FontHExtents() (fonts.FontExtents, bool) { return s.parent.FontHExtents() }
+
+func (s *subFace) FontVExtents() (fonts.FontExtents, bool) { return s.parent.FontVExtents() }
+
+func (s *subFace) NominalGlyph(ch rune) (fonts.GID, bool) {
+	if s.funcs.NominalGlyph != nil {
+		return s.funcs.NominalGlyph(s.parent, ch)
+	}
+	return s.parent.NominalGlyph(ch)
+}
+
+func (s *subFace) HorizontalAdvance(gid fonts.GID) float32 {
+	if s.funcs.HorizontalAdvance != nil {
+		return s.funcs.HorizontalAdvance(s.parent, gid)
+	}
+	return s.parent.HorizontalAdvance(gid)
+}
+
+func (s *subFace) VerticalAdvance(gid fonts.GID) float32 {
+	if s.funcs.VerticalAdvance != nil {
+		return s.funcs.VerticalAdvance(s.parent, gid)
+	}
+	return s.parent.VerticalAdvance(gid)
+}
+
+func (s *subFace) GlyphHOrigin(gid fonts.GID) (x, y int32, found bool) {
+	if s.funcs.GlyphHOrigin != nil {
+		return s.funcs.GlyphHOrigin(s.parent, gid)
+	}
+	return s.parent.GlyphHOrigin(gid)
+}
+
+func (s *subFace) GlyphVOrigin(gid fonts.GID) (x, y int32, found bool) {
+	if s.funcs.GlyphVOrigin != nil {
+		return s.funcs.GlyphVOrigin(s.parent, gid)
+	}
+	return s.parent.GlyphVOrigin(gid)
+}
+
+func (s *subFace) GlyphExtents(gid fonts.GID, xPpem, yPpem uint16) (fonts.GlyphExtents, bool) {
+	if s.funcs.GlyphExtents != nil {
+		return s.funcs.GlyphExtents(s.parent, gid, xPpem, yPpem)
+	}
+	return s.parent.GlyphExtents(gid, xPpem, yPpem)
+}
+
+// NameTable returns the table of the parent, or an empty table
+// if the parent does not implement FaceNamed
+func (s *subFace) NameTable() truetype.TableName {
+	if named, ok := s.parent.(FaceNamed); ok {
+		return named.NameTable()
+	}
+	return nil
+}
+
+// subFaceOpentype is used when the parent is a FaceOpentype
+type subFaceOpentype struct {
+	*subFace
+	parentOT FaceOpentype
+}
+
+func (s *subFaceOpentype) Variations() truetype.TableFvar { return s.parentOT.Variations() }
+
+func (s *subFaceOpentype) SetVarCoordinates(coords []float32) {
+	s.parentOT.SetVarCoordinates(coords)
+}
+
+func (s *subFaceOpentype) VarCoordinates() []float32 { return s.parentOT.VarCoordinates() }
+
+func (s *subFaceOpentype) NormalizeVariations(coords []float32) []float32 {
+	return s.parentOT.NormalizeVariations(coords)
+}
+
+func (s *subFaceOpentype) IsGraphite() (*truetype.Font, bool) { return s.parentOT.IsGraphite() }
+
+func (s *subFaceOpentype) LayoutTables() truetype.LayoutTables { return s.parentOT.LayoutTables() }
+
+func (s *subFaceOpentype) GetGlyphContourPoint(glyph fonts.GID, pointIndex uint16) (x, y int32, ok bool) {
+	return s.parentOT.GetGlyphContourPoint(glyph, pointIndex)
+}
+
+func (s *subFaceOpentype) VariationGlyph(ch, varSelector rune) (fonts.GID, bool) {
+	if s.funcs.VariationGlyph != nil {
+		return s.funcs.VariationGlyph(s.parent, ch, varSelector)
+	}
+	return s.parentOT.VariationGlyph(ch, varSelector)
+}
+
+// subFaceKerning is used when the parent is a FaceKerning,
+// or when the KernPair callback is provided
+type subFaceKerning struct {
+	*subFace
+}
+
+func (s *subFaceKerning) KernPair(left, right fonts.GID) int16 {
+	if s.funcs.KernPair != nil {
+		return s.funcs.KernPair(s.parent, left, right)
+	}
+	if kerning, ok := s.parent.(FaceKerning); ok {
+		return kerning.KernPair(left, right)
+	}
+	return 0
+}
@@ -0,0 +1,80 @@
+package harfbuzz
+
+import (
+	"testing"
+
+	"github.com/benoitkugler/textlayout/fonts"
+)
+
+func TestSubFont(t *testing.T) {
+	parent := NewFont(openFontFile("testdata/perf_reference/fonts/Roboto-Regular.ttf"))
+	glyphA, _ := parent.face.NominalGlyph('A')
+	glyph1, _ := parent.face.NominalGlyph('1')
+	glyph0, _ := parent.face.NominalGlyph('0')
+
+	const tabular = 600
+	child := parent.SubFont(FontFuncs{
+		NominalGlyph: func(parent Face, ch rune) (fonts.GID, bool) {
+			if ch == 0xE000 { // PUA icon
+				return parent.NominalGlyph('A')
+			}
+			return parent.NominalGlyph(ch)
+		},
+		HorizontalAdvance: func(parent Face, gid fonts.GID) float32 {
+			if gid == glyph0 || gid == glyph1 {
+				return tabular
+			}
+			return parent.HorizontalAdvance(gid)
+		},
+	})
+
+	// layout tables are shared
+	assert(t, child.otTables == parent.otTables)
+	_, isOT := child.face.(FaceOpentype)
+	assert(t, isOT)
+
+	buf := shapeRunes(parent, []rune{0xE000, '1'}, 0, nil)
+	assert(t, buf.Info[0].Glyph == 0)
+	assertEqualInt(t, int(buf.Pos[1].XAdvance), int(parent.GlyphHAdvance(glyph1)))
+
+	buf = shapeRunes(child, []rune{0xE000, '1', '0'}, 0, nil)
+	assert(t, buf.Info[0].Glyph == glyphA)
+	assertEqualInt(t, int(buf.Pos[0].XAdvance), int(parent.GlyphHAdvance(glyphA)))
+	assertEqualInt(t, int(buf.Pos[1].XAdvance), tabular)
+	assertEqualInt(t, int(buf.Pos[2].XAdvance), tabular)
+
+	// the child scale is independent from the parent one
+	child.XScale = 2 * parent.XScale
+	buf = shapeRunes(child, []rune{'1'}, 0, nil)
+	assertEqualInt(t, int(buf.Pos[0].XAdvance), 2*tabular)
+	assertEqualInt(t, int(parent.GlyphHAdvance(glyph1)), int(parent.face.HorizontalAdvance(glyph1)))
+
+	// sub fonts may be nested
+	grandChild := child.SubFont(FontFuncs{
+		HorizontalAdvance: func(parent Face, gid fonts.GID) float32 {
+			return parent.HorizontalAdvance(gid) + 10
+		},
+	})
+	buf = shapeRunes(grandChild, []rune{0xE000, '1'}, 0, nil)
+	assert(t, buf.Info[0].Glyph == glyphA)
+	assertEqualInt(t, int(buf.Pos[1].XAdvance), 2*(tabular+10))
+}
+
+func TestSubFontNonOpentype(t *testing.T) {
+	child := NewFont(dummyFace{}).SubFont(FontFuncs{
+		NominalGlyph: func(parent Face, ch rune) (fonts.GID, bool) { return fonts.GID(ch), true },
+	})
+	_, isOT := child.face.(FaceOpentype)
+	assert(t, !isOT)
+	_, isKerning := child.face.(FaceKerning)
+	assert(t, !isKerning)
+	assert(t, child.hasGlyph('a'))
+	assertEqualInt(t, int(child.face.Upem()), 1000)
+
+	child = NewFont(dummyFace{}).SubFont(FontFuncs{
+		KernPair: func(parent Face, left, right fonts.GID) int16 { return -int16(left + right) },
+	})
+	kerning, isKerning := child.face.(FaceKerning)
+	assert(t, isKerning)
+	assertEqualInt(t, int(kerning.KernPair(1, 2)), -3)
+}
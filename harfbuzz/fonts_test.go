@@ -266,3 +266,85 @@ func TestLigCarets(t *testing.T) {
 		t.Fatalf("for glyph %d, expected %v, got %v", 1023, expected, carets)
 	}
 }
+
+func TestLigCaretsLcar(t *testing.T) {
+	// the font has no GDEF table, so that the 'lcar' table is used
+	face := openFontFile("../fonts/truetype/testdata/ToyLcarOpbd.ttf")
+	font := NewFont(face)
+	font.XScale, font.YScale = int32(face.Upem())*2, int32(face.Upem())*4
+
+	if L := len(font.GetOTLigatureCarets(LeftToRight, 3)); L != 0 {
+		t.Fatalf("for glyph %d, expected %d, got %d", 3, 0, L)
+	}
+
+	carets := font.GetOTLigatureCarets(LeftToRight, 4)
+	expected := []Position{600, 1200}
+	if !reflect.DeepEqual(expected, carets) {
+		t.Fatalf("for glyph %d, expected %v, got %v", 4, expected, carets)
+	}
+
+	carets = font.GetOTLigatureCarets(TopToBottom, 5)
+	expected = []Position{2000}
+	if !reflect.DeepEqual(expected, carets) {
+		t.Fatalf("for glyph %d, expected %v, got %v", 5, expected, carets)
+	}
+}
+
+func TestOpticalBounds(t *testing.T) {
+	face := openFontFile("../fonts/truetype/testdata/ToyLcarOpbd.ttf")
+	font := NewFont(face)
+	font.XScale, font.YScale = int32(face.Upem())*2, int32(face.Upem())*4
+
+	for _, test := range []struct {
+		direction  Direction
+		glyph      fonts.GID
+		start, end Position
+		ok         bool
+	}{
+		{LeftToRight, 2, 200, 0, true},
+		{RightToLeft, 3, 0, 300, true},
+		{TopToBottom, 3, 0, -160, true},
+		{LeftToRight, 4, 0, 0, false},
+		{LeftToRight, 100, 0, 0, false},
+	} {
+		start, end, ok := font.GetOpticalBounds(test.direction, test.glyph)
+		if start != test.start || end != test.end || ok != test.ok {
+			t.Fatalf("for glyph %d, expected (%d, %d, %v), got (%d, %d, %v)",
+				test.glyph, test.start, test.end, test.ok, start, end, ok)
+		}
+	}
+
+	// no 'opbd' table
+	font = NewFont(openFontFile("testdata/fonts/NotoNastaliqUrdu-Regular.ttf"))
+	_, _, ok := font.GetOpticalBounds(LeftToRight, 1)
+	assert(t, !ok)
+}
+
+type metricsFace struct {
+	dummyFace
+}
+
+func (metricsFace) LineMetric(metric fonts.LineMetric) (float32, bool) {
+	return 100, metric != fonts.TypoLineGap
+}
+
+func TestFontLineMetric(t *testing.T) {
+	font := NewFont(metricsFace{})
+	font.XScale, font.YScale = 2000, 500
+
+	for _, metric := range []fonts.LineMetric{
+		fonts.HorizontalCaretRun, fonts.SubscriptEmXOffset, fonts.VerticalCaretRise,
+		fonts.VerticalClippingAscent, fonts.VerticalClippingDescent,
+	} {
+		v, ok := font.LineMetric(metric)
+		assert(t, ok)
+		assertEqualInt(t, int(v), 200)
+	}
+	for _, metric := range []fonts.LineMetric{fonts.XHeight, fonts.CapHeight, fonts.HorizontalClippingDescent, fonts.VerticalCaretRun} {
+		v, ok := font.LineMetric(metric)
+		assert(t, ok)
+		assertEqualInt(t, int(v), 50)
+	}
+	_, ok := font.LineMetric(fonts.TypoLineGap)
+	assert(t, !ok)
+}
@@ -85,8 +85,24 @@ const (
 	// breaking point only.
 	GlyphUnsafeToBreak GlyphMask = 0x00000001
 
+	// Indicates that if input text is changed on one side of the beginning of the cluster this glyph
+	// is part of, then the shaping results for the other side might change.
+	// Note that the absence of this flag will NOT by itself mean that it IS safe to concat text.
+	// Only two pieces of text both of which clear of this flag can be concatenated safely.
+	// This can be used to optimize re-shaping after an edit of the text (see `Buffer.ReshapeEdit`).
+	// This flag is only produced when the `ProduceUnsafeToConcat` shaping option is set.
+	// `GlyphUnsafeToBreak` always implies this flag.
+	GlyphUnsafeToConcat GlyphMask = 0x00000002
+
+	// In scripts that use elongation (Arabic, Mongolian, Syriac, etc.), this flag signifies that
+	// it is safe to insert a U+0640 TATWEEL character before this cluster for elongation.
+	// This flag does not determine the script-specific elongation places, but only
+	// when it is safe to do the elongation without interrupting text shaping.
+	// This flag is only produced when the `ProduceSafeToInsertTatweel` shaping option is set.
+	GlyphSafeToInsertTatweel GlyphMask = 0x00000004
+
 	// OR of all defined flags
-	glyphFlagDefined GlyphMask = GlyphUnsafeToBreak
+	glyphFlagDefined GlyphMask = GlyphUnsafeToBreak | GlyphUnsafeToConcat | GlyphSafeToInsertTatweel
 )
 
 // GlyphInfo holds information about the
@@ -185,11 +201,7 @@ func (info *GlyphInfo) setGeneralCategory(genCat generalCategory) {
 
 func (info *GlyphInfo) setCluster(cluster int, mask GlyphMask) {
 	if info.Cluster != cluster {
-		if mask&GlyphUnsafeToBreak != 0 {
-			info.Mask |= GlyphUnsafeToBreak
-		} else {
-			info.Mask &= ^GlyphUnsafeToBreak
-		}
+		info.Mask = (info.Mask & ^glyphFlagDefined) | (mask & glyphFlagDefined)
 	}
 	info.Cluster = cluster
 }
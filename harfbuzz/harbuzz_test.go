@@ -44,6 +44,18 @@ func openFontFile(filename string) *tt.Font {
 	return font
 }
 
+// shapeRunes shapes `text` in a new buffer, using the segment properties
+// guessed from the text. `tracer` is optional.
+func shapeRunes(font *Font, text []rune, flags ShappingOptions, tracer func(TraceEvent) bool) *Buffer {
+	buf := NewBuffer()
+	buf.Flags = flags
+	buf.Tracer = tracer
+	buf.AddRunes(text, 0, -1)
+	buf.GuessSegmentProperties()
+	buf.Shape(font, nil)
+	return buf
+}
+
 func TestDirection(t *testing.T) {
 	assert(t, LeftToRight.isHorizontal() && !LeftToRight.isVertical())
 	assert(t, RightToLeft.isHorizontal() && !RightToLeft.isVertical())
@@ -141,6 +141,13 @@ const (
 	// not be inserted in the rendering of incorrect
 	// character sequences (such at <0905 093E>).
 	DoNotinsertDottedCircle
+	// Flag indicating that the `GlyphUnsafeToConcat` glyph-flag should be
+	// produced by the shaper. By default it will not be produced since it
+	// incurs a cost.
+	ProduceUnsafeToConcat
+	// Flag indicating that the `GlyphSafeToInsertTatweel` glyph-flag should be
+	// produced by the shaper. By default it will not be produced.
+	ProduceSafeToInsertTatweel
 )
 
 // ClusterLevel allows selecting more fine-grained Cluster handling.
@@ -770,9 +770,9 @@ type aatLayoutFeatureSelectorInfo struct {
 	disable aatLayoutFeatureSelector // value to turn the selector off
 }
 
-// Used when getting or setting AAT feature selectors. Indicates that
+// AATLayoutNoSelectorIndex is used when getting or setting AAT feature selectors. It indicates that
 // there is no selector index corresponding to the selector of interest.
-const aatLayoutNoSelectorIndex = 0xFFFF
+const AATLayoutNoSelectorIndex = 0xFFFF
 
 /* Note: This context is used for kerning, even without AAT, hence the condition. */
 
@@ -989,6 +989,10 @@ func (c *aatApplyContext) applyMorx(chain tt.MorxChain, flags GlyphMask) {
 			fmt.Printf("MORX - start chainsubtable %d\n", i)
 		}
 
+		if !c.buffer.trace(TraceMorx, i, true) {
+			continue
+		}
+
 		if reverse {
 			c.buffer.Reverse()
 		}
@@ -999,6 +1003,8 @@ func (c *aatApplyContext) applyMorx(chain tt.MorxChain, flags GlyphMask) {
 			c.buffer.Reverse()
 		}
 
+		c.buffer.trace(TraceMorx, i, false)
+
 		if debugMode >= 2 {
 			fmt.Printf("MORX - end chainsubtable %d\n", i)
 			fmt.Println(c.buffer.Info)
@@ -1508,6 +1514,10 @@ func (c *aatApplyContext) applyKernx(kerx tt.TableKernx) {
 			fmt.Printf("AAT kerx : start subtable %d\n", i)
 		}
 
+		if !c.buffer.trace(TraceKerx, i, true) {
+			continue
+		}
+
 		if !seenCrossStream && st.IsCrossStream() {
 			/* Attach all glyphs into a chain. */
 			seenCrossStream = true
@@ -1536,6 +1546,8 @@ func (c *aatApplyContext) applyKernx(kerx tt.TableKernx) {
 			c.buffer.Reverse()
 		}
 
+		c.buffer.trace(TraceKerx, i, false)
+
 		if debugMode >= 2 {
 			fmt.Printf("AAT kerx : end subtable %d\n", i)
 			fmt.Println(c.buffer.Pos)
@@ -0,0 +1,97 @@
+package harfbuzz
+
+import (
+	tt "github.com/benoitkugler/textlayout/fonts/truetype"
+	"github.com/benoitkugler/textlayout/language"
+)
+
+// ported from src/hb-aat-layout.cc Copyright © 2017  Google, Inc. 2018  Ebrahim Byagowi
+
+// This file exposes the AAT features of a font, as defined by its 'feat' table.
+// The feature types and selectors are listed in
+// https://developer.apple.com/fonts/TrueType-Reference-Manual/RM09/AppendixF.html
+
+// returns nil if the font has no 'feat' table
+func (f *Font) aatFeat() tt.TableFeat {
+	if f.otTables == nil {
+		return nil
+	}
+	return f.otTables.Feat
+}
+
+// AATLayoutFeatureTypes returns the AAT feature types
+// included in the font, sorted.
+func (f *Font) AATLayoutFeatureTypes() []uint16 {
+	feat := f.aatFeat()
+	out := make([]uint16, len(feat))
+	for i, fn := range feat {
+		out[i] = fn.Feature
+	}
+	return out
+}
+
+// AATLayoutFeatureTypeNameID fetches the name identifier of the given
+// feature type, or false if the font does not support it.
+// The name may be resolved with `Font.Name`.
+func (f *Font) AATLayoutFeatureTypeNameID(featureType uint16) (tt.NameID, bool) {
+	fn := f.aatFeat().GetFeature(featureType)
+	if fn == nil {
+		return 0, false
+	}
+	return fn.NameIndex, true
+}
+
+// AATLayoutFeatureTypeSelectorInfos fetches the selectors available for the given feature type,
+// and the index of the default selector. This index is `AATLayoutNoSelectorIndex` when the
+// feature type is non-exclusive (or not supported by the font).
+func (f *Font) AATLayoutFeatureTypeSelectorInfos(featureType uint16) (selectors []tt.AATFeatureSelector, defaultIndex uint16) {
+	fn := f.aatFeat().GetFeature(featureType)
+	if fn == nil {
+		return nil, AATLayoutNoSelectorIndex
+	}
+	return fn.GetSelectorInfos()
+}
+
+// AATLayoutFeature gathers the information about an AAT feature type
+// supported by a font, with its names resolved.
+type AATLayoutFeature struct {
+	Name      string
+	Selectors []AATLayoutSelector
+	// DefaultIndex is the index into Selectors of the selector enabled by default,
+	// or `AATLayoutNoSelectorIndex` for non-exclusive features.
+	DefaultIndex uint16
+	Type         uint16
+	// Exclusive is true if the selectors are mutually exclusive
+	Exclusive bool
+}
+
+// AATLayoutSelector is a selector of an AAT feature, with its name resolved.
+type AATLayoutSelector struct {
+	Name string
+	tt.AATFeatureSelector
+}
+
+// AATLayoutFeatures returns the AAT features of the font, with their names
+// resolved in the given language (see `Font.Name`).
+// The names missing from the 'name' table are left empty.
+func (f *Font) AATLayoutFeatures(lang language.Language) []AATLayoutFeature {
+	feat := f.aatFeat()
+	out := make([]AATLayoutFeature, len(feat))
+	for i := range feat {
+		fn := &feat[i]
+		selectors, defaultIndex := fn.GetSelectorInfos()
+		feature := AATLayoutFeature{
+			Type:         fn.Feature,
+			Exclusive:    fn.IsExclusive(),
+			DefaultIndex: defaultIndex,
+			Selectors:    make([]AATLayoutSelector, len(selectors)),
+		}
+		feature.Name, _ = f.Name(fn.NameIndex, lang)
+		for j, selector := range selectors {
+			feature.Selectors[j].AATFeatureSelector = selector
+			feature.Selectors[j].Name, _ = f.Name(selector.Name, lang)
+		}
+		out[i] = feature
+	}
+	return out
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/benoitkugler/textlayout/fonts/truetype"
+	"github.com/benoitkugler/textlayout/language"
 )
 
 // ported from harfbuzz/test/api/test-aat-layout.c Copyright © 2018  Ebrahim Byagowi
@@ -74,7 +75,7 @@ func TestAatGetFeatureSelectors(t *testing.T) {
 	settings, defaultIndex, total = aatLayoutFeatureTypeGetSelectorInfos(feat, aatLayoutFeatureTypeTypographicExtras)
 
 	assertEqualInt(t, 1, total)
-	assertEqualInt(t, aatLayoutNoSelectorIndex, int(defaultIndex))
+	assertEqualInt(t, AATLayoutNoSelectorIndex, int(defaultIndex))
 
 	assertEqualInt(t, 8, int(settings[0].Enable))
 	assertEqualInt(t, 308, int(settings[0].Name))
@@ -88,3 +89,86 @@ func TestAatHas(t *testing.T) {
 	trak := openFontFile("testdata/fonts/aat-trak.ttf")
 	assert(t, !trak.LayoutTables().Trak.IsEmpty())
 }
+
+func TestAatLayoutFeatureTypes(t *testing.T) {
+	font := NewFont(openFontFile("testdata/fonts/aat-feat.ttf"))
+
+	features := font.AATLayoutFeatureTypes()
+	assertEqualInt(t, 11, len(features))
+	for i, f := range aatLayoutGetFeatureTypes(feat) {
+		assertEqualInt(t, int(f), int(features[i]))
+
+		nameID, ok := font.AATLayoutFeatureTypeNameID(f)
+		assert(t, ok)
+		assertEqualInt(t, aatLayoutFeatureTypeGetNameID(feat, f), int(nameID))
+
+		expSettings, expDefault, _ := aatLayoutFeatureTypeGetSelectorInfos(feat, f)
+		settings, defaultIndex := font.AATLayoutFeatureTypeSelectorInfos(f)
+		assertEqualInt(t, int(expDefault), int(defaultIndex))
+		assertEqualInt(t, len(expSettings), len(settings))
+		for j := range settings {
+			assert(t, settings[j] == expSettings[j])
+		}
+	}
+
+	_, ok := font.AATLayoutFeatureTypeNameID(0xFFFF)
+	assert(t, !ok)
+	settings, defaultIndex := font.AATLayoutFeatureTypeSelectorInfos(0xFFFF)
+	assert(t, settings == nil && defaultIndex == AATLayoutNoSelectorIndex)
+}
+
+// namedFace provides a 'name' table to a font lacking the feature names
+type namedFace struct {
+	*truetype.Font
+	names truetype.TableName
+}
+
+func (f namedFace) NameTable() truetype.TableName { return f.names }
+
+func TestAatLayoutFeatures(t *testing.T) {
+	utf16 := func(s string) []byte {
+		var out []byte
+		for _, r := range s {
+			out = append(out, byte(r>>8), byte(r))
+		}
+		return out
+	}
+	font := NewFont(namedFace{
+		Font: openFontFile("testdata/fonts/aat-feat.ttf"),
+		names: truetype.TableName{
+			{NameID: 258, PlatformID: truetype.PlatformMac, EncodingID: truetype.PEMacRoman, Value: []byte("Ligatures")},
+			{NameID: 258, PlatformID: truetype.PlatformMicrosoft, EncodingID: truetype.PEMicrosoftUnicodeCs, LanguageID: 0x040C, Value: utf16("Ligatures FR")},
+			{NameID: 259, PlatformID: truetype.PlatformMac, EncodingID: truetype.PEMacRoman, Value: []byte("Rare")},
+		},
+	})
+
+	features := font.AATLayoutFeatures("")
+	assertEqualInt(t, 11, len(features))
+
+	ligatures := features[0]
+	assertEqualInt(t, 1, int(ligatures.Type))
+	assert(t, ligatures.Name == "Ligatures")
+	assert(t, !ligatures.Exclusive)
+	assertEqualInt(t, AATLayoutNoSelectorIndex, int(ligatures.DefaultIndex))
+	assertEqualInt(t, 3, len(ligatures.Selectors))
+	assertEqualInt(t, 259, int(ligatures.Selectors[1].AATFeatureSelector.Name))
+	assert(t, ligatures.Selectors[1].Name == "Rare")
+	assert(t, ligatures.Selectors[0].Name == "") // missing name
+
+	letterCase := features[1]
+	assertEqualInt(t, 3, int(letterCase.Type))
+	assert(t, letterCase.Exclusive)
+	assertEqualInt(t, 0, int(letterCase.DefaultIndex))
+
+	features = font.AATLayoutFeatures(language.NewLanguage("fr"))
+	assert(t, features[0].Name == "Ligatures FR")
+
+	// fonts without 'feat' table
+	font = NewFont(openFontFile("testdata/fonts/aat-morx.ttf"))
+	assertEqualInt(t, 0, len(font.AATLayoutFeatures("")))
+	assertEqualInt(t, 0, len(font.AATLayoutFeatureTypes()))
+	_, ok := font.AATLayoutFeatureTypeNameID(1)
+	assert(t, !ok)
+	selectors, defaultIndex := font.AATLayoutFeatureTypeSelectorInfos(1)
+	assert(t, selectors == nil && defaultIndex == AATLayoutNoSelectorIndex)
+}
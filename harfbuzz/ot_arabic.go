@@ -286,7 +286,17 @@ func arabicJoining(buffer *Buffer) {
 
 		if entry.prevAction != arabNone && prev != -1 {
 			info[prev].complexAux = entry.prevAction
-			buffer.unsafeToBreak(prev, i+1)
+			buffer.safeToInsertTatweel(prev, i+1)
+		} else {
+			if prev == -1 {
+				if thisType >= joiningTypeR {
+					buffer.unsafeToConcatFromOutbuffer(0, i+1)
+				}
+			} else {
+				if thisType >= joiningTypeR || (2 <= state && state <= 5) /* States that have a possible prevAction. */ {
+					buffer.unsafeToConcat(prev, i+1)
+				}
+			}
 		}
 
 		info[i].complexAux = entry.currAction
@@ -305,6 +315,9 @@ func arabicJoining(buffer *Buffer) {
 		entry := &arabicStateTable[state][thisType]
 		if entry.prevAction != arabNone && prev != -1 {
 			info[prev].complexAux = entry.prevAction
+			buffer.safeToInsertTatweel(prev, len(buffer.Info))
+		} else if 2 <= state && state <= 5 /* States that have a possible prevAction. */ {
+			buffer.unsafeToConcat(prev, len(buffer.Info))
 		}
 		break
 	}
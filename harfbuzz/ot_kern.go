@@ -28,7 +28,9 @@ func kern(driver tt.SimpleKerns, crossStream bool, font *Font, buffer *Buffer, k
 		}
 
 		skippyIter.reset(idx, 1)
-		if !skippyIter.next() {
+		var unsafeTo int
+		if !skippyIter.next(&unsafeTo) {
+			buffer.unsafeToConcat(idx, unsafeTo)
 			idx++
 			continue
 		}
@@ -0,0 +1,290 @@
+package harfbuzz
+
+import (
+	"github.com/benoitkugler/textlayout/fonts"
+	tt "github.com/benoitkugler/textlayout/fonts/truetype"
+)
+
+// ported from src/hb-ot-shape.cc, src/hb-ot-layout-gsub-table.hh
+// Copyright © 2009,2010  Red Hat, Inc. 2010,2011,2012  Google, Inc. Behdad Esfahbod
+
+// GlyphsClosure returns the set of the glyphs which may be used
+// when shaping text made of `runes` with the given properties and features.
+// It includes the nominal glyphs of the runes (and of their mirrored
+// counterparts for backward directions), their variation glyphs, and
+// every glyph reachable through the GSUB lookups selected by the shaping plan.
+// The closure may be larger than strictly required, but it is always safe
+// to subset the font using it.
+// If the direction of `props` is not set, it is guessed from the script.
+func (f *Font) GlyphsClosure(props SegmentProperties, features []Feature, runes []rune) map[fonts.GID]bool {
+	if props.Direction == 0 {
+		props.Direction = getHorizontalDirection(props.Script)
+		if props.Direction == 0 {
+			props.Direction = LeftToRight
+		}
+	}
+
+	// the shaper mirrors the characters through the cmap for
+	// backward directions, using 'rtlm' as fallback
+	mirror := props.Direction.isBackward()
+
+	glyphs := make(map[fonts.GID]bool)
+	addRune := func(r rune) {
+		if g, ok := f.face.NominalGlyph(r); ok {
+			glyphs[g] = true
+		}
+		varFace, ok := f.face.(FaceOpentype)
+		if !ok {
+			return
+		}
+		for vs := rune(0xFE00); vs <= 0xFE0F; vs++ {
+			if g, ok := varFace.VariationGlyph(r, vs); ok {
+				glyphs[g] = true
+			}
+		}
+		for vs := rune(0xE0100); vs <= 0xE01EF; vs++ {
+			if g, ok := varFace.VariationGlyph(r, vs); ok {
+				glyphs[g] = true
+			}
+		}
+	}
+	for _, r := range runes {
+		addRune(r)
+		if m := uni.mirroring(r); mirror && m != r {
+			addRune(m)
+		}
+	}
+
+	if f.otTables == nil {
+		return glyphs
+	}
+
+	// the Opentype shaper is always used to select the lookups,
+	// as done in the reference implementation
+	sp := newShaperOpentype(f.otTables, f.varCoords())
+	sp.compile(props, features)
+	var lookups []uint16
+	for _, l := range sp.plan.map_.lookups[0] {
+		lookups = append(lookups, l.index)
+	}
+	f.LayoutSubstituteClosure(lookups, glyphs)
+	return glyphs
+}
+
+// LayoutSubstituteClosure adds to `glyphs` all the glyphs which may be
+// produced by applying the given GSUB lookups (any number of times, in any order)
+// to sequences made of glyphs in the set.
+func (f *Font) LayoutSubstituteClosure(lookups []uint16, glyphs map[fonts.GID]bool) {
+	if f.otTables == nil {
+		return
+	}
+	c := closureContext{lookups: f.otTables.GSUB.Lookups, glyphs: glyphs}
+	for {
+		size := len(glyphs)
+		for _, index := range lookups {
+			c.closeLookup(index, maxNestingLevel)
+		}
+		if len(glyphs) == size { // fixed point reached
+			return
+		}
+	}
+}
+
+type closureContext struct {
+	glyphs  map[fonts.GID]bool
+	lookups []tt.LookupGSUB
+
+	current []fonts.GID // snapshot of the set, refreshed for each lookup
+}
+
+func (c *closureContext) snapshot() {
+	// a new slice is required since snapshots are nested by recursion
+	c.current = make([]fonts.GID, 0, len(c.glyphs))
+	for g := range c.glyphs {
+		c.current = append(c.current, g)
+	}
+}
+
+func (c *closureContext) closeLookup(index uint16, nestingLevelLeft int) {
+	if int(index) >= len(c.lookups) || nestingLevelLeft == 0 {
+		return
+	}
+	for _, subtable := range c.lookups[index].Subtables {
+		c.snapshot()
+		c.closeSubtable(subtable, nestingLevelLeft)
+	}
+}
+
+func (c *closureContext) recurse(records []tt.SequenceLookup, nestingLevelLeft int) {
+	// the nested lookups are applied on the whole set, which
+	// is an over-approximation of the glyphs at the matched positions
+	for _, record := range records {
+		c.closeLookup(record.LookupIndex, nestingLevelLeft-1)
+	}
+}
+
+func (c *closureContext) intersectsGlyphs(glyphs []uint16) bool {
+	for _, g := range glyphs {
+		if !c.glyphs[fonts.GID(g)] {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *closureContext) intersectsCoverage(cov tt.Coverage) bool {
+	for _, g := range c.current {
+		if _, ok := cov.Index(g); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *closureContext) intersectsCoverages(covs []tt.Coverage) bool {
+	for _, cov := range covs {
+		if !c.intersectsCoverage(cov) {
+			return false
+		}
+	}
+	return true
+}
+
+// returns the classes of the glyphs in the set,
+// where class 0 is used for glyphs not covered by `class`
+func (c *closureContext) classes(class tt.Class) map[uint32]bool {
+	out := make(map[uint32]bool)
+	if class == nil {
+		out[0] = true
+		return out
+	}
+	for _, g := range c.current {
+		id, _ := class.ClassID(g)
+		out[id] = true
+	}
+	return out
+}
+
+func intersectsClasses(classes map[uint32]bool, ids []uint16) bool {
+	for _, id := range ids {
+		if !classes[uint32(id)] {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *closureContext) closeSubtable(subtable tt.GSUBSubtable, nestingLevelLeft int) {
+	switch data := subtable.Data.(type) {
+	case tt.GSUBSingle1:
+		for _, g := range c.current {
+			if _, ok := subtable.Coverage.Index(g); ok {
+				c.glyphs[fonts.GID(uint16(int(g)+int(data)))] = true
+			}
+		}
+	case tt.GSUBSingle2:
+		for _, g := range c.current {
+			if index, ok := subtable.Coverage.Index(g); ok && index < len(data) {
+				c.glyphs[data[index]] = true
+			}
+		}
+	case tt.GSUBMultiple1:
+		c.addSequences(subtable.Coverage, data)
+	case tt.GSUBAlternate1:
+		c.addSequences(subtable.Coverage, data)
+	case tt.GSUBLigature1:
+		for _, g := range c.current {
+			index, ok := subtable.Coverage.Index(g)
+			if !ok || index >= len(data) {
+				continue
+			}
+			for _, lig := range data[index] {
+				if c.intersectsGlyphs(lig.Components) {
+					c.glyphs[lig.Glyph] = true
+				}
+			}
+		}
+	case tt.GSUBContext1:
+		for _, g := range c.current {
+			index, ok := subtable.Coverage.Index(g)
+			if !ok || index >= len(data) {
+				continue
+			}
+			for _, rule := range data[index] {
+				if c.intersectsGlyphs(rule.Input) {
+					c.recurse(rule.Lookups, nestingLevelLeft)
+				}
+			}
+		}
+	case tt.GSUBContext2:
+		if !c.intersectsCoverage(subtable.Coverage) {
+			return
+		}
+		classes := c.classes(data.Class)
+		for class, rules := range data.SequenceSets {
+			if !classes[uint32(class)] {
+				continue
+			}
+			for _, rule := range rules {
+				if intersectsClasses(classes, rule.Input) {
+					c.recurse(rule.Lookups, nestingLevelLeft)
+				}
+			}
+		}
+	case tt.GSUBContext3:
+		if c.intersectsCoverages(data.Coverages) {
+			c.recurse(data.SequenceLookups, nestingLevelLeft)
+		}
+	case tt.GSUBChainedContext1:
+		for _, g := range c.current {
+			index, ok := subtable.Coverage.Index(g)
+			if !ok || index >= len(data) {
+				continue
+			}
+			for _, rule := range data[index] {
+				if c.intersectsGlyphs(rule.Backtrack) && c.intersectsGlyphs(rule.Input) && c.intersectsGlyphs(rule.Lookahead) {
+					c.recurse(rule.Lookups, nestingLevelLeft)
+				}
+			}
+		}
+	case tt.GSUBChainedContext2:
+		if !c.intersectsCoverage(subtable.Coverage) {
+			return
+		}
+		backtrack, input, lookahead := c.classes(data.BacktrackClass), c.classes(data.InputClass), c.classes(data.LookaheadClass)
+		for class, rules := range data.SequenceSets {
+			if !input[uint32(class)] {
+				continue
+			}
+			for _, rule := range rules {
+				if intersectsClasses(backtrack, rule.Backtrack) && intersectsClasses(input, rule.Input) &&
+					intersectsClasses(lookahead, rule.Lookahead) {
+					c.recurse(rule.Lookups, nestingLevelLeft)
+				}
+			}
+		}
+	case tt.GSUBChainedContext3:
+		if c.intersectsCoverages(data.Backtrack) && c.intersectsCoverages(data.Input) && c.intersectsCoverages(data.Lookahead) {
+			c.recurse(data.SequenceLookups, nestingLevelLeft)
+		}
+	case tt.GSUBReverseChainedContext1:
+		if !c.intersectsCoverages(data.Backtrack) || !c.intersectsCoverages(data.Lookahead) {
+			return
+		}
+		for _, g := range c.current {
+			if index, ok := subtable.Coverage.Index(g); ok && index < len(data.Substitutes) {
+				c.glyphs[data.Substitutes[index]] = true
+			}
+		}
+	}
+}
+
+func (c *closureContext) addSequences(cov tt.Coverage, sequences [][]fonts.GID) {
+	for _, g := range c.current {
+		if index, ok := cov.Index(g); ok && index < len(sequences) {
+			for _, s := range sequences[index] {
+				c.glyphs[s] = true
+			}
+		}
+	}
+}
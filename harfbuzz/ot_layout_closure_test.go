@@ -0,0 +1,99 @@
+package harfbuzz
+
+import (
+	"testing"
+
+	"github.com/benoitkugler/textlayout/fonts"
+	"github.com/benoitkugler/textlayout/language"
+)
+
+// the glyphs produced by shaping must be in the closure
+func TestGlyphsClosureContainsShaped(t *testing.T) {
+	for _, test := range []struct {
+		file string
+		text string
+	}{
+		{"testdata/perf_reference/fonts/Roboto-Regular.ttf", "office fluffy ffi"},
+		{"testdata/perf_reference/fonts/NotoSansDevanagari-Regular.ttf", "हिन्दी क्षत्रिय र्कि"},
+		{"testdata/perf_reference/fonts/Amiri-Regular.ttf", "بسم الله الرحمن الرحيم (1)"},
+	} {
+		font := NewFont(openFontFile(test.file))
+		runes := []rune(test.text)
+
+		buf := shapeRunes(font, runes, 0, nil)
+
+		closure := font.GlyphsClosure(buf.Props, nil, runes)
+		for _, info := range buf.Info {
+			if !closure[info.Glyph] {
+				t.Fatalf("%s: glyph %d missing from closure", test.file, info.Glyph)
+			}
+		}
+		if len(closure) < len(buf.Info)/2 {
+			t.Fatalf("%s: closure too small", test.file)
+		}
+	}
+}
+
+func TestGlyphsClosureMirroring(t *testing.T) {
+	// Roboto has no 'rtlm' feature : the mirrored characters are mapped through the cmap
+	font := NewFont(openFontFile("testdata/perf_reference/fonts/Roboto-Regular.ttf"))
+	runes := []rune("(a")
+
+	buf := NewBuffer()
+	buf.AddRunes(runes, 0, -1)
+	buf.GuessSegmentProperties()
+	buf.Props.Direction = RightToLeft
+	buf.Shape(font, nil)
+
+	mirrored, _ := font.face.NominalGlyph(')')
+	closure := font.GlyphsClosure(buf.Props, nil, runes)
+	for _, info := range buf.Info {
+		if !closure[info.Glyph] {
+			t.Fatalf("glyph %d missing from closure", info.Glyph)
+		}
+	}
+	assert(t, closure[mirrored])
+
+	buf.Props.Direction = LeftToRight
+	closure = font.GlyphsClosure(buf.Props, nil, runes)
+	assert(t, !closure[mirrored])
+}
+
+func TestGlyphsClosureLigature(t *testing.T) {
+	font := NewFont(openFontFile("testdata/perf_reference/fonts/Roboto-Regular.ttf"))
+	props := SegmentProperties{Script: language.Latin, Language: language.NewLanguage("en")}
+
+	buf := shapeRunes(font, []rune("fi"), 0, nil)
+	assertEqualInt(t, len(buf.Info), 1)
+	ligature := buf.Info[0].Glyph
+
+	if closure := font.GlyphsClosure(props, nil, []rune("f")); closure[ligature] {
+		t.Fatal("ligature should require all its components")
+	}
+	if closure := font.GlyphsClosure(props, nil, []rune("if")); !closure[ligature] {
+		t.Fatal("missing ligature glyph")
+	}
+	disabled := []Feature{{Tag: newTag('l', 'i', 'g', 'a'), Value: 0, Start: FeatureGlobalStart, End: FeatureGlobalEnd}}
+	if closure := font.GlyphsClosure(props, disabled, []rune("if")); closure[ligature] {
+		t.Fatal("ligature should be disabled")
+	}
+
+	// closure is stable
+	closure := font.GlyphsClosure(props, nil, []rune("if"))
+	size := len(closure)
+	var all []uint16
+	for l := range font.otTables.GSUB.Lookups {
+		all = append(all, uint16(l))
+	}
+	font.LayoutSubstituteClosure(all, closure)
+	if len(closure) < size {
+		t.Fatal("closure should only grow")
+	}
+	font.LayoutSubstituteClosure(all, closure)
+	other := map[fonts.GID]bool{}
+	for g := range closure {
+		other[g] = true
+	}
+	font.LayoutSubstituteClosure(all, other)
+	assertEqualInt(t, len(other), len(closure))
+}
@@ -148,19 +148,24 @@ func (table gposSubtable) apply(c *otApplyContext) bool {
 	case tt.GPOSPair1:
 		skippyIter := &c.iterInput
 		skippyIter.reset(buffer.idx, 1)
-		if !skippyIter.next() {
+		var unsafeTo int
+		if !skippyIter.next(&unsafeTo) {
+			buffer.unsafeToConcat(buffer.idx, unsafeTo)
 			return false
 		}
 		set := data.Values[index]
 		record := set.FindGlyph(buffer.Info[skippyIter.idx].Glyph)
 		if record == nil {
+			buffer.unsafeToConcat(buffer.idx, skippyIter.idx+1)
 			return false
 		}
 		c.applyGPOSPair(data.Formats, record.Pos, skippyIter.idx)
 	case tt.GPOSPair2:
 		skippyIter := &c.iterInput
 		skippyIter.reset(buffer.idx, 1)
-		if !skippyIter.next() {
+		var unsafeTo int
+		if !skippyIter.next(&unsafeTo) {
+			buffer.unsafeToConcat(buffer.idx, unsafeTo)
 			return false
 		}
 		class1, _ := data.First.ClassID(glyphID)
@@ -304,16 +309,20 @@ func (c *otApplyContext) applyGPOSCursive(data tt.GPOSCursive1, covIndex int, co
 
 	skippyIter := &c.iterInput
 	skippyIter.reset(buffer.idx, 1)
-	if !skippyIter.prev() {
+	var unsafeFrom int
+	if !skippyIter.prev(&unsafeFrom) {
+		buffer.unsafeToConcatFromOutbuffer(unsafeFrom, buffer.idx+1)
 		return false
 	}
 
 	prevIndex, ok := cov.Index(buffer.Info[skippyIter.idx].Glyph)
 	if !ok {
+		buffer.unsafeToConcatFromOutbuffer(skippyIter.idx, buffer.idx+1)
 		return false
 	}
 	prevRecord := data[prevIndex]
 	if prevRecord[1] == nil {
+		buffer.unsafeToConcatFromOutbuffer(skippyIter.idx, buffer.idx+1)
 		return false
 	}
 
@@ -449,10 +458,11 @@ func (c *otApplyContext) applyGPOSMarks(marks []tt.GPOSMark, markIndex, glyphInd
 	/* If this subtable doesn't have an anchor for this base and this class,
 	 * return false such that the subsequent subtables have a chance at it. */
 	if glyphAnchor == nil {
+		buffer.unsafeToConcatFromOutbuffer(glyphPos, buffer.idx+1)
 		return false
 	}
 
-	buffer.unsafeToBreak(glyphPos, buffer.idx)
+	buffer.unsafeToBreak(glyphPos, buffer.idx+1)
 	markX, markY := c.getAnchor(markAnchor, buffer.cur(0).Glyph)
 	baseX, baseY := c.getAnchor(glyphAnchor, buffer.Info[glyphPos].Glyph)
 
@@ -475,7 +485,9 @@ func (c *otApplyContext) applyGPOSMarkToBase(data tt.GPOSMarkToBase1, markIndex
 	skippyIter.reset(buffer.idx, 1)
 	skippyIter.matcher.lookupProps = uint32(tt.IgnoreMarks)
 	for {
-		if !skippyIter.prev() {
+		var unsafeFrom int
+		if !skippyIter.prev(&unsafeFrom) {
+			buffer.unsafeToConcatFromOutbuffer(unsafeFrom, buffer.idx+1)
 			return false
 		}
 		/* We only want to attach to the first of a MultipleSubst sequence.
@@ -497,6 +509,7 @@ func (c *otApplyContext) applyGPOSMarkToBase(data tt.GPOSMarkToBase1, markIndex
 
 	baseIndex, ok := data.BaseCoverage.Index(buffer.Info[skippyIter.idx].Glyph)
 	if !ok {
+		buffer.unsafeToConcatFromOutbuffer(skippyIter.idx, buffer.idx+1)
 		return false
 	}
 
@@ -510,13 +523,16 @@ func (c *otApplyContext) applyGPOSMarkToLigature(data tt.GPOSMarkToLigature1, ma
 	skippyIter := &c.iterInput
 	skippyIter.reset(buffer.idx, 1)
 	skippyIter.matcher.lookupProps = uint32(tt.IgnoreMarks)
-	if !skippyIter.prev() {
+	var unsafeFrom int
+	if !skippyIter.prev(&unsafeFrom) {
+		buffer.unsafeToConcatFromOutbuffer(unsafeFrom, buffer.idx+1)
 		return false
 	}
 
 	j := skippyIter.idx
 	ligIndex, ok := data.LigatureCoverage.Index(buffer.Info[j].Glyph)
 	if !ok {
+		buffer.unsafeToConcatFromOutbuffer(skippyIter.idx, buffer.idx+1)
 		return false
 	}
 
@@ -550,11 +566,14 @@ func (c *otApplyContext) applyGPOSMarkToMark(data tt.GPOSMarkToMark1, mark1Index
 	skippyIter := &c.iterInput
 	skippyIter.reset(buffer.idx, 1)
 	skippyIter.matcher.lookupProps = c.lookupProps &^ uint32(ignoreFlags)
-	if !skippyIter.prev() {
+	var unsafeFrom int
+	if !skippyIter.prev(&unsafeFrom) {
+		buffer.unsafeToConcatFromOutbuffer(unsafeFrom, buffer.idx+1)
 		return false
 	}
 
 	if !buffer.Info[skippyIter.idx].isMark() {
+		buffer.unsafeToConcatFromOutbuffer(skippyIter.idx, buffer.idx+1)
 		return false
 	}
 
@@ -580,11 +599,13 @@ func (c *otApplyContext) applyGPOSMarkToMark(data tt.GPOSMarkToMark1, mark1Index
 	}
 
 	/* Didn't match. */
+	buffer.unsafeToConcatFromOutbuffer(skippyIter.idx, buffer.idx+1)
 	return false
 
 good:
 	mark2Index, ok := data.Mark2Coverage.Index(buffer.Info[j].Glyph)
 	if !ok {
+		buffer.unsafeToConcatFromOutbuffer(skippyIter.idx, buffer.idx+1)
 		return false
 	}
 
@@ -156,11 +156,13 @@ func (table gsubSubtable) apply(c *otApplyContext) bool {
 		lB, lL := len(data.Backtrack), len(data.Lookahead)
 		hasMatch, startIndex := c.matchBacktrack(get1N(&c.indices, 0, lB), matchCoverage(data.Backtrack))
 		if !hasMatch {
+			c.buffer.unsafeToConcatFromOutbuffer(startIndex, c.buffer.idx+1)
 			return false
 		}
 
 		hasMatch, endIndex := c.matchLookahead(get1N(&c.indices, 0, lL), matchCoverage(data.Lookahead), 1)
 		if !hasMatch {
+			c.buffer.unsafeToConcat(c.buffer.idx, endIndex)
 			return false
 		}
 
@@ -250,6 +252,7 @@ func (c *otApplyContext) applySubsLigature(ligatureSet []tt.LigatureGlyph) bool
 
 		ok, matchLength, totalComponentCount := c.matchInput(lig.Components, matchGlyph, &matchPositions)
 		if !ok {
+			c.buffer.unsafeToConcat(c.buffer.idx, c.buffer.idx+matchLength)
 			continue
 		}
 		c.ligateInput(count, matchPositions, matchLength, lig.Glyph, totalComponentCount)
@@ -228,7 +228,10 @@ func (it *skippingIterator) reject() {
 
 func (it *skippingIterator) maySkip(info *GlyphInfo) uint8 { return it.matcher.maySkip(it.c, info) }
 
-func (it *skippingIterator) next() bool {
+// next advances the iterator, returning false if no match is found.
+// In this case, if `unsafeTo` is not nil, it is set to the end
+// of the range whose content decided the failure.
+func (it *skippingIterator) next(unsafeTo *int) bool {
 	for it.idx+it.numItems < it.end {
 		it.idx++
 		info := &it.c.buffer.Info[it.idx]
@@ -248,13 +251,22 @@ func (it *skippingIterator) next() bool {
 		}
 
 		if skip == no {
+			if unsafeTo != nil {
+				*unsafeTo = it.idx + 1
+			}
 			return false
 		}
 	}
+	if unsafeTo != nil {
+		*unsafeTo = it.end
+	}
 	return false
 }
 
-func (it *skippingIterator) prev() bool {
+// prev moves the iterator backward, returning false if no match is found.
+// In this case, if `unsafeFrom` is not nil, it is set to the start
+// of the range whose content decided the failure.
+func (it *skippingIterator) prev(unsafeFrom *int) bool {
 	L := len(it.c.buffer.outInfo)
 	//    assert (num_items > 0);
 	for it.idx > it.numItems-1 {
@@ -283,9 +295,15 @@ func (it *skippingIterator) prev() bool {
 		}
 
 		if skip == no {
+			if unsafeFrom != nil {
+				*unsafeFrom = max(1, it.idx) - 1
+			}
 			return false
 		}
 	}
+	if unsafeFrom != nil {
+		*unsafeFrom = 0
+	}
 	return false
 }
 
@@ -486,6 +504,7 @@ func (c *otApplyContext) contextApplyLookup(input []uint16, lookupRecord []tt.Se
 	var matchPositions [maxContextLength]int
 	hasMatch, matchLength, _ := c.matchInput(input, lookupContext, &matchPositions)
 	if !hasMatch {
+		c.buffer.unsafeToConcat(c.buffer.idx, c.buffer.idx+matchLength)
 		return false
 	}
 	c.buffer.unsafeToBreak(c.buffer.idx, c.buffer.idx+matchLength)
@@ -501,16 +520,19 @@ func (c *otApplyContext) chainContextApplyLookup(backtrack, input, lookahead []u
 
 	hasMatch, matchLength, _ := c.matchInput(input, lookupContexts[1], &matchPositions)
 	if !hasMatch {
+		c.buffer.unsafeToConcat(c.buffer.idx, c.buffer.idx+matchLength)
 		return false
 	}
 
-	hasMatch, startIndex := c.matchBacktrack(backtrack, lookupContexts[0])
+	hasMatch, endIndex := c.matchLookahead(lookahead, lookupContexts[2], matchLength)
 	if !hasMatch {
+		c.buffer.unsafeToConcat(c.buffer.idx, endIndex)
 		return false
 	}
 
-	hasMatch, endIndex := c.matchLookahead(lookahead, lookupContexts[2], matchLength)
+	hasMatch, startIndex := c.matchBacktrack(backtrack, lookupContexts[0])
 	if !hasMatch {
+		c.buffer.unsafeToConcatFromOutbuffer(startIndex, endIndex)
 		return false
 	}
 
@@ -650,8 +672,9 @@ func (c *otApplyContext) matchInput(input []uint16, matchFunc matcherFunc,
 	ligbase := ligbaseNotChecked
 	matchPositions[0] = buffer.idx
 	for i := 1; i < count; i++ {
-		if !skippyIter.next() {
-			return false, 0, 0
+		var unsafeTo int
+		if !skippyIter.next(&unsafeTo) {
+			return false, unsafeTo - buffer.idx, 0
 		}
 
 		matchPositions[i] = skippyIter.idx
@@ -960,8 +983,9 @@ func (c *otApplyContext) matchBacktrack(backtrack []uint16, matchFunc matcherFun
 	skippyIter.setMatchFunc(matchFunc, backtrack)
 
 	for i := 0; i < len(backtrack); i++ {
-		if !skippyIter.prev() {
-			return false, 0
+		var unsafeFrom int
+		if !skippyIter.prev(&unsafeFrom) {
+			return false, unsafeFrom
 		}
 	}
 
@@ -974,8 +998,9 @@ func (c *otApplyContext) matchLookahead(lookahead []uint16, matchFunc matcherFun
 	skippyIter.setMatchFunc(matchFunc, lookahead)
 
 	for i := 0; i < len(lookahead); i++ {
-		if !skippyIter.next() {
-			return false, 0
+		var unsafeTo int
+		if !skippyIter.next(&unsafeTo) {
+			return false, unsafeTo
 		}
 	}
 
@@ -0,0 +1,103 @@
+package harfbuzz
+
+import (
+	"sort"
+
+	tt "github.com/benoitkugler/textlayout/fonts/truetype"
+	"github.com/benoitkugler/textlayout/language"
+)
+
+// This file implements the support for the OpenType 'JSTF' table.
+
+// LayoutJustificationPriorities returns the justification suggestions
+// of the 'JSTF' table for the given script and language, sorted from the
+// most to the least preferred, or nil if the font has no suggestions.
+// The lookup indices refer to the GSUB and GPOS lookup lists.
+func (f *Font) LayoutJustificationPriorities(script language.Script, lang language.Language) []tt.JstfPriority {
+	if f.otTables == nil {
+		return nil
+	}
+	jstf := f.otTables.JSTF
+	scriptTags, languageTags := otTagsFromScriptAndLanguage(script, lang)
+	for _, scriptTag := range append(scriptTags, tagDefaultScript) {
+		scriptIndex := jstf.FindScript(scriptTag)
+		if scriptIndex == -1 {
+			continue
+		}
+		s := jstf.Scripts[scriptIndex]
+		for _, languageTag := range languageTags {
+			if languageIndex := s.FindLanguage(languageTag); languageIndex != -1 {
+				return s.Languages[languageIndex].Priorities
+			}
+		}
+		if s.DefaultLanguage != nil {
+			return s.DefaultLanguage.Priorities
+		}
+		return nil
+	}
+	return nil
+}
+
+// jstfLookups stores the lookups enabled or disabled
+// by JSTF priorities, indexed by table (GSUB then GPOS).
+// A nil value is valid and means no modification.
+type jstfLookups struct {
+	enabled, disabled [2]map[uint16]bool
+}
+
+func newJstfLookups() *jstfLookups {
+	var out jstfLookups
+	for i := range out.enabled {
+		out.enabled[i] = make(map[uint16]bool)
+		out.disabled[i] = make(map[uint16]bool)
+	}
+	return &out
+}
+
+// add applies the modifications of one priority level,
+// on top of the previous ones
+func (jl *jstfLookups) add(mods tt.JstfModifications) {
+	for tableIndex, lists := range [2][2][]uint16{
+		{mods.EnableGSUB, mods.DisableGSUB},
+		{mods.EnableGPOS, mods.DisableGPOS},
+	} {
+		for _, index := range lists[0] {
+			jl.enabled[tableIndex][index] = true
+			delete(jl.disabled[tableIndex], index)
+		}
+		for _, index := range lists[1] {
+			jl.disabled[tableIndex][index] = true
+			delete(jl.enabled[tableIndex], index)
+		}
+	}
+}
+
+func (jl *jstfLookups) isDisabled(tableIndex int, lookupIndex uint16) bool {
+	return jl != nil && jl.disabled[tableIndex][lookupIndex]
+}
+
+// extraLookups returns the enabled lookups, not already
+// present in `lookups`, sorted by index
+func (jl *jstfLookups) extraLookups(tableIndex int, lookups []lookupMap, lookupCount int) []uint16 {
+	if jl == nil {
+		return nil
+	}
+	var out []uint16
+	for index := range jl.enabled[tableIndex] {
+		if int(index) >= lookupCount {
+			continue
+		}
+		found := false
+		for _, l := range lookups {
+			if l.index == index {
+				found = true
+				break
+			}
+		}
+		if !found {
+			out = append(out, index)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
@@ -0,0 +1,419 @@
+package harfbuzz
+
+import (
+	"github.com/benoitkugler/textlayout/fonts"
+	tt "github.com/benoitkugler/textlayout/fonts/truetype"
+	"github.com/benoitkugler/textlayout/language"
+)
+
+// ported from src/hb-ot-layout.cc Copyright © 2006  2007,2008,2009  Red Hat, Inc. 2012,2013  Google, Inc. Behdad Esfahbod
+
+// This file exposes the content of the OpenType layout tables,
+// as seen by the shaper.
+// The indexes used are the one of the tables, so that
+// the scripts, languages, features and lookups may be queried
+// and then passed to the other functions.
+
+// LayoutTable selects one of the GSUB or GPOS tables.
+type LayoutTable uint8
+
+const (
+	LayoutGSUB LayoutTable = iota // glyph substitutions
+	LayoutGPOS                    // glyph positionning
+)
+
+const (
+	// NoScriptIndex is returned when no script is selected.
+	NoScriptIndex = noScriptIndex
+	// DefaultLanguageIndex refers to the default language of a script.
+	DefaultLanguageIndex = defaultLanguageIndex
+	// NoFeatureIndex is returned when a feature is not found.
+	NoFeatureIndex = noFeatureIndex
+	// NoVariationsIndex selects the default features, ignoring feature variations.
+	NoVariationsIndex = noVariationsIndex
+)
+
+// returns nil if the font has no layout tables
+func (f *Font) layoutTable(table LayoutTable) *tt.TableLayout {
+	if f.otTables == nil {
+		return nil
+	}
+	if table == LayoutGPOS {
+		return &f.otTables.GPOS.TableLayout
+	}
+	return &f.otTables.GSUB.TableLayout
+}
+
+// LayoutScriptTags returns the script tags of the given table.
+// The indexes of the returned slice are the script indexes.
+func (f *Font) LayoutScriptTags(table LayoutTable) []tt.Tag {
+	g := f.layoutTable(table)
+	if g == nil {
+		return nil
+	}
+	out := make([]tt.Tag, len(g.Scripts))
+	for i, s := range g.Scripts {
+		out[i] = s.Tag
+	}
+	return out
+}
+
+// LayoutLanguageTags returns the language tags of the given table, underneath
+// `scriptIndex`. The indexes of the returned slice are the language indexes.
+// The default language, if any, is not included (see `DefaultLanguageIndex`).
+func (f *Font) LayoutLanguageTags(table LayoutTable, scriptIndex int) []tt.Tag {
+	g := f.layoutTable(table)
+	if g == nil || scriptIndex < 0 || scriptIndex >= len(g.Scripts) {
+		return nil
+	}
+	langs := g.Scripts[scriptIndex].Languages
+	out := make([]tt.Tag, len(langs))
+	for i, l := range langs {
+		out[i] = l.Tag
+	}
+	return out
+}
+
+// LayoutFeatureTags returns the tags of all the features of the given table.
+// The indexes of the returned slice are the feature indexes, so that
+// the same tag may appear several times.
+func (f *Font) LayoutFeatureTags(table LayoutTable) []tt.Tag {
+	g := f.layoutTable(table)
+	if g == nil {
+		return nil
+	}
+	out := make([]tt.Tag, len(g.Features))
+	for i, feat := range g.Features {
+		out[i] = feat.Tag
+	}
+	return out
+}
+
+// LayoutSelectScript selects an OpenType script from the `scriptTags` slice.
+// If the table does not have any of the requested scripts, then 'DFLT',
+// 'dflt', and 'latn' tags are tried in that order. If the table still does not
+// have any of these scripts, `NoScriptIndex` is returned.
+// `found` is true if one of the requested scripts is selected, false if a fallback
+// script is selected or if no scripts are selected.
+func (f *Font) LayoutSelectScript(table LayoutTable, scriptTags []tt.Tag) (scriptIndex int, chosen tt.Tag, found bool) {
+	g := f.layoutTable(table)
+	if g == nil {
+		return NoScriptIndex, NoScriptIndex, false
+	}
+	return selectScript(g, scriptTags)
+}
+
+// LayoutSelectLanguage fetches the index of the first language tag found
+// in `languageTags`, underneath `scriptIndex`.
+// If none is found, the 'dflt' language is tried, then `DefaultLanguageIndex`
+// is returned. `found` is true if one of the requested languages is selected.
+func (f *Font) LayoutSelectLanguage(table LayoutTable, scriptIndex int, languageTags []tt.Tag) (languageIndex int, found bool) {
+	g := f.layoutTable(table)
+	if g == nil || scriptIndex < 0 || scriptIndex >= len(g.Scripts) {
+		return DefaultLanguageIndex, false
+	}
+	return selectLanguage(g, scriptIndex, languageTags)
+}
+
+// LayoutLanguageFeatures returns the indexes of the features enabled
+// underneath the given script and language (which may be `DefaultLanguageIndex`),
+// and the index of the required feature, or `NoFeatureIndex`.
+func (f *Font) LayoutLanguageFeatures(table LayoutTable, scriptIndex, languageIndex int) (features []uint16, required uint16) {
+	g := f.layoutTable(table)
+	if g == nil || scriptIndex < 0 || scriptIndex >= len(g.Scripts) {
+		return nil, NoFeatureIndex
+	}
+	script := g.Scripts[scriptIndex]
+	if languageIndex == DefaultLanguageIndex && script.DefaultLanguage == nil ||
+		languageIndex != DefaultLanguageIndex && (languageIndex < 0 || languageIndex >= len(script.Languages)) {
+		return nil, NoFeatureIndex
+	}
+	l := script.GetLangSys(uint16(languageIndex))
+	required = l.RequiredFeatureIndex
+	if int(required) >= len(g.Features) {
+		required = NoFeatureIndex
+	}
+	return l.Features, required
+}
+
+// LayoutFindFeature returns the index of the first feature with the given tag
+// underneath the given script and language, or `NoFeatureIndex`.
+func (f *Font) LayoutFindFeature(table LayoutTable, scriptIndex, languageIndex int, featureTag tt.Tag) uint16 {
+	g := f.layoutTable(table)
+	if g == nil || scriptIndex < 0 || scriptIndex >= len(g.Scripts) {
+		return NoFeatureIndex
+	}
+	return findFeatureLang(g, scriptIndex, languageIndex, featureTag)
+}
+
+// LayoutFindFeatureVariations returns the index of the feature variation
+// matching the current variation coordinates of the font,
+// or `NoVariationsIndex`.
+func (f *Font) LayoutFindFeatureVariations(table LayoutTable) int {
+	g := f.layoutTable(table)
+	if g == nil {
+		return NoVariationsIndex
+	}
+	return g.FindVariationIndex(f.varCoords())
+}
+
+// LayoutFeatureLookups returns the indexes of the lookups of the given feature,
+// taking into account the feature variation `variationsIndex`
+// (use `NoVariationsIndex` to ignore variations).
+func (f *Font) LayoutFeatureLookups(table LayoutTable, featureIndex uint16, variationsIndex int) []uint16 {
+	g := f.layoutTable(table)
+	if g == nil || int(featureIndex) >= len(g.Features) ||
+		(variationsIndex != NoVariationsIndex && (variationsIndex < 0 || variationsIndex >= len(g.FeatureVariations))) {
+		return nil
+	}
+	return getFeatureLookupsWithVar(g, featureIndex, variationsIndex)
+}
+
+// LayoutLookup describes a GSUB or GPOS lookup.
+type LayoutLookup struct {
+	tt.LookupOptions
+	// Type is either a `tt.GSUBType` or a `tt.GPOSType`,
+	// depending on the table.
+	Type         uint16
+	NumSubtables int
+}
+
+// LayoutLookupCount returns the number of lookups in the given table.
+func (f *Font) LayoutLookupCount(table LayoutTable) int {
+	if f.otTables == nil {
+		return 0
+	}
+	if table == LayoutGPOS {
+		return len(f.otTables.GPOS.Lookups)
+	}
+	return len(f.otTables.GSUB.Lookups)
+}
+
+// LayoutLookupInfo returns the type and flags of the given lookup, or false
+// if `lookupIndex` is invalid.
+func (f *Font) LayoutLookupInfo(table LayoutTable, lookupIndex uint16) (LayoutLookup, bool) {
+	if int(lookupIndex) >= f.LayoutLookupCount(table) {
+		return LayoutLookup{}, false
+	}
+	if table == LayoutGPOS {
+		l := f.otTables.GPOS.Lookups[lookupIndex]
+		return LayoutLookup{LookupOptions: l.LookupOptions, Type: uint16(l.Type), NumSubtables: len(l.Subtables)}, true
+	}
+	l := f.otTables.GSUB.Lookups[lookupIndex]
+	return LayoutLookup{LookupOptions: l.LookupOptions, Type: uint16(l.Type), NumSubtables: len(l.Subtables)}, true
+}
+
+// LayoutFeaturesFor returns the tags of the features supported by the font
+// for the given script and language, as selected by the shaper (including the
+// required feature, if any), without duplicates.
+func (f *Font) LayoutFeaturesFor(table LayoutTable, script language.Script, lang language.Language) []tt.Tag {
+	g := f.layoutTable(table)
+	if g == nil {
+		return nil
+	}
+	scriptTags, languageTags := otTagsFromScriptAndLanguage(script, lang)
+	scriptIndex, _, _ := selectScript(g, scriptTags)
+	if scriptIndex == noScriptIndex {
+		return nil
+	}
+	languageIndex, _ := selectLanguage(g, scriptIndex, languageTags)
+	features, required := f.LayoutLanguageFeatures(table, scriptIndex, languageIndex)
+	if required != NoFeatureIndex {
+		features = append([]uint16{required}, features...)
+	}
+
+	var out []tt.Tag
+	seen := make(map[tt.Tag]bool)
+	for _, index := range features {
+		if int(index) >= len(g.Features) {
+			continue
+		}
+		if tag := g.Features[index].Tag; !seen[tag] {
+			seen[tag] = true
+			out = append(out, tag)
+		}
+	}
+	return out
+}
+
+// LayoutLookupGlyphAlternates returns the alternates of `glyph` provided
+// by the given GSUB lookup : the substitute of a single substitution, or the
+// alternates of an alternate substitution. Other lookup types are ignored.
+func (f *Font) LayoutLookupGlyphAlternates(lookupIndex uint16, glyph fonts.GID) []fonts.GID {
+	if f.otTables == nil || int(lookupIndex) >= len(f.otTables.GSUB.Lookups) {
+		return nil
+	}
+	for _, subtable := range f.otTables.GSUB.Lookups[lookupIndex].Subtables {
+		index, ok := subtable.Coverage.Index(glyph)
+		if !ok {
+			continue
+		}
+		switch data := subtable.Data.(type) {
+		case tt.GSUBSingle1:
+			return []fonts.GID{fonts.GID(uint16(int(glyph) + int(data)))}
+		case tt.GSUBSingle2:
+			if index < len(data) {
+				return []fonts.GID{data[index]}
+			}
+		case tt.GSUBAlternate1:
+			if index < len(data) {
+				return append([]fonts.GID(nil), data[index]...)
+			}
+		}
+	}
+	return nil
+}
+
+// LayoutGlyphAlternates returns the alternates of `glyph` provided by the GSUB
+// features with tag `featureTag` (like 'salt', 'swsh', 'aalt' or 'cv01'),
+// without duplicates, in the order of the features and lookups.
+// This is meant to build glyph pickers.
+func (f *Font) LayoutGlyphAlternates(featureTag tt.Tag, glyph fonts.GID) []fonts.GID {
+	g := f.layoutTable(LayoutGSUB)
+	if g == nil {
+		return nil
+	}
+	var out []fonts.GID
+	seen := map[fonts.GID]bool{glyph: true}
+	for _, feature := range g.Features {
+		if feature.Tag != featureTag {
+			continue
+		}
+		for _, lookupIndex := range feature.LookupIndices {
+			for _, alt := range f.LayoutLookupGlyphAlternates(lookupIndex, glyph) {
+				if !seen[alt] {
+					seen[alt] = true
+					out = append(out, alt)
+				}
+			}
+		}
+	}
+	return out
+}
+
+// LayoutFeatureCharacters returns the characters for which the given
+// feature provides glyph variants, as listed by the 'cv01' to 'cv99' features.
+// It returns nil for the other features.
+func (f *Font) LayoutFeatureCharacters(table LayoutTable, featureIndex uint16) []rune {
+	g := f.layoutTable(table)
+	if g == nil || int(featureIndex) >= len(g.Features) {
+		return nil
+	}
+	if params, ok := g.Features[featureIndex].Params.(tt.FeatureParamsCharacterVariants); ok {
+		return params.Characters
+	}
+	return nil
+}
+
+// FeatureNameIDs gathers the name IDs found in the params
+// of the 'ss01' to 'ss20' and 'cv01' to 'cv99' features.
+// Zero values means no name.
+// Use `Font.Name` to retrieve the corresponding strings.
+type FeatureNameIDs struct {
+	// Label is the name of the feature, to be displayed in UI.
+	Label tt.NameID
+	// Tooltip is a description of the feature ('cvXX' only).
+	Tooltip tt.NameID
+	// SampleText is a sample text illustrating the feature ('cvXX' only).
+	SampleText tt.NameID
+	// FirstParamLabel is the name of the first parameter of the feature ('cvXX' only),
+	// the other ones using consecutive IDs.
+	FirstParamLabel tt.NameID
+	// NumNamedParameters is the number of named parameters ('cvXX' only).
+	NumNamedParameters int
+}
+
+// LayoutFeatureNameIDs returns the name IDs of the given feature,
+// or false if it has no names.
+func (f *Font) LayoutFeatureNameIDs(table LayoutTable, featureIndex uint16) (FeatureNameIDs, bool) {
+	g := f.layoutTable(table)
+	if g == nil || int(featureIndex) >= len(g.Features) {
+		return FeatureNameIDs{}, false
+	}
+	switch params := g.Features[featureIndex].Params.(type) {
+	case tt.FeatureParamsStylisticSet:
+		return FeatureNameIDs{Label: params.UINameID}, true
+	case tt.FeatureParamsCharacterVariants:
+		return FeatureNameIDs{
+			Label:              params.FeatUILabelNameID,
+			Tooltip:            params.FeatUITooltipTextNameID,
+			SampleText:         params.SampleTextNameID,
+			FirstParamLabel:    params.FirstParamUILabelNameID,
+			NumNamedParameters: int(params.NumNamedParameters),
+		}, true
+	}
+	return FeatureNameIDs{}, false
+}
+
+// LayoutFeatureName returns the UI label of the given feature,
+// in the language `lang` if available, or false if
+// the font does not provide such a name.
+// This is for instance "Single-storey a" for a 'ss01' feature.
+func (f *Font) LayoutFeatureName(table LayoutTable, featureIndex uint16, lang language.Language) (string, bool) {
+	ids, ok := f.LayoutFeatureNameIDs(table, featureIndex)
+	if !ok || ids.Label == 0 {
+		return "", false
+	}
+	return f.Name(ids.Label, lang)
+}
+
+// LayoutSizeParams returns the params of the GPOS 'size' feature,
+// which describe the optical size range the font is designed for.
+func (f *Font) LayoutSizeParams() (tt.FeatureParamsSize, bool) {
+	g := f.layoutTable(LayoutGPOS)
+	if g == nil {
+		return tt.FeatureParamsSize{}, false
+	}
+	for _, feature := range g.Features {
+		if feature.Tag == newTag('s', 'i', 'z', 'e') {
+			params, ok := feature.Params.(tt.FeatureParamsSize)
+			return params, ok
+		}
+	}
+	return tt.FeatureParamsSize{}, false
+}
+
+// Name returns the entry `id` of the 'name' table, in the language `lang`
+// if available (an empty language selects the default, English name).
+// It returns false if the face does not implement `FaceNamed`
+// or if the name is not found.
+func (f *Font) Name(id tt.NameID, lang language.Language) (string, bool) {
+	named, ok := f.face.(FaceNamed)
+	if !ok {
+		return "", false
+	}
+	names := named.NameTable()
+	var entry *tt.NameEntry
+	if lang == "" {
+		entry = names.SelectEntry(id)
+	} else {
+		entry = names.SelectEntryLanguage(id, string(lang))
+	}
+	if entry == nil {
+		return "", false
+	}
+	return entry.String(), true
+}
+
+// GlyphClass is the class of a glyph, as defined in the GDEF table.
+type GlyphClass uint8
+
+const (
+	GlyphClassUnclassified GlyphClass = iota // Glyphs not matching the other classifications
+	GlyphClassBase                           // Spacing, single characters, capable of accepting marks
+	GlyphClassLigature                       // Glyphs that represent ligation of multiple characters
+	GlyphClassMark                           // Non-spacing, combining glyphs that represent marks
+	GlyphClassComponent                      // Spacing glyphs that represent part of a single character
+)
+
+// LayoutGlyphClass returns the GDEF class of the glyph.
+func (f *Font) LayoutGlyphClass(glyph fonts.GID) GlyphClass {
+	if f.otTables == nil || f.otTables.GDEF.Class == nil {
+		return GlyphClassUnclassified
+	}
+	class, _ := f.otTables.GDEF.Class.ClassID(glyph)
+	if class > uint32(GlyphClassComponent) {
+		return GlyphClassUnclassified
+	}
+	return GlyphClass(class)
+}
@@ -0,0 +1,183 @@
+package harfbuzz
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/benoitkugler/textlayout/fonts"
+	tt "github.com/benoitkugler/textlayout/fonts/truetype"
+	"github.com/benoitkugler/textlayout/language"
+)
+
+func tagsToStrings(tags []tt.Tag) []string {
+	out := make([]string, len(tags))
+	for i, t := range tags {
+		out[i] = t.String()
+	}
+	return out
+}
+
+func TestLayoutQuery(t *testing.T) {
+	font := NewFont(openFontFile("testdata/perf_reference/fonts/NotoSansDevanagari-Regular.ttf"))
+
+	scripts := font.LayoutScriptTags(LayoutGSUB)
+	if exp := []string{"dev2", "deva", "latn"}; !reflect.DeepEqual(tagsToStrings(scripts), exp) {
+		t.Fatalf("expected scripts %v, got %v", exp, tagsToStrings(scripts))
+	}
+	if exp := []string{"MAR ", "NEP "}; !reflect.DeepEqual(tagsToStrings(font.LayoutLanguageTags(LayoutGSUB, 0)), exp) {
+		t.Fatalf("unexpected languages %v", font.LayoutLanguageTags(LayoutGSUB, 0))
+	}
+	if L := len(font.LayoutFeatureTags(LayoutGSUB)); L != 22 {
+		t.Fatalf("expected 22 features, got %d", L)
+	}
+
+	scriptIndex, chosen, found := font.LayoutSelectScript(LayoutGSUB, []tt.Tag{tt.MustNewTag("dev2")})
+	if scriptIndex != 0 || chosen != tt.MustNewTag("dev2") || !found {
+		t.Fatalf("unexpected script %d %s", scriptIndex, chosen)
+	}
+	scriptIndex, chosen, found = font.LayoutSelectScript(LayoutGSUB, []tt.Tag{tt.MustNewTag("arab")})
+	if scriptIndex != 2 || chosen != tt.MustNewTag("latn") || found {
+		t.Fatalf("unexpected fallback script %d %s", scriptIndex, chosen)
+	}
+
+	langIndex, found := font.LayoutSelectLanguage(LayoutGSUB, 0, []tt.Tag{tt.MustNewTag("NEP ")})
+	if langIndex != 1 || !found {
+		t.Fatalf("unexpected language %d", langIndex)
+	}
+	langIndex, found = font.LayoutSelectLanguage(LayoutGSUB, 0, []tt.Tag{tt.MustNewTag("FRA ")})
+	if langIndex != DefaultLanguageIndex || found {
+		t.Fatalf("unexpected language %d", langIndex)
+	}
+
+	features, required := font.LayoutLanguageFeatures(LayoutGSUB, 2, DefaultLanguageIndex)
+	if !reflect.DeepEqual(features, []uint16{5}) || required != NoFeatureIndex {
+		t.Fatalf("unexpected features %v %d", features, required)
+	}
+	features, _ = font.LayoutLanguageFeatures(LayoutGSUB, 0, DefaultLanguageIndex)
+	for _, index := range features {
+		if len(font.LayoutFeatureLookups(LayoutGSUB, index, NoVariationsIndex)) == 0 {
+			t.Fatalf("feature %d without lookups", index)
+		}
+	}
+	if font.LayoutFindFeature(LayoutGSUB, 0, DefaultLanguageIndex, tt.MustNewTag("liga")) != NoFeatureIndex {
+		t.Fatal("unexpected liga feature")
+	}
+	if index := font.LayoutFindFeature(LayoutGSUB, 0, DefaultLanguageIndex, tt.MustNewTag("akhn")); index == NoFeatureIndex {
+		t.Fatal("missing akhn feature")
+	}
+	if font.LayoutFindFeatureVariations(LayoutGSUB) != NoVariationsIndex {
+		t.Fatal("unexpected feature variations")
+	}
+
+	if tags := tagsToStrings(font.LayoutFeaturesFor(LayoutGPOS, language.Devanagari, language.NewLanguage("hi"))); !reflect.DeepEqual(tags, []string{"abvm", "blwm", "dist"}) {
+		t.Fatalf("unexpected GPOS features %v", tags)
+	}
+	if tags := font.LayoutFeaturesFor(LayoutGSUB, language.Devanagari, language.NewLanguage("hi")); len(tags) != 13 {
+		t.Fatalf("unexpected GSUB features %v", tagsToStrings(tags))
+	}
+
+	if L := font.LayoutLookupCount(LayoutGPOS); L != 33 {
+		t.Fatalf("expected 33 GPOS lookups, got %d", L)
+	}
+	lookup, ok := font.LayoutLookupInfo(LayoutGPOS, 0)
+	if !ok || lookup.Type != uint16(tt.GPOSMarkToBase) || lookup.NumSubtables != 1 {
+		t.Fatalf("unexpected lookup %v", lookup)
+	}
+	if _, ok := font.LayoutLookupInfo(LayoutGPOS, 33); ok {
+		t.Fatal("expected invalid lookup")
+	}
+
+	if class := font.LayoutGlyphClass(10); class != GlyphClassBase {
+		t.Fatalf("expected base glyph, got %d", class)
+	}
+}
+
+func TestLayoutQueryNoTables(t *testing.T) {
+	font := NewFont(openFontFile("testdata/perf_reference/fonts/Roboto-Regular.ttf"))
+	font.otTables = nil
+	if font.LayoutScriptTags(LayoutGSUB) != nil || font.LayoutLookupCount(LayoutGPOS) != 0 ||
+		font.LayoutFeaturesFor(LayoutGSUB, language.Latin, "") != nil || font.LayoutGlyphClass(1) != GlyphClassUnclassified {
+		t.Fatal("expected empty results")
+	}
+}
+
+func TestLayoutGlyphAlternates(t *testing.T) {
+	font := NewFont(openFontFile("testdata/harfbuzz_reference/aots/fonts/gsub3_1_simple_f1.otf"))
+	if alts := font.LayoutLookupGlyphAlternates(0, 18); !reflect.DeepEqual(alts, []fonts.GID{20, 21, 22}) {
+		t.Fatalf("unexpected alternates %v", alts)
+	}
+	assertEqualInt(t, 0, len(font.LayoutLookupGlyphAlternates(0, 19)))
+	assertEqualInt(t, 0, len(font.LayoutLookupGlyphAlternates(10, 18)))
+	tag := font.LayoutFeatureTags(LayoutGSUB)[0]
+	if alts := font.LayoutGlyphAlternates(tag, 18); !reflect.DeepEqual(alts, []fonts.GID{20, 21, 22}) {
+		t.Fatalf("unexpected alternates %v", alts)
+	}
+
+	// single substitutions
+	font = NewFont(openFontFile("testdata/perf_reference/fonts/Roboto-Regular.ttf"))
+	if alts := font.LayoutGlyphAlternates(tt.MustNewTag("salt"), 48); !reflect.DeepEqual(alts, []fonts.GID{1962}) {
+		t.Fatalf("unexpected alternates %v", alts)
+	}
+	assertEqualInt(t, 0, len(font.LayoutGlyphAlternates(tt.MustNewTag("swsh"), 48)))
+}
+
+func TestLayoutFeatureCharacters(t *testing.T) {
+	font := NewFont(openFontFile("testdata/fonts/cv01.otf"))
+	if chars := font.LayoutFeatureCharacters(LayoutGSUB, 0); !reflect.DeepEqual(chars, []rune{10, 24030}) {
+		t.Fatalf("unexpected characters %v", chars)
+	}
+	assertEqualInt(t, 0, len(font.LayoutFeatureCharacters(LayoutGSUB, 1)))
+	assertEqualInt(t, 0, len(font.LayoutFeatureCharacters(LayoutGPOS, 0)))
+	if alts := font.LayoutGlyphAlternates(tt.MustNewTag("cv01"), 1); !reflect.DeepEqual(alts, []fonts.GID{2}) {
+		t.Fatalf("unexpected alternates %v", alts)
+	}
+}
+
+func TestLayoutFeatureNames(t *testing.T) {
+	font := NewFont(openFontFile("testdata/fonts/cv01.otf"))
+	ids, ok := font.LayoutFeatureNameIDs(LayoutGSUB, 0)
+	assert(t, ok)
+	if exp := (FeatureNameIDs{Label: 256, Tooltip: 257, SampleText: 258, FirstParamLabel: 259, NumNamedParameters: 2}); ids != exp {
+		t.Fatalf("expected %v, got %v", exp, ids)
+	}
+	for id, exp := range map[tt.NameID]string{
+		ids.Label:               "uilabel simple a",
+		ids.Tooltip:             "tool tip simple a",
+		ids.SampleText:          "sample text simple a",
+		ids.FirstParamLabel:     "param1 text simple a",
+		ids.FirstParamLabel + 1: "param2 text simple a",
+	} {
+		name, ok := font.Name(id, "")
+		assert(t, ok)
+		if name != exp {
+			t.Fatalf("expected %s, got %s", exp, name)
+		}
+	}
+	// no french name : fallback to english
+	if name, _ := font.LayoutFeatureName(LayoutGSUB, 0, language.NewLanguage("fr")); name != "uilabel simple a" {
+		t.Fatalf("unexpected name %s", name)
+	}
+	_, ok = font.Name(300, "")
+	assert(t, !ok)
+
+	font = NewFont(openFontFile("testdata/perf_reference/fonts/Amiri-Regular.ttf"))
+	index := font.LayoutFindFeature(LayoutGSUB, 0, DefaultLanguageIndex, tt.MustNewTag("ss01"))
+	assert(t, index != NoFeatureIndex)
+	if name, _ := font.LayoutFeatureName(LayoutGSUB, index, language.NewLanguage("en-us")); name != "Low Baa dot following a Raa or Waw" {
+		t.Fatalf("unexpected name %s", name)
+	}
+	index = font.LayoutFindFeature(LayoutGSUB, 0, DefaultLanguageIndex, tt.MustNewTag("init"))
+	_, ok = font.LayoutFeatureNameIDs(LayoutGSUB, index)
+	assert(t, !ok)
+	_, ok = font.LayoutSizeParams()
+	assert(t, !ok)
+}
+
+func TestLayoutSizeParams(t *testing.T) {
+	font := NewFont(openFontFile("testdata/harfbuzz_reference/text-rendering-tests/fonts/AdobeVFPrototype-Subset.otf"))
+	params, ok := font.LayoutSizeParams()
+	assert(t, ok)
+	if exp := (tt.FeatureParamsSize{DesignSize: 100}); params != exp {
+		t.Fatalf("expected %v, got %v", exp, params)
+	}
+}
@@ -467,6 +467,10 @@ func (m *otMap) position(plan *otShapePlan, font *Font, buffer *Buffer) {
 
 func (m *otMap) apply(proxy otProxy, plan *otShapePlan, font *Font, buffer *Buffer) {
 	tableIndex := proxy.tableIndex
+	step := TraceGSUB
+	if tableIndex == 1 {
+		step = TraceGPOS
+	}
 	i := 0
 	c := newOtApplyContext(tableIndex, font, buffer)
 	c.recurseFunc = proxy.recurseFunc
@@ -479,6 +483,9 @@ func (m *otMap) apply(proxy otProxy, plan *otShapePlan, font *Font, buffer *Buff
 
 		for ; i < stage.lastLookup; i++ {
 			lookupIndex := m.lookups[tableIndex][i].index
+			if buffer.jstf.isDisabled(tableIndex, lookupIndex) {
+				continue
+			}
 
 			if debugMode >= 1 {
 				fmt.Printf("\t\tLookup %d start\n", lookupIndex)
@@ -494,7 +501,11 @@ func (m *otMap) apply(proxy otProxy, plan *otShapePlan, font *Font, buffer *Buff
 			if len(c.buffer.Info) > c.buffer.maxLen {
 				return
 			}
+			if !buffer.trace(step, int(lookupIndex), true) {
+				continue
+			}
 			c.applyString(proxy.otProxyMeta, &proxy.accels[lookupIndex])
+			buffer.trace(step, int(lookupIndex), false)
 
 			if debugMode >= 1 {
 				fmt.Println("\t\tLookup end")
@@ -508,7 +519,24 @@ func (m *otMap) apply(proxy otProxy, plan *otShapePlan, font *Font, buffer *Buff
 				fmt.Println("\t\tExecuting pause function")
 			}
 
+			buffer.trace(TracePause, stageI, true)
 			stage.pauseFunc(plan, font, buffer)
+			buffer.trace(TracePause, stageI, false)
+		}
+	}
+
+	// lookups enabled by JSTF priorities, not already applied
+	for _, lookupIndex := range buffer.jstf.extraLookups(tableIndex, m.lookups[tableIndex], len(proxy.accels)) {
+		c.lookupIndex = lookupIndex
+		c.setLookupMask(m.globalMask)
+		c.setAutoZWJ(true)
+		c.setAutoZWNJ(true)
+		c.random = false
+
+		if !buffer.trace(step, int(lookupIndex), true) {
+			continue
 		}
+		c.applyString(proxy.otProxyMeta, &proxy.accels[lookupIndex])
+		buffer.trace(step, int(lookupIndex), false)
 	}
 }
@@ -72,8 +72,12 @@ type otNormalizeContext struct {
 	compose   func(c *otNormalizeContext, a, b rune) (ab rune, ok bool)
 }
 
-func setGlyph(info *GlyphInfo, font *Font) {
-	info.Glyph, _ = font.face.NominalGlyph(info.codepoint)
+func setGlyph(info *GlyphInfo, font *Font, notFound fonts.GID) {
+	var ok bool
+	info.Glyph, ok = font.face.NominalGlyph(info.codepoint)
+	if !ok {
+		info.Glyph = notFound
+	}
 }
 
 func outputChar(buffer *Buffer, unichar rune, glyph fonts.GID) {
@@ -136,6 +140,9 @@ func (c *otNormalizeContext) decomposeCurrentCharacter(shortest bool) {
 	buffer := c.buffer
 	u := buffer.cur(0).codepoint
 	glyph, ok := c.font.face.NominalGlyph(u)
+	if !ok {
+		glyph = buffer.NotFound
+	}
 
 	if shortest && ok {
 		nextChar(buffer, glyph)
@@ -171,7 +178,7 @@ func (c *otNormalizeContext) decomposeCurrentCharacter(shortest bool) {
 		}
 	}
 
-	nextChar(buffer, glyph)
+	nextChar(buffer, glyph) // glyph is initialized in earlier branches.
 }
 
 func (c *otNormalizeContext) handleVariationSelectorCluster(end int) {
@@ -183,29 +190,31 @@ func (c *otNormalizeContext) handleVariationSelectorCluster(end int) {
 	for buffer.idx < end-1 {
 		if uni.isVariationSelector(buffer.cur(+1).codepoint) {
 			var ok bool
-			buffer.cur(0).Glyph, ok = font.face.(FaceOpentype).VariationGlyph(buffer.cur(0).codepoint, buffer.cur(+1).codepoint)
+			if face, isOpentype := font.face.(FaceOpentype); isOpentype {
+				buffer.cur(0).Glyph, ok = face.VariationGlyph(buffer.cur(0).codepoint, buffer.cur(+1).codepoint)
+			}
 			if ok {
 				r := buffer.cur(0).codepoint
 				buffer.replaceGlyphs(2, []rune{r}, nil)
 			} else {
 				// Just pass on the two characters separately, let GSUB do its magic.
-				setGlyph(buffer.cur(0), font)
+				setGlyph(buffer.cur(0), font, buffer.NotFound)
 				buffer.nextGlyph()
-				setGlyph(buffer.cur(0), font)
+				setGlyph(buffer.cur(0), font, buffer.NotFound)
 				buffer.nextGlyph()
 			}
 			// skip any further variation selectors.
 			for buffer.idx < end && uni.isVariationSelector(buffer.cur(0).codepoint) {
-				setGlyph(buffer.cur(0), font)
+				setGlyph(buffer.cur(0), font, buffer.NotFound)
 				buffer.nextGlyph()
 			}
 		} else {
-			setGlyph(buffer.cur(0), font)
+			setGlyph(buffer.cur(0), font, buffer.NotFound)
 			buffer.nextGlyph()
 		}
 	}
 	if buffer.idx < end {
-		setGlyph(buffer.cur(0), font)
+		setGlyph(buffer.cur(0), font, buffer.NotFound)
 		buffer.nextGlyph()
 	}
 }
@@ -514,10 +514,7 @@ func hideDefaultIgnorables(buffer *Buffer, font *Font) {
 
 	info := buffer.Info
 
-	var (
-		invisible = buffer.Invisible
-		ok        bool
-	)
+	invisible, ok := buffer.Invisible, true
 	if invisible == 0 {
 		invisible, ok = font.face.NominalGlyph(' ')
 	}
@@ -560,7 +557,9 @@ func (c *otContext) substituteBeforePosition() {
 
 	c.otRotateChars()
 
+	buffer.trace(TraceNormalize, 0, true)
 	otShapeNormalize(c.plan, buffer, c.font)
+	buffer.trace(TraceNormalize, 0, false)
 
 	c.setupMasks()
 
@@ -590,7 +589,9 @@ func (c *otContext) substituteAfterPosition() {
 	if debugMode >= 1 {
 		fmt.Printf("POSTPROCESS glyphs start (%T)\n", c.plan.shaper)
 	}
+	c.buffer.trace(TracePostprocess, 0, true)
 	c.plan.shaper.postprocessGlyphs(c.plan, c.buffer, c.font)
+	c.buffer.trace(TracePostprocess, 0, false)
 	if debugMode >= 1 {
 		fmt.Println("POSTPROCESS glyphs end ")
 	}
@@ -709,26 +710,47 @@ func (c *otContext) position() {
 /* Propagate cluster-level glyph flags to be the same on all cluster glyphs.
  * Simplifies using them. */
 func propagateFlags(buffer *Buffer) {
-	if buffer.scratchFlags&bsfHasUnsafeToBreak == 0 {
+	if buffer.scratchFlags&bsfHasGlyphFlags == 0 {
 		return
 	}
 
+	/* If we are producing SAFE_TO_INSERT_TATWEEL, then do two things:
+	 *
+	 * - If the places that the Arabic shaper marked as SAFE_TO_INSERT_TATWEEL,
+	 *   are UNSAFE_TO_BREAK, then clear the SAFE_TO_INSERT_TATWEEL,
+	 * - Any place that is SAFE_TO_INSERT_TATWEEL, is also now UNSAFE_TO_BREAK.
+	 *
+	 * We couldn't make this interaction earlier. It has to be done here.
+	 */
+	flipTatweel := buffer.Flags&ProduceSafeToInsertTatweel != 0
+
+	clearConcat := buffer.Flags&ProduceUnsafeToConcat == 0
+
 	info := buffer.Info
 
 	iter, count := buffer.clusterIterator()
 	for start, end := iter.next(); start < count; start, end = iter.next() {
-		var mask uint32
+		var mask GlyphMask
 		for i := start; i < end; i++ {
-			if info[i].Mask&GlyphUnsafeToBreak != 0 {
-				mask = GlyphUnsafeToBreak
-				break
-			}
+			mask |= info[i].Mask & glyphFlagDefined
 		}
-		if mask != 0 {
-			for i := start; i < end; i++ {
-				info[i].Mask |= mask
+
+		if flipTatweel {
+			if mask&GlyphUnsafeToBreak != 0 {
+				mask &= ^GlyphSafeToInsertTatweel
+			}
+			if mask&GlyphSafeToInsertTatweel != 0 {
+				mask |= GlyphUnsafeToBreak | GlyphUnsafeToConcat
 			}
 		}
+
+		if clearConcat {
+			mask &= ^GlyphUnsafeToConcat
+		}
+
+		for i := start; i < end; i++ {
+			info[i].Mask = (info[i].Mask & ^glyphFlagDefined) | mask
+		}
 	}
 }
 
@@ -790,7 +812,9 @@ func (sp *shaperOpentype) shape(font *Font, buffer *Buffer, features []Feature)
 	if debugMode >= 1 {
 		fmt.Printf("PREPROCESS text start (complex shaper %T)\n", c.plan.shaper)
 	}
+	c.buffer.trace(TracePreprocess, 0, true)
 	c.plan.shaper.preprocessText(c.plan, c.buffer, c.font)
+	c.buffer.trace(TracePreprocess, 0, false)
 	if debugMode >= 1 {
 		fmt.Println("PREPROCESS text end")
 	}
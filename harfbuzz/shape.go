@@ -1,9 +1,6 @@
 package harfbuzz
 
-import (
-	"fmt"
-	"sync"
-)
+import "fmt"
 
 // ported from harfbuzz/src/hb-shape.cc, harfbuzz/src/hb-shape-plan.cc Copyright © 2009, 2012 Behdad Esfahbod
 
@@ -26,8 +23,9 @@ import (
 // It also depends on the properties of the segment of text : the `Props`
 // field of the buffer must be set before calling `Shape`.
 func (b *Buffer) Shape(font *Font, features []Feature) {
-	shapePlan := newShapePlanCached(font, b.Props, features, font.varCoords())
+	shapePlan := font.shapePlan(b.Props, features)
 	shapePlan.execute(font, b, features)
+	b.shaped = true
 }
 
 type shaperKind uint8
@@ -110,14 +108,9 @@ func (plan shapePlan) userFeaturesMatch(other shapePlan) bool {
 	return true
 }
 
-func (plan shapePlan) equal(other shapePlan) bool {
-	return plan.props == other.props &&
-		plan.userFeaturesMatch(other) && plan.shaper.kind() == other.shaper.kind()
-}
-
 // Constructs a shaping plan for a combination of @face, @userFeatures, @props,
 // plus the variation-space coordinates @coords.
-// See newShapePlanCached for caching support.
+// See `ShapePlanCache` for caching support.
 func newShapePlan(font *Font, props SegmentProperties,
 	userFeatures []Feature, coords []float32) *shapePlan {
 	if debugMode >= 1 {
@@ -146,44 +139,12 @@ func (sp *shapePlan) execute(font *Font, buffer *Buffer, features []Feature) {
 	sp.shaper.shape(font, buffer, features)
 }
 
-/*
- * Caching
- */
-
-var (
-	planCache     = map[Face][]*shapePlan{}
-	planCacheLock sync.Mutex
-)
-
-// creates (or returns) a cached shaping plan suitable for reuse, for a combination
-// of `face`, `userFeatures`, `props`, plus the variation-space coordinates `coords`.
-func newShapePlanCached(font *Font, props SegmentProperties,
-	userFeatures []Feature, coords []float32) *shapePlan {
-
-	var key shapePlan
-	key.init(false, font, props, userFeatures, coords)
-
-	planCacheLock.Lock()
-	defer planCacheLock.Unlock()
-
-	plans := planCache[font.face]
-
-	for _, plan := range plans {
-		if plan.equal(key) {
-			if debugMode >= 1 {
-				fmt.Printf("\tPLAN %p fulfilled from cache\n", plan)
-			}
-			return plan
-		}
+// shapePlan returns the plan to use with the given properties and features,
+// using the cache of the font, if any.
+func (font *Font) shapePlan(props SegmentProperties, userFeatures []Feature) *shapePlan {
+	coords := font.varCoords()
+	if font.PlanCache == nil {
+		return newShapePlan(font, props, userFeatures, coords)
 	}
-	plan := newShapePlan(font, props, userFeatures, coords)
-
-	plans = append(plans, plan)
-	planCache[font.face] = plans
-
-	if debugMode >= 1 {
-		fmt.Printf("\tPLAN %p inserted into cache\n", plan)
-	}
-
-	return plan
+	return font.PlanCache.get(font, props, userFeatures, coords)
 }
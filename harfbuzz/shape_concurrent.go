@@ -0,0 +1,102 @@
+package harfbuzz
+
+import (
+	"runtime"
+	"sync"
+)
+
+// BufferPool stores buffers which may be reused across shaping calls,
+// to reduce allocations when shaping many segments.
+// The zero value is ready to use, and a pool is safe for concurrent use.
+type BufferPool struct {
+	pool sync.Pool
+}
+
+// Get returns an empty buffer, with the same settings as
+// a buffer returned by `NewBuffer`, but possibly reusing
+// the storage of a previously released buffer.
+func (p *BufferPool) Get() *Buffer {
+	b, ok := p.pool.Get().(*Buffer)
+	if !ok {
+		return NewBuffer()
+	}
+	b.Clear()
+	b.ClusterLevel = MonotoneGraphemes
+	b.Tracer = nil
+	b.jstf = nil
+	return b
+}
+
+// Put releases `b` to the pool. `b` (including its `Info` and `Pos` slices)
+// must not be used after this call.
+func (p *BufferPool) Put(b *Buffer) {
+	if b != nil {
+		p.pool.Put(b)
+	}
+}
+
+// ShapeRequest describes one segment of text shaped by `ShapeMany`.
+type ShapeRequest struct {
+	Font     *Font
+	Features []Feature
+
+	// Text is the paragraph containing the segment, which is
+	// given by ItemOffset and ItemLength, as in `Buffer.AddRunes`.
+	Text                   []rune
+	ItemOffset, ItemLength int
+
+	// Props is used for the buffer properties. The properties
+	// not set are guessed from the text (see `Buffer.GuessSegmentProperties`).
+	Props SegmentProperties
+
+	// Flags and ClusterLevel are copied to the buffer.
+	Flags        ShappingOptions
+	ClusterLevel ClusterLevel
+}
+
+// ShapeMany shapes the given segments, using up to `workers` goroutines (if `workers` <= 0,
+// GOMAXPROCS is used). The fonts may be shared between requests (see `Font`).
+//
+// The returned buffers store the shaping results, in the order of `requests`.
+// They are taken from `pool`, if not nil, and may be released once used.
+func ShapeMany(requests []ShapeRequest, workers int, pool *BufferPool) []*Buffer {
+	if pool == nil {
+		pool = new(BufferPool)
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(requests) {
+		workers = len(requests)
+	}
+
+	out := make([]*Buffer, len(requests))
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				out[i] = requests[i].shape(pool.Get())
+			}
+		}()
+	}
+	for i := range requests {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+
+	return out
+}
+
+func (req ShapeRequest) shape(b *Buffer) *Buffer {
+	b.Flags = req.Flags
+	b.ClusterLevel = req.ClusterLevel
+	b.AddRunes(req.Text, req.ItemOffset, req.ItemLength)
+	b.Props = req.Props
+	b.GuessSegmentProperties()
+	b.Shape(req.Font, req.Features)
+	return b
+}
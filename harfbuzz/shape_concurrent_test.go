@@ -0,0 +1,132 @@
+package harfbuzz
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/benoitkugler/textlayout/fonts"
+)
+
+var concurrentTexts = []string{
+	"Hello world, an office with ffi ligatures",
+	"بسم الله الرحمن الرحيم",
+	"हिन्दी भाषा का इतिहास",
+	"abc 123 (x)",
+}
+
+func concurrentFonts() []*Font {
+	var out []*Font
+	for _, file := range []string{
+		"testdata/perf_reference/fonts/Roboto-Regular.ttf",
+		"testdata/perf_reference/fonts/Amiri-Regular.ttf",
+		"testdata/perf_reference/fonts/NotoSansDevanagari-Regular.ttf",
+		"testdata/fonts/aat-morx.ttf",
+		"testdata/fonts/Simple-Graphite-Font.ttf",
+	} {
+		out = append(out, NewFont(openFontFile(file)))
+	}
+	return out
+}
+
+func sameShaping(b1, b2 *Buffer) bool {
+	return b1.Diff(b2, ^fonts.GID(0), 0) == DiffEqual
+}
+
+func TestBufferPool(t *testing.T) {
+	var pool BufferPool
+	for i := 0; i < 10; i++ {
+		b := pool.Get()
+		assertEqualInt(t, len(b.Info), 0)
+		assertEqualInt(t, len(b.Pos), 0)
+		assert(t, b.Flags == 0 && b.ClusterLevel == MonotoneGraphemes && b.Props == SegmentProperties{})
+		assert(t, b.Replacement == NewBuffer().Replacement && b.Tracer == nil)
+
+		b.AddRunes([]rune("some text"), 0, -1)
+		b.Flags = RemoveDefaultIgnorables
+		b.ClusterLevel = Characters
+		b.GuessSegmentProperties()
+		b.Tracer = func(TraceEvent) bool { return true }
+		pool.Put(b)
+	}
+	pool.Put(nil) // no-op
+}
+
+func TestShapeMany(t *testing.T) {
+	fonts := concurrentFonts()
+	var requests []ShapeRequest
+	for _, font := range fonts {
+		for _, text := range concurrentTexts {
+			runes := []rune(text)
+			requests = append(requests,
+				ShapeRequest{Font: font, Text: runes, ItemLength: -1},
+				ShapeRequest{Font: font, Text: runes, ItemOffset: 2, ItemLength: len(runes) - 4, ClusterLevel: Characters},
+			)
+		}
+	}
+
+	expected := make([]*Buffer, len(requests))
+	for i, req := range requests {
+		expected[i] = req.shape(NewBuffer())
+	}
+
+	var pool BufferPool
+	for _, workers := range []int{0, 1, 3, 100} {
+		got := ShapeMany(requests, workers, &pool)
+		assertEqualInt(t, len(got), len(requests))
+		for i, b := range got {
+			if !sameShaping(b, expected[i]) {
+				t.Fatalf("workers %d, request %d: expected %v, got %v", workers, i, expected[i].Info, b.Info)
+			}
+			assert(t, b.ClusterLevel == requests[i].ClusterLevel)
+			pool.Put(b)
+		}
+	}
+
+	assertEqualInt(t, len(ShapeMany(nil, 0, nil)), 0)
+}
+
+// TestShapeConcurrent shares the fonts between goroutines :
+// it should be run with the race detector.
+func TestShapeConcurrent(t *testing.T) {
+	fonts := concurrentFonts()
+	expected := make([][]*Buffer, len(fonts))
+	for i, font := range fonts {
+		for _, text := range concurrentTexts {
+			req := ShapeRequest{Font: font, Text: []rune(text), ItemLength: -1}
+			expected[i] = append(expected[i], req.shape(NewBuffer()))
+		}
+		font.PlanCache.Clear() // also exercise the plan compilation
+	}
+
+	var (
+		pool   BufferPool
+		wg     sync.WaitGroup
+		failed = make(chan int, 8)
+	)
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for iter := 0; iter < 5; iter++ {
+				for i, font := range fonts {
+					for j, text := range concurrentTexts {
+						req := ShapeRequest{Font: font, Text: []rune(text), ItemLength: -1}
+						b := req.shape(pool.Get())
+						font.GlyphExtents(b.Info[0].Glyph)
+						font.ExtentsForDirection(b.Props.Direction)
+						if !sameShaping(b, expected[i][j]) {
+							failed <- w
+							return
+						}
+						pool.Put(b)
+					}
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(failed)
+	for w := range failed {
+		t.Errorf("unexpected shaping result in worker %d", w)
+	}
+}
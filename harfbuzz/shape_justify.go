@@ -0,0 +1,344 @@
+package harfbuzz
+
+import (
+	"sort"
+
+	"github.com/benoitkugler/textlayout/fonts/truetype"
+)
+
+// ported from src/hb-shape.cc Copyright © 2009  Red Hat, Inc., 2012  Google, Inc.  Behdad Esfahbod
+
+const (
+	tatweel = 0x0640
+
+	// maximum number of shaping done when searching for the axis value
+	justifyMaxIterations = 24
+)
+
+// JustifyOptions defines the target of `Buffer.ShapeJustify`.
+type JustifyOptions struct {
+	// MinAdvance and MaxAdvance define the acceptable range
+	// for the total advance of the shaped text, in font scale units.
+	MinAdvance, MaxAdvance Position
+
+	// JSTF enables the justification suggestions of the font 'JSTF' table
+	// (see `Font.LayoutJustificationPriorities`), which are tried first :
+	// the shrinkage or extension lookup modifications are applied, one priority
+	// level after the other, until the target is reached.
+	// The maximum adjustments (`JstfModifications.Max`) are ignored.
+	JSTF bool
+
+	// Axis is the variation axis used to adjust the advance.
+	// If zero, the 'jstf' axis is used when the font has one, then the 'wdth' axis.
+	// Set it to a tag not supported by the font to disable the use of variations.
+	Axis truetype.Tag
+
+	// Kashida enables the insertion of U+0640 TATWEEL characters, when
+	// the text is still too short after the axis adjustment.
+	// Tatweels are only inserted where the shaper reports
+	// it is safe (see `GlyphSafeToInsertTatweel`), so this is mostly
+	// useful for scripts using elongation, like Arabic.
+	Kashida bool
+}
+
+// JustifyResult describes the adjustments done by `Buffer.ShapeJustify`.
+type JustifyResult struct {
+	// Advance is the total advance of the shaped text.
+	Advance Position
+	// Priorities is the number of JSTF priority levels applied.
+	Priorities int
+	// Axis is the variation axis used, or zero if none was used.
+	Axis truetype.Tag
+	// AxisValue is the value of Axis, in design-space units.
+	AxisValue float32
+	// Kashidas is the number of tatweels inserted.
+	Kashidas int
+	// Justified is true if Advance is between the targets.
+	Justified bool
+}
+
+func (opts JustifyOptions) contains(advance Position) bool {
+	return opts.MinAdvance <= advance && advance <= opts.MaxAdvance
+}
+
+// ShapeJustify shapes the buffer content (like `Shape`), adjusting the
+// result so that its total advance fits in the range [opts.MinAdvance, opts.MaxAdvance].
+//
+// The text is first shaped as is. If its advance is out of range, the JSTF
+// suggestions are tried (see `JustifyOptions.JSTF`). Then the value
+// of a variation axis is searched for by shaping repeatedly : the variation coordinates
+// of the font face are modified during the search, and restored before returning.
+// The value used for the final result is reported in `JustifyResult.AxisValue`, and
+// should be applied to render the glyphs (see `Font.SetVarCoordsDesign`).
+// If the text is still too short, tatweels may be inserted (see `JustifyOptions.Kashida`).
+// The inserted characters have the cluster of the character following them.
+//
+// When the target can't be reached, the closest result found is kept in the buffer.
+func (b *Buffer) ShapeJustify(font *Font, features []Feature, opts JustifyOptions) JustifyResult {
+	input := append([]GlyphInfo(nil), b.Info...)
+	if opts.Kashida {
+		flags := b.Flags
+		b.Flags |= ProduceSafeToInsertTatweel
+		defer func() { b.Flags = flags }()
+	}
+	defer func() { b.jstf = nil }()
+
+	var res JustifyResult
+	res.Advance = b.shapeInput(font, features, input)
+	if opts.contains(res.Advance) {
+		res.Justified = true
+		return res
+	}
+
+	if opts.JSTF {
+		res.Priorities, res.Advance = b.justifyWithJSTF(font, features, input, res.Advance, opts)
+		if opts.contains(res.Advance) {
+			res.Justified = true
+			return res
+		}
+	}
+
+	if face, axisIndex := font.justifyAxis(opts.Axis); axisIndex != -1 {
+		defer face.SetVarCoordinates(append([]float32(nil), face.VarCoordinates()...))
+
+		res.Axis = face.Variations().Axis[axisIndex].Tag
+		// the target is the closest end of the accepted range
+		target := opts.MaxAdvance
+		if res.Advance < opts.MinAdvance {
+			target = opts.MinAdvance
+		}
+		res.AxisValue, res.Advance = b.justifyWithAxis(font, features, input, face, axisIndex, target, opts)
+	}
+
+	if opts.Kashida && res.Advance < opts.MinAdvance {
+		res.Kashidas, res.Advance = b.justifyWithKashidas(font, features, input, res.Advance, opts)
+	}
+
+	res.Justified = opts.contains(res.Advance)
+	return res
+}
+
+// shapeInput restores the runes in `input`, shapes them
+// and returns the total advance
+func (b *Buffer) shapeInput(font *Font, features []Feature, input []GlyphInfo) Position {
+	b.Info = append(b.Info[:0], input...)
+	b.Pos = append(b.Pos[:0], make([]GlyphPosition, len(input))...)
+	b.outInfo = b.outInfo[:0]
+	b.scratchFlags = 0
+	b.haveOutput = false
+	b.idx = 0
+
+	b.Shape(font, features)
+
+	var advance Position
+	for _, pos := range b.Pos {
+		if b.Props.Direction.isHorizontal() {
+			advance += pos.XAdvance
+		} else {
+			advance -= pos.YAdvance
+		}
+	}
+	return advance
+}
+
+// justifyWithJSTF applies the JSTF priorities, until the target is reached,
+// and returns the number of priorities applied and the new advance.
+// The modifications are kept in `b.jstf` for the next steps.
+func (b *Buffer) justifyWithJSTF(font *Font, features []Feature, input []GlyphInfo,
+	advance Position, opts JustifyOptions) (int, Position) {
+	priorities := font.LayoutJustificationPriorities(b.Props.Script, b.Props.Language)
+	if len(priorities) == 0 {
+		return 0, advance
+	}
+	shrink := advance > opts.MaxAdvance
+	b.jstf = newJstfLookups()
+	for i, priority := range priorities {
+		if shrink {
+			b.jstf.add(priority.Shrinkage)
+		} else {
+			b.jstf.add(priority.Extension)
+		}
+		advance = b.shapeInput(font, features, input)
+		if shrink && advance <= opts.MaxAdvance || !shrink && advance >= opts.MinAdvance {
+			return i + 1, advance
+		}
+	}
+	return len(priorities), advance
+}
+
+// justifyAxis returns the variable face and the index of the axis
+// used for justification, or -1
+func (f *Font) justifyAxis(tag truetype.Tag) (FaceOpentype, int) {
+	face, ok := f.face.(FaceOpentype)
+	if !ok {
+		return nil, -1
+	}
+	candidates := []truetype.Tag{tag}
+	if tag == 0 {
+		candidates = []truetype.Tag{truetype.MustNewTag("jstf"), truetype.MustNewTag("wdth")}
+	}
+	axes := face.Variations().Axis
+	for _, candidate := range candidates {
+		for i, axis := range axes {
+			if axis.Tag == candidate && axis.Minimum < axis.Maximum {
+				return face, i
+			}
+		}
+	}
+	return nil, -1
+}
+
+// justifyWithAxis searches for the value of the given axis reaching `target`
+// (or at least the range of `opts`), and returns it, with the advance of the result stored in `b`.
+func (b *Buffer) justifyWithAxis(font *Font, features []Feature, input []GlyphInfo,
+	face FaceOpentype, axisIndex int, target Position, opts JustifyOptions) (float32, Position) {
+	fvar := face.Variations()
+	axis := fvar.Axis[axisIndex]
+	coords := make([]float32, len(fvar.Axis))
+	copy(coords, face.VarCoordinates())
+
+	current := float32(-1)
+	shapeAt := func(value float32) Position {
+		design := fvar.GetDesignCoordsDefault(nil)
+		design[axisIndex] = value
+		coords[axisIndex] = face.NormalizeVariations(design)[axisIndex]
+		face.SetVarCoordinates(coords)
+		current = value
+		return b.shapeInput(font, features, input)
+	}
+
+	lo, hi := axis.Minimum, axis.Maximum
+	advLo, advHi := shapeAt(lo), shapeAt(hi)
+
+	// we look for a root of advance - target
+	gLo, gHi := float32(advLo-target), float32(advHi-target)
+
+	best, bestAdvance := hi, advHi
+	if abs32(gLo) < abs32(gHi) {
+		best, bestAdvance = lo, advLo
+	}
+
+	if (gLo < 0) != (gHi < 0) && !opts.contains(bestAdvance) {
+		// regula falsi, with the Illinois modification
+		side := 0
+		for iter := 0; iter < justifyMaxIterations; iter++ {
+			value := (lo*gHi - hi*gLo) / (gHi - gLo)
+			if value <= lo || value >= hi {
+				value = (lo + hi) / 2
+			}
+			advance := shapeAt(value)
+			if abs32(float32(advance-target)) < abs32(float32(bestAdvance-target)) || opts.contains(advance) {
+				best, bestAdvance = value, advance
+			}
+			if opts.contains(advance) {
+				break
+			}
+			g := float32(advance - target)
+			if (g < 0) == (gLo < 0) {
+				lo, gLo = value, g
+				if side == -1 {
+					gHi /= 2
+				}
+				side = -1
+			} else {
+				hi, gHi = value, g
+				if side == +1 {
+					gLo /= 2
+				}
+				side = +1
+			}
+		}
+	}
+
+	if current != best {
+		bestAdvance = shapeAt(best)
+	}
+	return best, bestAdvance
+}
+
+// justifyWithKashidas inserts tatweels in `input` where it is
+// safe to do so, according to the current content of `b`,
+// and returns the number of tatweels inserted and the new advance.
+func (b *Buffer) justifyWithKashidas(font *Font, features []Feature, input []GlyphInfo,
+	advance Position, opts JustifyOptions) (int, Position) {
+	if _, ok := font.face.NominalGlyph(tatweel); !ok {
+		return 0, advance
+	}
+
+	// clusters before which tatweels may be inserted, in logical order
+	var positions []int
+	seen := make(map[int]bool)
+	for _, info := range b.Info {
+		if info.Mask&GlyphSafeToInsertTatweel != 0 && !seen[info.Cluster] {
+			seen[info.Cluster] = true
+			positions = append(positions, info.Cluster)
+		}
+	}
+	if len(positions) == 0 {
+		return 0, advance
+	}
+	sort.Ints(positions)
+
+	// the nominal glyph may be substituted, so
+	// its width is estimated by shaping a lone tatweel
+	single := NewBuffer()
+	single.Props = b.Props
+	width := single.shapeInput(font, features, []GlyphInfo{{codepoint: tatweel}})
+	if width <= 0 {
+		return 0, advance
+	}
+
+	shapeWith := func(n int) Position {
+		counts := make(map[int]int, len(positions))
+		for i := 0; i < n; i++ {
+			counts[positions[i%len(positions)]]++
+		}
+		withTatweels := make([]GlyphInfo, 0, len(input)+n)
+		for _, info := range input {
+			for ; counts[info.Cluster] > 0; counts[info.Cluster]-- {
+				withTatweels = append(withTatweels, GlyphInfo{codepoint: tatweel, Cluster: info.Cluster})
+			}
+			withTatweels = append(withTatweels, info)
+		}
+		return b.shapeInput(font, features, withTatweels)
+	}
+
+	// tatweels may interact with the other glyphs (kerning, ligatures),
+	// so the estimated count is adjusted one step at a time;
+	// the longest result not exceeding the range is kept
+	n := int((opts.MinAdvance - advance + width - 1) / width)
+	best, bestAdvance, last := 0, advance, 0
+	for iter := 0; n > 0 && iter < justifyMaxIterations; iter++ {
+		withAdvance := shapeWith(n)
+		last = n
+		if withAdvance <= opts.MaxAdvance {
+			if withAdvance > bestAdvance {
+				best, bestAdvance = n, withAdvance
+			}
+			if withAdvance >= opts.MinAdvance {
+				break
+			}
+			n++
+		} else {
+			if best != 0 {
+				break
+			}
+			n--
+		}
+	}
+
+	if best != last {
+		if best == 0 { // restore the result without tatweels
+			return 0, b.shapeInput(font, features, input)
+		}
+		bestAdvance = shapeWith(best)
+	}
+	return best, bestAdvance
+}
+
+func abs32(v float32) float32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,174 @@
+package harfbuzz
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/benoitkugler/textlayout/fonts/truetype"
+	"github.com/benoitkugler/textlayout/language"
+)
+
+func TestShapeJustifyAxis(t *testing.T) {
+	face := openFontFile("testdata/fonts/SourceSansVariable-Roman.anchor.ttf")
+	font := NewFont(face)
+	wght := truetype.MustNewTag("wght")
+	text := []rune("Justified text")
+
+	newBuffer := func() *Buffer {
+		buf := NewBuffer()
+		buf.AddRunes(text, 0, -1)
+		buf.GuessSegmentProperties()
+		return buf
+	}
+
+	buf := newBuffer()
+	buf.Shape(font, nil)
+	natural := totalAdvance(buf)
+
+	// already justified
+	buf = newBuffer()
+	res := buf.ShapeJustify(font, nil, JustifyOptions{MinAdvance: natural - 10, MaxAdvance: natural + 10, Axis: wght})
+	assert(t, res.Justified && res.Axis == 0)
+	assertEqualInt(t, int(res.Advance), int(natural))
+
+	for _, target := range []Position{natural + 200, natural + 600} {
+		buf = newBuffer()
+		res = buf.ShapeJustify(font, nil, JustifyOptions{MinAdvance: target - 5, MaxAdvance: target + 5, Axis: wght})
+		assert(t, res.Justified)
+		assert(t, res.Axis == wght)
+		assert(t, 200 <= res.AxisValue && res.AxisValue <= 900)
+		assertEqualInt(t, int(res.Advance), int(totalAdvance(buf)))
+		assertEqualInt(t, len(buf.Info), len(text))
+
+		// the face is left unchanged
+		assert(t, len(face.VarCoordinates()) == 0)
+
+		// applying the axis value yields the same result
+		fvar := face.Variations()
+		design := fvar.GetDesignCoordsDefault(nil)
+		for i, axis := range fvar.Axis {
+			if axis.Tag == wght {
+				design[i] = res.AxisValue
+			}
+		}
+		font.SetVarCoordsDesign(design)
+		ref := newBuffer()
+		ref.Shape(font, nil)
+		assertEqualInt(t, int(res.Advance), int(totalAdvance(ref)))
+		face.SetVarCoordinates(nil)
+	}
+
+	// out of reach : the closest end of the axis is used
+	buf = newBuffer()
+	res = buf.ShapeJustify(font, nil, JustifyOptions{MinAdvance: 10 * natural, MaxAdvance: 11 * natural, Axis: wght})
+	assert(t, !res.Justified)
+	assert(t, res.AxisValue == 900)
+	assertEqualInt(t, int(res.Advance), int(totalAdvance(buf)))
+
+	// no such axis
+	buf = newBuffer()
+	res = buf.ShapeJustify(font, nil, JustifyOptions{MinAdvance: natural + 200, MaxAdvance: natural + 300})
+	assert(t, !res.Justified && res.Axis == 0)
+}
+
+func TestShapeJustifyKashida(t *testing.T) {
+	font := NewFont(openFontFile("testdata/perf_reference/fonts/Amiri-Regular.ttf"))
+	text := []rune("بسم الله الرحمن")
+
+	newBuffer := func() *Buffer {
+		buf := NewBuffer()
+		buf.AddRunes(text, 0, -1)
+		buf.GuessSegmentProperties()
+		return buf
+	}
+
+	buf := newBuffer()
+	buf.Shape(font, nil)
+	natural := totalAdvance(buf)
+	assert(t, buf.Flags&ProduceSafeToInsertTatweel == 0)
+
+	target := natural + 1500
+	buf = newBuffer()
+	res := buf.ShapeJustify(font, nil, JustifyOptions{MinAdvance: target - 300, MaxAdvance: target + 300, Kashida: true})
+	assert(t, res.Justified)
+	assert(t, res.Kashidas > 0)
+	assertEqualInt(t, int(res.Advance), int(totalAdvance(buf)))
+	assert(t, len(buf.Info) > 0)
+	assert(t, buf.Flags&ProduceSafeToInsertTatweel == 0)
+
+	// clusters still refer to the input text
+	for _, info := range buf.Info {
+		assert(t, 0 <= info.Cluster && info.Cluster < len(text))
+	}
+
+	// without kashidas, the text is not modified
+	buf = newBuffer()
+	res = buf.ShapeJustify(font, nil, JustifyOptions{MinAdvance: target - 300, MaxAdvance: target + 300})
+	assert(t, !res.Justified && res.Kashidas == 0)
+	assertEqualInt(t, int(res.Advance), int(natural))
+}
+
+func totalAdvance(buf *Buffer) Position {
+	var advance Position
+	for _, pos := range buf.Pos {
+		advance += pos.XAdvance
+	}
+	return advance
+}
+
+func TestShapeJustifyJSTF(t *testing.T) {
+	font := NewFont(openFontFile("../fonts/truetype/testdata/Roboto-JSTF.ttf"))
+	text := []rune("HAVANA AVATAR")
+
+	shape := func(features []Feature, opts *JustifyOptions) (*Buffer, JustifyResult) {
+		buf := NewBuffer()
+		buf.AddRunes(text, 0, -1)
+		buf.GuessSegmentProperties()
+		if opts == nil {
+			buf.Shape(font, features)
+			return buf, JustifyResult{Advance: totalAdvance(buf)}
+		}
+		res := buf.ShapeJustify(font, features, *opts)
+		return buf, res
+	}
+
+	_, natural := shape(nil, nil)
+	// priority 0 enables the 'cpsp' lookup, priority 1 disables 'kern'
+	_, spaced := shape([]Feature{{Tag: truetype.MustNewTag("cpsp"), Value: 1, Start: FeatureGlobalStart, End: FeatureGlobalEnd}}, nil)
+	_, unkerned := shape([]Feature{
+		{Tag: truetype.MustNewTag("cpsp"), Value: 1, Start: FeatureGlobalStart, End: FeatureGlobalEnd},
+		{Tag: truetype.MustNewTag("kern"), Value: 0, Start: FeatureGlobalStart, End: FeatureGlobalEnd},
+	}, nil)
+	assert(t, natural.Advance < spaced.Advance && spaced.Advance < unkerned.Advance)
+
+	for i, expected := range []JustifyResult{spaced, unkerned} {
+		buf, res := shape(nil, &JustifyOptions{MinAdvance: expected.Advance, MaxAdvance: expected.Advance, JSTF: true})
+		assert(t, res.Justified)
+		assertEqualInt(t, res.Priorities, i+1)
+		assertEqualInt(t, int(res.Advance), int(expected.Advance))
+		assertEqualInt(t, int(totalAdvance(buf)), int(expected.Advance))
+		assert(t, buf.jstf == nil)
+	}
+
+	// JSTF is opt-in
+	_, res := shape(nil, &JustifyOptions{MinAdvance: spaced.Advance, MaxAdvance: spaced.Advance})
+	assert(t, !res.Justified && res.Priorities == 0)
+
+	// not enough
+	_, res = shape(nil, &JustifyOptions{MinAdvance: 2 * unkerned.Advance, MaxAdvance: 3 * unkerned.Advance, JSTF: true})
+	assert(t, !res.Justified && res.Priorities == 2)
+	assertEqualInt(t, int(res.Advance), int(unkerned.Advance))
+}
+
+func TestLayoutJustificationPriorities(t *testing.T) {
+	font := NewFont(openFontFile("../fonts/truetype/testdata/Roboto-JSTF.ttf"))
+	assertEqualInt(t, len(font.LayoutJustificationPriorities(language.Latin, "")), 2)
+	assertEqualInt(t, len(font.LayoutJustificationPriorities(language.Latin, language.NewLanguage("fr"))), 2)
+	priorities := font.LayoutJustificationPriorities(language.Latin, language.NewLanguage("tr"))
+	assertEqualInt(t, len(priorities), 1)
+	assert(t, reflect.DeepEqual(priorities[0].Extension.DisableGSUB, []uint16{16, 17}))
+	assert(t, font.LayoutJustificationPriorities(language.Arabic, "") == nil)
+
+	font = NewFont(openFontFile("testdata/perf_reference/fonts/Roboto-Regular.ttf"))
+	assert(t, font.LayoutJustificationPriorities(language.Latin, "") == nil)
+}
@@ -0,0 +1,166 @@
+package harfbuzz
+
+import (
+	tt "github.com/benoitkugler/textlayout/fonts/truetype"
+)
+
+// ShaperKind identifies the shaping engine used for a font.
+type ShaperKind uint8
+
+const (
+	// ShaperFallback is used for fonts without advanced layout tables.
+	ShaperFallback ShaperKind = ShaperKind(skFallback)
+	// ShaperOpentype is used for OpenType (and AAT) fonts.
+	ShaperOpentype ShaperKind = ShaperKind(skOpentype)
+	// ShaperGraphite is used for valid Graphite fonts.
+	ShaperGraphite ShaperKind = ShaperKind(skGraphite)
+)
+
+func (sk ShaperKind) String() string {
+	switch sk {
+	case ShaperFallback:
+		return "fallback"
+	case ShaperOpentype:
+		return "ot"
+	case ShaperGraphite:
+		return "graphite"
+	default:
+		return "<invalid shaper>"
+	}
+}
+
+// ShapePlan exposes the decisions taken by `Buffer.Shape` for a given
+// font, segment properties and features, without shaping any text.
+// It is meant for debugging and inspection purposes.
+type ShapePlan struct {
+	plan *shapePlan
+}
+
+// NewShapePlan returns the plan used by `Buffer.Shape` when
+// called with `font`, `features`, and a buffer whose properties are `props`.
+// The direction of `props` must be set (see `Buffer.GuessSegmentProperties`).
+func NewShapePlan(font *Font, props SegmentProperties, features []Feature) ShapePlan {
+	return ShapePlan{plan: font.shapePlan(props, features)}
+}
+
+// Shaper returns the shaping engine used.
+func (sp ShapePlan) Shaper() ShaperKind { return ShaperKind(sp.plan.shaper.kind()) }
+
+// returns nil for non Opentype shapers
+func (sp ShapePlan) otPlan() *otShapePlan {
+	if ot, ok := sp.plan.shaper.(*shaperOpentype); ok {
+		return &ot.plan
+	}
+	return nil
+}
+
+// ComplexShaper returns the name of the script specific shaper
+// selected by the Opentype engine, which is one of
+// "arabic", "hangul", "hebrew", "indic", "khmer", "myanmar",
+// "thai", "use", "default" and "dumb" (for AAT fonts).
+// An empty string is returned for the other engines.
+func (sp ShapePlan) ComplexShaper() string {
+	plan := sp.otPlan()
+	if plan == nil {
+		return ""
+	}
+	switch cs := plan.shaper.(type) {
+	case *complexShaperArabic:
+		return "arabic"
+	case *complexShaperHangul:
+		return "hangul"
+	case complexShaperHebrew:
+		return "hebrew"
+	case *complexShaperIndic:
+		return "indic"
+	case *complexShaperKhmer:
+		return "khmer"
+	case complexShaperMyanmar:
+		return "myanmar"
+	case complexShaperThai:
+		return "thai"
+	case *complexShaperUSE:
+		return "use"
+	case complexShaperDefault:
+		if cs.dumb {
+			return "dumb"
+		}
+		return "default"
+	default:
+		return "unknown"
+	}
+}
+
+// ChosenScript returns the script tag selected in the given layout table,
+// and false if it is a fallback script (or if the table has no scripts).
+func (sp ShapePlan) ChosenScript(table LayoutTable) (tt.Tag, bool) {
+	plan := sp.otPlan()
+	if plan == nil {
+		return 0, false
+	}
+	return plan.map_.chosenScript[table], plan.map_.foundScript[table]
+}
+
+// Lookups returns the indexes of the lookups that will be applied
+// from the given layout table, grouped by stages, in application order.
+// Stages are separated by pauses, where the complex shapers perform
+// additionnal processing.
+// It returns nil when the Opentype engine is not used, or when
+// the GSUB/GPOS tables are not applied (see `UsesAAT`).
+func (sp ShapePlan) Lookups(table LayoutTable) [][]uint16 {
+	plan := sp.otPlan()
+	if plan == nil || (table == LayoutGSUB && plan.applyMorx) || (table == LayoutGPOS && !plan.applyGpos) {
+		return nil
+	}
+	lookups := plan.map_.lookups[table]
+	out := make([][]uint16, len(plan.map_.stages[table]))
+	start := 0
+	for i, stage := range plan.map_.stages[table] {
+		for _, l := range lookups[start:stage.lastLookup] {
+			out[i] = append(out[i], l.index)
+		}
+		start = stage.lastLookup
+	}
+	return out
+}
+
+// UsesAAT returns true if the AAT 'morx' table is used
+// instead of the GSUB table.
+func (sp ShapePlan) UsesAAT() bool {
+	plan := sp.otPlan()
+	return plan != nil && plan.applyMorx
+}
+
+// PositioningKind describes the source of the glyph positions
+// used by the Opentype engine.
+type PositioningKind uint8
+
+const (
+	// PositioningNone is used for non Opentype shapers
+	PositioningNone PositioningKind = iota
+	// PositioningGPOS uses the GPOS table
+	PositioningGPOS
+	// PositioningKerx uses the AAT 'kerx' table
+	PositioningKerx
+	// PositioningKern uses the 'kern' table
+	PositioningKern
+	// PositioningFallback uses heuristics for marks positionning and kerning
+	PositioningFallback
+)
+
+// Positioning returns the source used to adjust the glyph positions.
+func (sp ShapePlan) Positioning() PositioningKind {
+	plan := sp.otPlan()
+	switch {
+	case plan == nil:
+		return PositioningNone
+	case plan.applyGpos:
+		return PositioningGPOS
+	case plan.applyKerx:
+		return PositioningKerx
+	case plan.applyKern:
+		return PositioningKern
+	default:
+		return PositioningFallback
+	}
+}
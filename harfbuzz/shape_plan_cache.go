@@ -0,0 +1,188 @@
+package harfbuzz
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultShapePlanCacheSize is the capacity of the
+// cache created by `NewFont`.
+const DefaultShapePlanCacheSize = 64
+
+// ShapePlanCache stores the shaping plans built by `Buffer.Shape`,
+// so that they are only compiled once for a given face, segment properties,
+// features and variation coordinates.
+//
+// It is bounded: when full, the least recently used plan is evicted.
+// Looking up a plan does not require any locking, so that a cache
+// may be safely shared by several fonts and goroutines.
+type ShapePlanCache struct {
+	clock uint64 // incremented on each lookup, accessed atomically
+
+	plans atomic.Value // planCacheMap, never mutated once stored
+
+	mu       sync.Mutex // serializes the updates of `plans`
+	capacity int
+}
+
+// key used to select plans : the features are hashed, and the
+// candidate plans are then compared with `userFeaturesMatch`
+type planCacheKey struct {
+	face       Face
+	props      SegmentProperties
+	kind       shaperKind
+	variations otShapePlanKey
+	features   uint64
+}
+
+type planCacheEntry struct {
+	lastUsed uint64 // accessed atomically
+	plan     *shapePlan
+}
+
+type planCacheMap map[planCacheKey][]*planCacheEntry
+
+// NewShapePlanCache returns an empty cache, storing at most
+// `capacity` plans. If `capacity` is not positive,
+// `DefaultShapePlanCacheSize` is used.
+func NewShapePlanCache(capacity int) *ShapePlanCache {
+	if capacity <= 0 {
+		capacity = DefaultShapePlanCacheSize
+	}
+	c := &ShapePlanCache{capacity: capacity}
+	c.plans.Store(planCacheMap{})
+	return c
+}
+
+// Len returns the number of plans in the cache.
+func (c *ShapePlanCache) Len() int {
+	n := 0
+	for _, entries := range c.load() {
+		n += len(entries)
+	}
+	return n
+}
+
+// Invalidate removes the plans built for `face`.
+// It should be called when a face is dropped, or modified.
+func (c *ShapePlanCache) Invalidate(face Face) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	old := c.load()
+	plans := make(planCacheMap, len(old))
+	for key, entries := range old {
+		if key.face != face {
+			plans[key] = entries
+		}
+	}
+	c.plans.Store(plans)
+}
+
+// Clear removes all the plans.
+func (c *ShapePlanCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.plans.Store(planCacheMap{})
+}
+
+func (c *ShapePlanCache) load() planCacheMap { return c.plans.Load().(planCacheMap) }
+
+func (plan *shapePlan) cacheKey(face Face) planCacheKey {
+	key := planCacheKey{face: face, props: plan.props, kind: plan.shaper.kind()}
+	if ot, ok := plan.shaper.(*shaperOpentype); ok {
+		key.variations = ot.key
+	}
+
+	// FNV-1a hash of the relevant parts of the features (see userFeaturesMatch)
+	const prime = 1099511628211
+	h := uint64(14695981039346656037)
+	for _, feat := range plan.userFeatures {
+		global := uint32(0)
+		if feat.Start == FeatureGlobalStart && feat.End == FeatureGlobalEnd {
+			global = 1
+		}
+		for _, v := range [3]uint32{uint32(feat.Tag), feat.Value, global} {
+			h = (h ^ uint64(v)) * prime
+		}
+	}
+	key.features = h
+	return key
+}
+
+func (c *ShapePlanCache) lookup(key planCacheKey, plan shapePlan) *shapePlan {
+	for _, entry := range c.load()[key] {
+		if entry.plan.userFeaturesMatch(plan) {
+			atomic.StoreUint64(&entry.lastUsed, atomic.AddUint64(&c.clock, 1))
+			return entry.plan
+		}
+	}
+	return nil
+}
+
+// returns a cached plan, or compiles a new one
+func (c *ShapePlanCache) get(font *Font, props SegmentProperties,
+	userFeatures []Feature, coords []float32) *shapePlan {
+	var plan shapePlan
+	plan.init(false, font, props, userFeatures, coords)
+	key := plan.cacheKey(font.face)
+
+	if cached := c.lookup(key, plan); cached != nil {
+		return cached
+	}
+
+	// compile without holding the lock
+	newPlan := newShapePlan(font, props, userFeatures, coords)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// another goroutine may have added the plan in the meantime
+	if cached := c.lookup(key, plan); cached != nil {
+		return cached
+	}
+
+	old := c.load()
+	plans := make(planCacheMap, len(old)+1)
+	size := 0
+	for k, entries := range old {
+		plans[k] = entries
+		size += len(entries)
+	}
+	if size >= c.capacity {
+		plans.evictOldest()
+	}
+	entry := &planCacheEntry{plan: newPlan, lastUsed: atomic.AddUint64(&c.clock, 1)}
+	// copy the slice, which may be shared with `old`
+	plans[key] = append(append([]*planCacheEntry(nil), plans[key]...), entry)
+	c.plans.Store(plans)
+
+	return newPlan
+}
+
+// evictOldest removes the least recently used entry
+func (plans planCacheMap) evictOldest() {
+	var (
+		oldestKey   planCacheKey
+		oldestIndex = -1
+		oldest      uint64
+	)
+	for key, entries := range plans {
+		for i, entry := range entries {
+			if used := atomic.LoadUint64(&entry.lastUsed); oldestIndex == -1 || used < oldest {
+				oldestKey, oldestIndex, oldest = key, i, used
+			}
+		}
+	}
+	if oldestIndex == -1 {
+		return
+	}
+	entries := plans[oldestKey]
+	if len(entries) == 1 {
+		delete(plans, oldestKey)
+		return
+	}
+	kept := make([]*planCacheEntry, 0, len(entries)-1)
+	kept = append(kept, entries[:oldestIndex]...)
+	plans[oldestKey] = append(kept, entries[oldestIndex+1:]...)
+}
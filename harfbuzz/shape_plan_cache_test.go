@@ -0,0 +1,71 @@
+package harfbuzz
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/benoitkugler/textlayout/language"
+)
+
+func TestShapePlanCache(t *testing.T) {
+	font := NewFont(openFontFile("testdata/perf_reference/fonts/Roboto-Regular.ttf"))
+	cache := NewShapePlanCache(2)
+	font.PlanCache = cache
+
+	latin := SegmentProperties{Direction: LeftToRight, Script: language.Latin}
+	greek := SegmentProperties{Direction: LeftToRight, Script: language.Greek}
+	cyrillic := SegmentProperties{Direction: LeftToRight, Script: language.Cyrillic}
+	liga := []Feature{{Tag: newTag('l', 'i', 'g', 'a'), Value: 0, Start: FeatureGlobalStart, End: FeatureGlobalEnd}}
+
+	p1 := font.shapePlan(latin, nil)
+	assert(t, font.shapePlan(latin, nil) == p1)
+	assertEqualInt(t, 1, cache.Len())
+
+	// features are part of the key
+	p2 := font.shapePlan(latin, liga)
+	assert(t, p2 != p1)
+	assert(t, font.shapePlan(latin, []Feature{{Tag: newTag('l', 'i', 'g', 'a'), Value: 0, Start: FeatureGlobalStart, End: FeatureGlobalEnd}}) == p2)
+	assertEqualInt(t, 2, cache.Len())
+
+	// the least recently used plan (p1) is evicted
+	font.shapePlan(latin, liga)
+	font.shapePlan(greek, nil)
+	assertEqualInt(t, 2, cache.Len())
+	assert(t, font.shapePlan(latin, liga) == p2)
+	assert(t, font.shapePlan(latin, nil) != p1)
+
+	// invalidation
+	other := NewFont(openFontFile("testdata/perf_reference/fonts/Amiri-Regular.ttf"))
+	other.PlanCache = cache
+	other.shapePlan(cyrillic, nil)
+	cache.Invalidate(font.face)
+	assertEqualInt(t, 1, cache.Len())
+	cache.Clear()
+	assertEqualInt(t, 0, cache.Len())
+
+	// no cache
+	font.PlanCache = nil
+	assert(t, font.shapePlan(latin, nil) != font.shapePlan(latin, nil))
+}
+
+func TestShapePlanCacheConcurrent(t *testing.T) {
+	font := NewFont(openFontFile("testdata/perf_reference/fonts/Roboto-Regular.ttf"))
+	font.PlanCache = NewShapePlanCache(3)
+
+	scripts := []language.Script{language.Latin, language.Greek, language.Cyrillic, language.Arabic}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				props := SegmentProperties{Direction: LeftToRight, Script: scripts[(i+j)%len(scripts)]}
+				if font.shapePlan(props, nil).props != props {
+					t.Error("invalid plan")
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert(t, font.PlanCache.Len() <= 3)
+}
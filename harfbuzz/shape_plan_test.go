@@ -0,0 +1,69 @@
+package harfbuzz
+
+import (
+	"testing"
+
+	tt "github.com/benoitkugler/textlayout/fonts/truetype"
+	"github.com/benoitkugler/textlayout/language"
+)
+
+func planFor(filename string, script language.Script, dir Direction) ShapePlan {
+	font := NewFont(openFontFile(filename))
+	props := SegmentProperties{Direction: dir, Script: script, Language: language.NewLanguage("en")}
+	return NewShapePlan(font, props, nil)
+}
+
+func TestShapePlanIndic(t *testing.T) {
+	plan := planFor("testdata/perf_reference/fonts/NotoSansDevanagari-Regular.ttf", language.Devanagari, LeftToRight)
+	if plan.Shaper() != ShaperOpentype || plan.ComplexShaper() != "indic" || plan.UsesAAT() {
+		t.Fatalf("unexpected shaper %s %s", plan.Shaper(), plan.ComplexShaper())
+	}
+	if tag, found := plan.ChosenScript(LayoutGSUB); tag != tt.MustNewTag("dev2") || !found {
+		t.Fatalf("unexpected script %s", tag)
+	}
+	if plan.Positioning() != PositioningGPOS {
+		t.Fatalf("unexpected positioning %d", plan.Positioning())
+	}
+
+	gsub := plan.Lookups(LayoutGSUB)
+	if len(gsub) < 2 {
+		t.Fatalf("expected several GSUB stages, got %d", len(gsub))
+	}
+	total := 0
+	for _, stage := range gsub {
+		for _, l := range stage {
+			if int(l) >= len(plan.plan.shaper.(*shaperOpentype).tables.GSUB.Lookups) {
+				t.Fatalf("invalid lookup index %d", l)
+			}
+		}
+		total += len(stage)
+	}
+	if total == 0 {
+		t.Fatal("expected GSUB lookups")
+	}
+	if gpos := plan.Lookups(LayoutGPOS); len(gpos) == 0 {
+		t.Fatal("expected GPOS lookups")
+	}
+}
+
+func TestShapePlanKinds(t *testing.T) {
+	plan := planFor("testdata/perf_reference/fonts/Amiri-Regular.ttf", language.Arabic, RightToLeft)
+	if plan.ComplexShaper() != "arabic" {
+		t.Fatalf("unexpected complex shaper %s", plan.ComplexShaper())
+	}
+
+	plan = planFor("testdata/perf_reference/fonts/Roboto-Regular.ttf", language.Latin, LeftToRight)
+	if plan.ComplexShaper() != "default" {
+		t.Fatalf("unexpected complex shaper %s", plan.ComplexShaper())
+	}
+
+	plan = planFor("testdata/fonts/aat-morx.ttf", language.Latin, LeftToRight)
+	if !plan.UsesAAT() || plan.Lookups(LayoutGSUB) != nil {
+		t.Fatal("expected AAT shaping")
+	}
+
+	plan = planFor("testdata/fonts/Simple-Graphite-Font.ttf", language.Latin, LeftToRight)
+	if plan.Shaper() != ShaperGraphite || plan.ComplexShaper() != "" || plan.Positioning() != PositioningNone {
+		t.Fatalf("unexpected shaper %s", plan.Shaper())
+	}
+}
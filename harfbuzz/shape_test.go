@@ -24,65 +24,6 @@ import (
 
 // parse and run the test cases directly copied from harfbuzz/test/shaping
 
-type formatOptions struct {
-	hideGlyphNames bool
-	hidePositions  bool
-	hideAdvances   bool
-	hideClusters   bool
-	showExtents    bool
-	showFlags      bool
-}
-
-// return a compact representation of the buffer contents
-func (b *Buffer) serialize(font *Font, opt formatOptions) string {
-	if len(b.Info) == 0 {
-		return "" //  the reference does not return []
-	}
-	gs := new(strings.Builder)
-	gs.WriteByte('[')
-	var x, y Position
-	for i, glyph := range b.Info {
-		if opt.hideGlyphNames {
-			fmt.Fprintf(gs, "%d", glyph.Glyph)
-		} else {
-			gs.WriteString(font.glyphToString(glyph.Glyph))
-		}
-
-		if !opt.hideClusters {
-			fmt.Fprintf(gs, "=%d", glyph.Cluster)
-		}
-		pos := b.Pos[i]
-
-		if !opt.hidePositions {
-			if x+pos.XOffset != 0 || y+pos.YOffset != 0 {
-				fmt.Fprintf(gs, "@%d,%d", x+pos.XOffset, y+pos.YOffset)
-			}
-			if !opt.hideAdvances {
-				fmt.Fprintf(gs, "+%d", pos.XAdvance)
-				if pos.YAdvance != 0 {
-					fmt.Fprintf(gs, ",%d", pos.YAdvance)
-				}
-			}
-		}
-
-		if opt.showExtents {
-			extents, _ := font.GlyphExtents(glyph.Glyph)
-			fmt.Fprintf(gs, "<%d,%d,%d,%d>", extents.XBearing, extents.YBearing, extents.Width, extents.Height)
-		}
-
-		if i != len(b.Info)-1 {
-			gs.WriteByte('|')
-		}
-
-		if opt.hideAdvances {
-			x += pos.XAdvance
-			y += pos.YAdvance
-		}
-	}
-	gs.WriteByte(']')
-	return gs.String()
-}
-
 type fontOptions struct {
 	font *Font // cached value of getFont()
 
@@ -207,7 +148,7 @@ type testOptions struct {
 	input    textInput
 	shaper   shapeOptions
 	fontOpts fontOptions
-	format   formatOptions
+	format   SerializeFlags
 }
 
 type shapeOptions struct {
@@ -240,7 +181,7 @@ func (so *shapeOptions) setupBuffer(buffer *Buffer) {
 	buffer.Flags = flags
 	buffer.Invisible = so.invisibleGlyph
 	buffer.ClusterLevel = so.clusterLevel
-	buffer.guessSegmentProperties()
+	buffer.GuessSegmentProperties()
 }
 
 func copyBufferProperties(dst, src *Buffer) {
@@ -251,6 +192,9 @@ func copyBufferProperties(dst, src *Buffer) {
 
 func appendBuffer(dst, src *Buffer, start, end int) {
 	origLen := len(dst.Info)
+	if origLen == 0 {
+		dst.shaped = src.shaped
+	}
 
 	dst.Info = append(dst.Info, src.Info[start:end]...)
 	dst.Pos = append(dst.Pos, src.Pos[start:end]...)
@@ -439,8 +383,8 @@ func (so *shapeOptions) verifyBufferSafeToBreak(buffer, textBuffer *Buffer, font
 		}
 	}
 
-	diff := bufferDiff(reconstruction, buffer, ^fonts.GID(0), 0)
-	if diff != bufferDiffFlagEqual {
+	diff := reconstruction.Diff(buffer, ^fonts.GID(0), 0)
+	if diff != DiffEqual {
 		/* Return the reconstructed result instead so it can be inspected. */
 		buffer.Info = nil
 		buffer.Pos = nil
@@ -478,7 +422,7 @@ func (mft testOptions) shape(verify bool) (string, error) {
 		return "", err
 	}
 
-	return buffer.serialize(font, mft.format), nil
+	return buffer.Serialize(font, SerializeText, mft.format), nil
 }
 
 const featuresUsage = `Comma-separated list of font features
@@ -583,13 +527,23 @@ const variationsUsage = `Comma-separated list of font variations
 func parseOptions(options string) (testOptions, error) {
 	flags := flag.NewFlagSet("options", flag.ContinueOnError)
 
-	var fmtOpts formatOptions
-	flags.BoolVar(&fmtOpts.hideClusters, "no-clusters", false, "Do not output cluster indices")
-	flags.BoolVar(&fmtOpts.hideGlyphNames, "no-glyph-names", false, "Output glyph indices instead of names")
-	flags.BoolVar(&fmtOpts.hidePositions, "no-positions", false, "Do not output glyph positions")
-	flags.BoolVar(&fmtOpts.hideAdvances, "no-advances", false, "Do not output glyph advances")
-	flags.BoolVar(&fmtOpts.showExtents, "show-extents", false, "Output glyph extents")
-	flags.BoolVar(&fmtOpts.showFlags, "show-flags", false, "Output glyph flags")
+	var fmtOpts SerializeFlags
+	formatFlags := [...]struct {
+		name  string
+		flag  SerializeFlags
+		usage string
+		value *bool
+	}{
+		{"no-clusters", SerializeNoClusters, "Do not output cluster indices", nil},
+		{"no-glyph-names", SerializeNoGlyphNames, "Output glyph indices instead of names", nil},
+		{"no-positions", SerializeNoPositions, "Do not output glyph positions", nil},
+		{"no-advances", SerializeNoAdvances, "Do not output glyph advances", nil},
+		{"show-extents", SerializeGlyphExtents, "Output glyph extents", nil},
+		{"show-flags", SerializeGlyphFlags, "Output glyph flags", nil},
+	}
+	for i, f := range formatFlags {
+		formatFlags[i].value = flags.Bool(f.name, false, f.usage)
+	}
 
 	ned := flags.Bool("ned", false, "No Extra Data; Do not output clusters or advances")
 
@@ -644,9 +598,13 @@ func parseOptions(options string) (testOptions, error) {
 		return testOptions{}, err
 	}
 
+	for _, f := range formatFlags {
+		if *f.value {
+			fmtOpts |= f.flag
+		}
+	}
 	if *ned {
-		fmtOpts.hideClusters = true
-		fmtOpts.hideAdvances = true
+		fmtOpts |= SerializeNoClusters | SerializeNoAdvances
 	}
 	fontOpts.fontRef.Index = uint16(*fontRefIndex)
 	out := testOptions{
@@ -0,0 +1,295 @@
+package harfbuzz
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"unicode/utf16"
+
+	"github.com/benoitkugler/textlayout/fonts"
+)
+
+// ToUnicodeBuilder accumulates, for one font, the text
+// represented by the glyphs of shaped buffers, and outputs
+// it as a PostScript ToUnicode CMap, as used in PDF files.
+//
+// Since a CMap may only map a glyph to one string, conflicting
+// mappings (for instance a glyph shared by several code points)
+// are resolved by keeping the most frequent one (or the first
+// seen for equal frequencies).
+type ToUnicodeBuilder struct {
+	font *Font
+
+	// glyph -> candidates, in insertion order
+	candidates map[fonts.GID][]toUnicodeCandidate
+}
+
+type toUnicodeCandidate struct {
+	text  []rune
+	count int
+}
+
+// NewToUnicodeBuilder returns an empty builder, used to
+// collect the glyphs of `font`.
+func NewToUnicodeBuilder(font *Font) *ToUnicodeBuilder {
+	return &ToUnicodeBuilder{font: font, candidates: make(map[fonts.GID][]toUnicodeCandidate)}
+}
+
+// AddBuffer records the mappings found in `buffer`, which must have been
+// shaped with the font of the builder.
+// `text`, `itemOffset` and `itemLength` are the arguments passed to `buffer.AddRunes`,
+// so that the clusters of `buffer` are indexes into `text`.
+//
+// The text of a cluster spans from its value to the next (greater) cluster value.
+// A cluster made of one glyph (like a ligature) is mapped to the whole text;
+// otherwise, the glyphs matching the nominal glyph of a rune are mapped to this rune,
+// and the remaining runes are mapped to the first glyph not already mapped.
+func (b *ToUnicodeBuilder) AddBuffer(buffer *Buffer, text []rune, itemOffset, itemLength int) {
+	if itemLength == -1 {
+		itemLength = len(text) - itemOffset
+	}
+	itemEnd := itemOffset + itemLength
+
+	// sorted clusters values, used to compute the text boundaries
+	var clusters []int
+	seen := make(map[int]bool)
+	for _, info := range buffer.Info {
+		if !seen[info.Cluster] {
+			seen[info.Cluster] = true
+			clusters = append(clusters, info.Cluster)
+		}
+	}
+	sort.Ints(clusters)
+
+	clusterText := func(cluster int) []rune {
+		end := itemEnd
+		if i := sort.SearchInts(clusters, cluster); i+1 < len(clusters) {
+			end = clusters[i+1]
+		}
+		if cluster < 0 || end > len(text) || cluster >= end {
+			return nil
+		}
+		return text[cluster:end]
+	}
+
+	// glyphs in logical order
+	glyphs := make([]GlyphInfo, len(buffer.Info))
+	copy(glyphs, buffer.Info)
+	if buffer.Props.Direction.isBackward() {
+		for i, j := 0, len(glyphs)-1; i < j; i, j = i+1, j-1 {
+			glyphs[i], glyphs[j] = glyphs[j], glyphs[i]
+		}
+	}
+
+	// glyphs of a cluster are not always contiguous (see ClusterLevel Characters)
+	groups := make(map[int][]fonts.GID)
+	var order []int
+	for _, info := range glyphs {
+		if _, has := groups[info.Cluster]; !has {
+			order = append(order, info.Cluster)
+		}
+		groups[info.Cluster] = append(groups[info.Cluster], info.Glyph)
+	}
+
+	for _, cluster := range order {
+		b.addCluster(groups[cluster], clusterText(cluster))
+	}
+}
+
+func (b *ToUnicodeBuilder) addCluster(glyphs []fonts.GID, text []rune) {
+	if len(text) == 0 {
+		return
+	}
+	if len(glyphs) == 1 {
+		b.add(glyphs[0], text)
+		return
+	}
+
+	mapped := make([][]rune, len(glyphs))
+	used := make([]bool, len(text))
+	for i, glyph := range glyphs {
+		for j, r := range text {
+			if used[j] {
+				continue
+			}
+			if g, ok := b.font.face.NominalGlyph(r); ok && g == glyph {
+				mapped[i] = text[j : j+1]
+				used[j] = true
+				break
+			}
+		}
+	}
+
+	var remaining []rune
+	for j, r := range text {
+		if !used[j] {
+			remaining = append(remaining, r)
+		}
+	}
+	if len(remaining) != 0 {
+		target := 0
+		for i := range glyphs {
+			if mapped[i] == nil {
+				target = i
+				break
+			}
+		}
+		mapped[target] = append(append([]rune(nil), mapped[target]...), remaining...)
+	}
+
+	for i, glyph := range glyphs {
+		if mapped[i] != nil {
+			b.add(glyph, mapped[i])
+		}
+	}
+}
+
+func (b *ToUnicodeBuilder) add(glyph fonts.GID, text []rune) {
+	cands := b.candidates[glyph]
+	for i, cand := range cands {
+		if string(cand.text) == string(text) {
+			cands[i].count++
+			return
+		}
+	}
+	b.candidates[glyph] = append(cands, toUnicodeCandidate{text: append([]rune(nil), text...), count: 1})
+}
+
+// Mapping returns the resolved mapping from glyphs to text.
+func (b *ToUnicodeBuilder) Mapping() map[fonts.GID][]rune {
+	out := make(map[fonts.GID][]rune, len(b.candidates))
+	for glyph, cands := range b.candidates {
+		best := cands[0]
+		for _, cand := range cands[1:] {
+			if cand.count > best.count {
+				best = cand
+			}
+		}
+		out[glyph] = best.text
+	}
+	return out
+}
+
+// Conflicts returns the glyphs for which several texts
+// have been found, with all the candidates, ordered by decreasing frequency.
+// The first one is the text used in the CMap.
+func (b *ToUnicodeBuilder) Conflicts() map[fonts.GID][][]rune {
+	out := make(map[fonts.GID][][]rune)
+	for glyph, cands := range b.candidates {
+		if len(cands) < 2 {
+			continue
+		}
+		sorted := append([]toUnicodeCandidate(nil), cands...)
+		sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].count > sorted[j].count })
+		texts := make([][]rune, len(sorted))
+		for i, cand := range sorted {
+			texts[i] = cand.text
+		}
+		out[glyph] = texts
+	}
+	return out
+}
+
+// maximum number of entries in a bfchar or bfrange block
+const maxCMapBlockSize = 100
+
+type bfRange struct {
+	start, end fonts.GID
+	text       []rune // text of start
+}
+
+// WriteCMap writes the ToUnicode CMap stream content, using
+// 2-bytes glyph indexes as character codes (as for Identity-H CID fonts).
+// The .notdef glyph and glyphs with indexes greater than 0xFFFF are ignored.
+func (b *ToUnicodeBuilder) WriteCMap(w io.Writer) error {
+	mapping := b.Mapping()
+	glyphs := make([]fonts.GID, 0, len(mapping))
+	for glyph := range mapping {
+		if glyph == 0 || glyph > 0xFFFF {
+			continue
+		}
+		glyphs = append(glyphs, glyph)
+	}
+	sort.Slice(glyphs, func(i, j int) bool { return glyphs[i] < glyphs[j] })
+
+	var chars, ranges []bfRange
+	for i := 0; i < len(glyphs); {
+		glyph := glyphs[i]
+		text := mapping[glyph]
+		// extend the range as long as the glyphs and the (single, BMP) runes are consecutive,
+		// without crossing a 256 boundary
+		j := i + 1
+		if len(text) == 1 && text[0] <= 0xFFFF {
+			for ; j < len(glyphs); j++ {
+				next, nextText := glyphs[j], mapping[glyphs[j]]
+				delta := rune(next - glyph)
+				if next != glyphs[j-1]+1 || next>>8 != glyph>>8 ||
+					len(nextText) != 1 || nextText[0] != text[0]+delta || nextText[0]>>8 != text[0]>>8 {
+					break
+				}
+			}
+		}
+		if j-i > 1 {
+			ranges = append(ranges, bfRange{start: glyph, end: glyphs[j-1], text: text})
+		} else {
+			chars = append(chars, bfRange{start: glyph, end: glyph, text: text})
+		}
+		i = j
+	}
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString(`/CIDInit /ProcSet findresource begin
+12 dict begin
+begincmap
+/CIDSystemInfo
+<< /Registry (Adobe)
+/Ordering (UCS)
+/Supplement 0
+>> def
+/CMapName /Adobe-Identity-UCS def
+/CMapType 2 def
+1 begincodespacerange
+<0000> <FFFF>
+endcodespacerange
+`)
+	for len(chars) != 0 {
+		block := chars
+		if len(block) > maxCMapBlockSize {
+			block = block[:maxCMapBlockSize]
+		}
+		chars = chars[len(block):]
+		fmt.Fprintf(bw, "%d beginbfchar\n", len(block))
+		for _, c := range block {
+			fmt.Fprintf(bw, "<%04X> <%s>\n", c.start, utf16Hex(c.text))
+		}
+		bw.WriteString("endbfchar\n")
+	}
+	for len(ranges) != 0 {
+		block := ranges
+		if len(block) > maxCMapBlockSize {
+			block = block[:maxCMapBlockSize]
+		}
+		ranges = ranges[len(block):]
+		fmt.Fprintf(bw, "%d beginbfrange\n", len(block))
+		for _, r := range block {
+			fmt.Fprintf(bw, "<%04X> <%04X> <%s>\n", r.start, r.end, utf16Hex(r.text))
+		}
+		bw.WriteString("endbfrange\n")
+	}
+	bw.WriteString(`endcmap
+CMapName currentdict /CMap defineresource pop
+end
+end
+`)
+	return bw.Flush()
+}
+
+// utf16Hex returns the hexadecimal form of the UTF-16BE encoding of `text`
+func utf16Hex(text []rune) string {
+	out := make([]byte, 0, 4*len(text))
+	for _, u := range utf16.Encode(text) {
+		out = append(out, fmt.Sprintf("%04X", u)...)
+	}
+	return string(out)
+}
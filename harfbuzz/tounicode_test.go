@@ -0,0 +1,103 @@
+package harfbuzz
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/benoitkugler/textlayout/fonts"
+)
+
+func TestToUnicodeLigatures(t *testing.T) {
+	font := NewFont(openFontFile("testdata/perf_reference/fonts/Roboto-Regular.ttf"))
+	b := NewToUnicodeBuilder(font)
+
+	text := []rune("office fl")
+	b.AddBuffer(shapeRunes(font, text, 0, nil), text, 0, -1)
+
+	mapping := b.Mapping()
+	if len(mapping) != 6 {
+		t.Fatalf("unexpected mapping %v", mapping)
+	}
+	for _, exp := range []string{"ffi", "fl", "o", "c", "e", " "} {
+		found := false
+		for _, s := range mapping {
+			found = found || string(s) == exp
+		}
+		if !found {
+			t.Errorf("missing mapping to %q", exp)
+		}
+	}
+}
+
+func TestToUnicodeReordering(t *testing.T) {
+	font := NewFont(openFontFile("testdata/perf_reference/fonts/NotoSansDevanagari-Regular.ttf"))
+	b := NewToUnicodeBuilder(font)
+
+	text := []rune("कि")
+	buf := shapeRunes(font, text, 0, nil)
+	b.AddBuffer(buf, text, 0, -1)
+
+	// the matra is displayed first
+	ka, _ := font.face.NominalGlyph('क')
+	if len(buf.Info) != 2 || buf.Info[1].Glyph != ka {
+		t.Fatalf("unexpected shaping %v", buf.Info)
+	}
+	mapping := b.Mapping()
+	if string(mapping[ka]) != "क" || string(mapping[buf.Info[0].Glyph]) != "ि" {
+		t.Fatalf("unexpected mapping %v", mapping)
+	}
+}
+
+func TestToUnicodeConflicts(t *testing.T) {
+	font := NewFont(openFontFile("testdata/perf_reference/fonts/Roboto-Regular.ttf"))
+	b := NewToUnicodeBuilder(font)
+	b.add(10, []rune("a"))
+	b.add(10, []rune("b"))
+	b.add(10, []rune("b"))
+	b.add(11, []rune("c"))
+
+	if s := b.Mapping()[10]; string(s) != "b" {
+		t.Fatalf("expected most frequent mapping, got %q", string(s))
+	}
+	conflicts := b.Conflicts()
+	if len(conflicts) != 1 || len(conflicts[10]) != 2 || string(conflicts[10][0]) != "b" {
+		t.Fatalf("unexpected conflicts %v", conflicts)
+	}
+}
+
+func TestToUnicodeCMap(t *testing.T) {
+	font := NewFont(openFontFile("testdata/perf_reference/fonts/Roboto-Regular.ttf"))
+	b := NewToUnicodeBuilder(font)
+	for i := fonts.GID(0); i < 5; i++ {
+		b.add(0xFE+i, []rune{'A' + rune(i)})
+	}
+	b.add(0, []rune("x"))       // .notdef is ignored
+	b.add(300, []rune("ffi"))   // ligature
+	b.add(301, []rune{0x1F600}) // surrogate pair
+	for i := fonts.GID(0); i < 150; i++ {
+		b.add(1000+2*i, []rune{0x4E00 + rune(i)}) // not consecutive
+	}
+
+	var out bytes.Buffer
+	if err := b.WriteCMap(&out); err != nil {
+		t.Fatal(err)
+	}
+	cmap := out.String()
+	for _, exp := range []string{
+		"begincmap",
+		"<0000> <FFFF>",
+		"<012C> <006600660069>\n<012D> <D83DDE00>\n",
+		"100 beginbfchar",
+		"52 beginbfchar",
+		"<00FE> <00FF> <0041>",
+		"<0100> <0102> <0043>",
+		"2 beginbfrange",
+		"<0512> <4E95>\nendbfchar",
+		"endcmap",
+	} {
+		if !strings.Contains(cmap, exp) {
+			t.Errorf("missing %q in\n%s", exp, cmap)
+		}
+	}
+}
@@ -1,6 +1,10 @@
 package pango
 
-import "math"
+import (
+	"math"
+
+	"github.com/benoitkugler/textlayout/harfbuzz"
+)
 
 // GlyphItem is a pair of a Item and the glyphs
 // resulting from shaping the text corresponding to an item.
@@ -159,6 +163,32 @@ func (glyphItem *GlyphItem) letterSpace(text []rune, logAttrs []CharAttr, letter
 	}
 }
 
+// opticalBound returns the distance the leftmost (or rightmost) glyph of the run
+// may protrude in the margin, according to the font optical bounds, or 0.
+func (glyphItem *GlyphItem) opticalBound(leftmost bool) GlyphUnit {
+	glyphs := glyphItem.Glyphs.Glyphs
+	font := glyphItem.Item.Analysis.Font
+	if len(glyphs) == 0 || font == nil {
+		return 0
+	}
+	glyph := glyphs[0].Glyph
+	if !leftmost {
+		glyph = glyphs[len(glyphs)-1].Glyph
+	}
+	if glyph == GLYPH_EMPTY || glyph&GLYPH_UNKNOWN_FLAG != 0 {
+		return 0
+	}
+
+	left, right, ok := font.GetHarfbuzzFont().GetOpticalBounds(harfbuzz.LeftToRight, glyph.GID())
+	if !ok {
+		return 0
+	}
+	if leftmost {
+		return GlyphUnit(left)
+	}
+	return GlyphUnit(right)
+}
+
 // GetLogicalWidths determine the screen width corresponding to each character. When
 // multiple characters compose a single cluster, the width of the entire
 // cluster is divided equally among the characters.
@@ -1066,6 +1066,24 @@ func (line *LayoutLine) getLineExtentsLayoutCoords(layout *Layout,
 	}
 }
 
+// GetOpticalMargins returns the distances (in Pango units) by which the first
+// and last glyphs of the line may protrude into the left and right margins,
+// as defined by the optical bounds of their fonts (AAT 'opbd' table).
+// Positive values mean that the glyph may hang outside of the margin, so that
+// hanging punctuation is achieved by shifting the line by `-left` and
+// extending its available width by `left + right`.
+// Fonts without optical bounds yield 0.
+func (line *LayoutLine) GetOpticalMargins() (left, right GlyphUnit) {
+	if line.Runs == nil {
+		return 0, 0
+	}
+	first, last := line.Runs.Data, line.Runs.Data
+	for l := line.Runs.Next; l != nil; l = l.Next {
+		last = l.Data
+	}
+	return first.opticalBound(true), last.opticalBound(false)
+}
+
 func (line *LayoutLine) getCharDirection(index int) Direction {
 	for runList := line.Runs; runList != nil; runList = runList.Next {
 		run := runList.Data
@@ -10,6 +10,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/benoitkugler/textlayout/fonts/truetype"
+	"github.com/benoitkugler/textlayout/harfbuzz"
 	"github.com/benoitkugler/textlayout/pango"
 )
 
@@ -528,3 +530,54 @@ func TestIterExtents(t *testing.T) {
 		}
 	}
 }
+
+// opbdFont only provides a harfbuzz font
+type opbdFont struct {
+	pango.Font
+	hb *harfbuzz.Font
+}
+
+func (f opbdFont) GetHarfbuzzFont() *harfbuzz.Font { return f.hb }
+
+func TestOpticalMargins(t *testing.T) {
+	file, err := os.Open("../fonts/truetype/testdata/ToyLcarOpbd.ttf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	face, err := truetype.Parse(file, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hb := harfbuzz.NewFont(face)
+	hb.XScale, hb.YScale = int32(face.Upem())*2, int32(face.Upem())*2
+	font := opbdFont{hb: hb}
+
+	run := func(glyphs ...pango.Glyph) *pango.GlyphItem {
+		item := &pango.GlyphItem{Item: &pango.Item{}, Glyphs: &pango.GlyphString{}}
+		item.Item.Analysis.Font = font
+		for _, g := range glyphs {
+			item.Glyphs.Glyphs = append(item.Glyphs.Glyphs, pango.GlyphInfo{Glyph: g})
+		}
+		return item
+	}
+
+	for _, test := range []struct {
+		runs        []*pango.GlyphItem
+		left, right pango.GlyphUnit
+	}{
+		{nil, 0, 0},
+		{[]*pango.GlyphItem{run(2, 1, 3)}, 200, 300},
+		{[]*pango.GlyphItem{run(3, 1), run(1, 2)}, 0, 0},
+		{[]*pango.GlyphItem{run(2), run(), run(1, 3)}, 200, 300},
+		{[]*pango.GlyphItem{run(pango.GLYPH_EMPTY, 1), run(2, 3|pango.GLYPH_UNKNOWN_FLAG)}, 0, 0},
+	} {
+		var line pango.LayoutLine
+		for i := len(test.runs) - 1; i >= 0; i-- {
+			line.Runs = &pango.RunList{Data: test.runs[i], Next: line.Runs}
+		}
+		if left, right := line.GetOpticalMargins(); left != test.left || right != test.right {
+			t.Fatalf("expected margins (%d, %d), got (%d, %d)", test.left, test.right, left, right)
+		}
+	}
+}